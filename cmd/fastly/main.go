@@ -157,6 +157,11 @@ func main() {
 			}
 		}
 
+		codeError := fsterr.ExitCodeError{}
+		if errors.As(err, &codeError) {
+			os.Exit(codeError.Code)
+		}
+
 		os.Exit(1)
 	}
 }
@@ -36,8 +36,12 @@ type Interface interface {
 	ActivateVersion(*fastly.ActivateVersionInput) (*fastly.Version, error)
 	DeactivateVersion(*fastly.DeactivateVersionInput) (*fastly.Version, error)
 	LockVersion(*fastly.LockVersionInput) (*fastly.Version, error)
+	ValidateVersion(*fastly.ValidateVersionInput) (bool, string, error)
 	LatestVersion(*fastly.LatestVersionInput) (*fastly.Version, error)
 
+	GetSettings(*fastly.GetSettingsInput) (*fastly.Settings, error)
+	UpdateSettings(*fastly.UpdateSettingsInput) (*fastly.Settings, error)
+
 	CreateDomain(*fastly.CreateDomainInput) (*fastly.Domain, error)
 	ListDomains(*fastly.ListDomainsInput) ([]*fastly.Domain, error)
 	GetDomain(*fastly.GetDomainInput) (*fastly.Domain, error)
@@ -58,6 +62,51 @@ type Interface interface {
 	UpdateHealthCheck(*fastly.UpdateHealthCheckInput) (*fastly.HealthCheck, error)
 	DeleteHealthCheck(*fastly.DeleteHealthCheckInput) error
 
+	CreateCondition(*fastly.CreateConditionInput) (*fastly.Condition, error)
+	ListConditions(*fastly.ListConditionsInput) ([]*fastly.Condition, error)
+	GetCondition(*fastly.GetConditionInput) (*fastly.Condition, error)
+	UpdateCondition(*fastly.UpdateConditionInput) (*fastly.Condition, error)
+	DeleteCondition(*fastly.DeleteConditionInput) error
+
+	CreateHeader(*fastly.CreateHeaderInput) (*fastly.Header, error)
+	ListHeaders(*fastly.ListHeadersInput) ([]*fastly.Header, error)
+	GetHeader(*fastly.GetHeaderInput) (*fastly.Header, error)
+	UpdateHeader(*fastly.UpdateHeaderInput) (*fastly.Header, error)
+	DeleteHeader(*fastly.DeleteHeaderInput) error
+
+	CreateDirector(*fastly.CreateDirectorInput) (*fastly.Director, error)
+	ListDirectors(*fastly.ListDirectorsInput) ([]*fastly.Director, error)
+	GetDirector(*fastly.GetDirectorInput) (*fastly.Director, error)
+	UpdateDirector(*fastly.UpdateDirectorInput) (*fastly.Director, error)
+	DeleteDirector(*fastly.DeleteDirectorInput) error
+
+	CreateDirectorBackend(*fastly.CreateDirectorBackendInput) (*fastly.DirectorBackend, error)
+	DeleteDirectorBackend(*fastly.DeleteDirectorBackendInput) error
+
+	CreateCacheSetting(*fastly.CreateCacheSettingInput) (*fastly.CacheSetting, error)
+	ListCacheSettings(*fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error)
+	GetCacheSetting(*fastly.GetCacheSettingInput) (*fastly.CacheSetting, error)
+	UpdateCacheSetting(*fastly.UpdateCacheSettingInput) (*fastly.CacheSetting, error)
+	DeleteCacheSetting(*fastly.DeleteCacheSettingInput) error
+
+	CreateRequestSetting(*fastly.CreateRequestSettingInput) (*fastly.RequestSetting, error)
+	ListRequestSettings(*fastly.ListRequestSettingsInput) ([]*fastly.RequestSetting, error)
+	GetRequestSetting(*fastly.GetRequestSettingInput) (*fastly.RequestSetting, error)
+	UpdateRequestSetting(*fastly.UpdateRequestSettingInput) (*fastly.RequestSetting, error)
+	DeleteRequestSetting(*fastly.DeleteRequestSettingInput) error
+
+	CreateResponseObject(*fastly.CreateResponseObjectInput) (*fastly.ResponseObject, error)
+	ListResponseObjects(*fastly.ListResponseObjectsInput) ([]*fastly.ResponseObject, error)
+	GetResponseObject(*fastly.GetResponseObjectInput) (*fastly.ResponseObject, error)
+	UpdateResponseObject(*fastly.UpdateResponseObjectInput) (*fastly.ResponseObject, error)
+	DeleteResponseObject(*fastly.DeleteResponseObjectInput) error
+
+	CreateGzip(*fastly.CreateGzipInput) (*fastly.Gzip, error)
+	ListGzips(*fastly.ListGzipsInput) ([]*fastly.Gzip, error)
+	GetGzip(*fastly.GetGzipInput) (*fastly.Gzip, error)
+	UpdateGzip(*fastly.UpdateGzipInput) (*fastly.Gzip, error)
+	DeleteGzip(*fastly.DeleteGzipInput) error
+
 	GetPackage(*fastly.GetPackageInput) (*fastly.Package, error)
 	UpdatePackage(*fastly.UpdatePackageInput) (*fastly.Package, error)
 
@@ -236,6 +285,7 @@ type Interface interface {
 	GetVCL(*fastly.GetVCLInput) (*fastly.VCL, error)
 	UpdateVCL(*fastly.UpdateVCLInput) (*fastly.VCL, error)
 	DeleteVCL(*fastly.DeleteVCLInput) error
+	GetGeneratedVCL(*fastly.GetGeneratedVCLInput) (*fastly.VCL, error)
 
 	CreateSnippet(i *fastly.CreateSnippetInput) (*fastly.Snippet, error)
 	ListSnippets(i *fastly.ListSnippetsInput) ([]*fastly.Snippet, error)
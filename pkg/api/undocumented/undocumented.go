@@ -18,6 +18,12 @@ import (
 // EdgeComputeTrial is the API endpoint for activating a compute trial.
 const EdgeComputeTrial = "/customer/%s/edge-compute-trial"
 
+// Boilerplate is the API endpoint for retrieving the boilerplate VCL of a
+// service version.
+//
+// NOTE: This endpoint is documented but isn't supported by go-fastly.
+const Boilerplate = "/service/%s/version/%d/boilerplate"
+
 // RequestTimeout is the timeout for the API network request.
 const RequestTimeout = 5 * time.Second
 
@@ -40,20 +46,29 @@ func NewError(err error, statusCode int) APIError {
 	}
 }
 
-// Get calls the given API endpoint and returns its response data.
-func Get(host, path, token string, c api.HTTPClient) (data []byte, err error) {
-	host = strings.TrimSuffix(host, "/")
-	endpoint := fmt.Sprintf("%s%s", host, path)
+// CallOptions is used as input to Call().
+type CallOptions struct {
+	APIEndpoint string
+	HTTPClient  api.HTTPClient
+	Method      string
+	Path        string
+	Token       string
+}
+
+// Call calls the given API endpoint and returns its response data.
+func Call(opts CallOptions) (data []byte, err error) {
+	host := strings.TrimSuffix(opts.APIEndpoint, "/")
+	endpoint := fmt.Sprintf("%s%s", host, opts.Path)
 
-	req, err := http.NewRequest(http.MethodPost, endpoint, nil)
+	req, err := http.NewRequest(opts.Method, endpoint, nil)
 	if err != nil {
 		return data, NewError(err, 0)
 	}
 
-	req.Header.Set("Fastly-Key", token)
+	req.Header.Set("Fastly-Key", opts.Token)
 	req.Header.Set("User-Agent", useragent.Name)
 
-	res, err := c.Do(req)
+	res, err := opts.HTTPClient.Do(req)
 	if err != nil {
 		if urlErr, ok := err.(*url.Error); ok && urlErr.Timeout() {
 			return data, fsterr.RemediationError{
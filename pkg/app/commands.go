@@ -6,13 +6,18 @@ import (
 	"github.com/fastly/cli/pkg/commands/aclentry"
 	"github.com/fastly/cli/pkg/commands/authtoken"
 	"github.com/fastly/cli/pkg/commands/backend"
+	"github.com/fastly/cli/pkg/commands/cachesetting"
 	"github.com/fastly/cli/pkg/commands/compute"
+	"github.com/fastly/cli/pkg/commands/condition"
 	"github.com/fastly/cli/pkg/commands/config"
 	"github.com/fastly/cli/pkg/commands/configstore"
 	"github.com/fastly/cli/pkg/commands/configstoreentry"
 	"github.com/fastly/cli/pkg/commands/dictionary"
 	"github.com/fastly/cli/pkg/commands/dictionaryentry"
+	"github.com/fastly/cli/pkg/commands/director"
 	"github.com/fastly/cli/pkg/commands/domain"
+	"github.com/fastly/cli/pkg/commands/gzip"
+	"github.com/fastly/cli/pkg/commands/header"
 	"github.com/fastly/cli/pkg/commands/healthcheck"
 	"github.com/fastly/cli/pkg/commands/ip"
 	"github.com/fastly/cli/pkg/commands/kvstore"
@@ -48,7 +53,9 @@ import (
 	"github.com/fastly/cli/pkg/commands/profile"
 	"github.com/fastly/cli/pkg/commands/purge"
 	"github.com/fastly/cli/pkg/commands/ratelimit"
+	"github.com/fastly/cli/pkg/commands/requestsetting"
 	"github.com/fastly/cli/pkg/commands/resourcelink"
+	"github.com/fastly/cli/pkg/commands/responseobject"
 	"github.com/fastly/cli/pkg/commands/secretstore"
 	"github.com/fastly/cli/pkg/commands/secretstoreentry"
 	"github.com/fastly/cli/pkg/commands/service"
@@ -58,6 +65,8 @@ import (
 	"github.com/fastly/cli/pkg/commands/stats"
 	"github.com/fastly/cli/pkg/global"
 
+	directorBackend "github.com/fastly/cli/pkg/commands/director/backend"
+	serviceVersionSettings "github.com/fastly/cli/pkg/commands/serviceversion/settings"
 	tlsConfig "github.com/fastly/cli/pkg/commands/tls/config"
 	tlsCustom "github.com/fastly/cli/pkg/commands/tls/custom"
 	tlsCustomActivation "github.com/fastly/cli/pkg/commands/tls/custom/activation"
@@ -107,16 +116,30 @@ func defineCommands(
 	backendDescribe := backend.NewDescribeCommand(backendCmdRoot.CmdClause, g, m)
 	backendList := backend.NewListCommand(backendCmdRoot.CmdClause, g, m)
 	backendUpdate := backend.NewUpdateCommand(backendCmdRoot.CmdClause, g, m)
+	cacheSettingCmdRoot := cachesetting.NewRootCommand(app, g)
+	cacheSettingCreate := cachesetting.NewCreateCommand(cacheSettingCmdRoot.CmdClause, g, m)
+	cacheSettingDelete := cachesetting.NewDeleteCommand(cacheSettingCmdRoot.CmdClause, g, m)
+	cacheSettingDescribe := cachesetting.NewDescribeCommand(cacheSettingCmdRoot.CmdClause, g, m)
+	cacheSettingList := cachesetting.NewListCommand(cacheSettingCmdRoot.CmdClause, g, m)
+	cacheSettingUpdate := cachesetting.NewUpdateCommand(cacheSettingCmdRoot.CmdClause, g, m)
 	computeCmdRoot := compute.NewRootCommand(app, g)
 	computeBuild := compute.NewBuildCommand(computeCmdRoot.CmdClause, g, m)
 	computeDeploy := compute.NewDeployCommand(computeCmdRoot.CmdClause, g, m)
 	computeHashsum := compute.NewHashsumCommand(computeCmdRoot.CmdClause, g, computeBuild, m)
 	computeInit := compute.NewInitCommand(computeCmdRoot.CmdClause, g, m)
+	computeInspect := compute.NewInspectCommand(computeCmdRoot.CmdClause, g, m)
 	computePack := compute.NewPackCommand(computeCmdRoot.CmdClause, g, m)
 	computePublish := compute.NewPublishCommand(computeCmdRoot.CmdClause, g, computeBuild, computeDeploy, m)
 	computeServe := compute.NewServeCommand(computeCmdRoot.CmdClause, g, computeBuild, opts.Versioners.Viceroy, m)
+	computeTest := compute.NewTestCommand(computeCmdRoot.CmdClause, g, computeBuild, opts.Versioners.Viceroy, m)
 	computeUpdate := compute.NewUpdateCommand(computeCmdRoot.CmdClause, g, m)
 	computeValidate := compute.NewValidateCommand(computeCmdRoot.CmdClause, g, m)
+	conditionCmdRoot := condition.NewRootCommand(app, g)
+	conditionCreate := condition.NewCreateCommand(conditionCmdRoot.CmdClause, g, m)
+	conditionDelete := condition.NewDeleteCommand(conditionCmdRoot.CmdClause, g, m)
+	conditionDescribe := condition.NewDescribeCommand(conditionCmdRoot.CmdClause, g, m)
+	conditionList := condition.NewListCommand(conditionCmdRoot.CmdClause, g, m)
+	conditionUpdate := condition.NewUpdateCommand(conditionCmdRoot.CmdClause, g, m)
 	configCmdRoot := config.NewRootCommand(app, g)
 	configstoreCmdRoot := configstore.NewRootCommand(app, g)
 	configstoreCreate := configstore.NewCreateCommand(configstoreCmdRoot.CmdClause, g, m)
@@ -143,6 +166,15 @@ func defineCommands(
 	dictionaryEntryUpdate := dictionaryentry.NewUpdateCommand(dictionaryEntryCmdRoot.CmdClause, g, m)
 	dictionaryList := dictionary.NewListCommand(dictionaryCmdRoot.CmdClause, g, m)
 	dictionaryUpdate := dictionary.NewUpdateCommand(dictionaryCmdRoot.CmdClause, g, m)
+	directorCmdRoot := director.NewRootCommand(app, g)
+	directorCreate := director.NewCreateCommand(directorCmdRoot.CmdClause, g, m)
+	directorDelete := director.NewDeleteCommand(directorCmdRoot.CmdClause, g, m)
+	directorDescribe := director.NewDescribeCommand(directorCmdRoot.CmdClause, g, m)
+	directorList := director.NewListCommand(directorCmdRoot.CmdClause, g, m)
+	directorUpdate := director.NewUpdateCommand(directorCmdRoot.CmdClause, g, m)
+	directorBackendCmdRoot := directorBackend.NewRootCommand(directorCmdRoot.CmdClause, g)
+	directorBackendAdd := directorBackend.NewAddCommand(directorBackendCmdRoot.CmdClause, g, m)
+	directorBackendRemove := directorBackend.NewRemoveCommand(directorBackendCmdRoot.CmdClause, g, m)
 	domainCmdRoot := domain.NewRootCommand(app, g)
 	domainCreate := domain.NewCreateCommand(domainCmdRoot.CmdClause, g, m)
 	domainDelete := domain.NewDeleteCommand(domainCmdRoot.CmdClause, g, m)
@@ -150,6 +182,18 @@ func defineCommands(
 	domainList := domain.NewListCommand(domainCmdRoot.CmdClause, g, m)
 	domainUpdate := domain.NewUpdateCommand(domainCmdRoot.CmdClause, g, m)
 	domainValidate := domain.NewValidateCommand(domainCmdRoot.CmdClause, g, m)
+	gzipCmdRoot := gzip.NewRootCommand(app, g)
+	gzipCreate := gzip.NewCreateCommand(gzipCmdRoot.CmdClause, g, m)
+	gzipDelete := gzip.NewDeleteCommand(gzipCmdRoot.CmdClause, g, m)
+	gzipDescribe := gzip.NewDescribeCommand(gzipCmdRoot.CmdClause, g, m)
+	gzipList := gzip.NewListCommand(gzipCmdRoot.CmdClause, g, m)
+	gzipUpdate := gzip.NewUpdateCommand(gzipCmdRoot.CmdClause, g, m)
+	headerCmdRoot := header.NewRootCommand(app, g)
+	headerCreate := header.NewCreateCommand(headerCmdRoot.CmdClause, g, m)
+	headerDelete := header.NewDeleteCommand(headerCmdRoot.CmdClause, g, m)
+	headerDescribe := header.NewDescribeCommand(headerCmdRoot.CmdClause, g, m)
+	headerList := header.NewListCommand(headerCmdRoot.CmdClause, g, m)
+	headerUpdate := header.NewUpdateCommand(headerCmdRoot.CmdClause, g, m)
 	healthcheckCmdRoot := healthcheck.NewRootCommand(app, g)
 	healthcheckCreate := healthcheck.NewCreateCommand(healthcheckCmdRoot.CmdClause, g, m)
 	healthcheckDelete := healthcheck.NewDeleteCommand(healthcheckCmdRoot.CmdClause, g, m)
@@ -166,7 +210,10 @@ func defineCommands(
 	kvstoreentryCreate := kvstoreentry.NewCreateCommand(kvstoreentryCmdRoot.CmdClause, g, m)
 	kvstoreentryDelete := kvstoreentry.NewDeleteCommand(kvstoreentryCmdRoot.CmdClause, g, m)
 	kvstoreentryDescribe := kvstoreentry.NewDescribeCommand(kvstoreentryCmdRoot.CmdClause, g, m)
+	kvstoreentryExport := kvstoreentry.NewExportCommand(kvstoreentryCmdRoot.CmdClause, g, m)
+	kvstoreentryImport := kvstoreentry.NewImportCommand(kvstoreentryCmdRoot.CmdClause, g, m)
 	kvstoreentryList := kvstoreentry.NewListCommand(kvstoreentryCmdRoot.CmdClause, g, m)
+	kvstoreentrySync := kvstoreentry.NewSyncCommand(kvstoreentryCmdRoot.CmdClause, g, m)
 	logtailCmdRoot := logtail.NewRootCommand(app, g, m)
 	loggingCmdRoot := logging.NewRootCommand(app, g)
 	loggingAzureblobCmdRoot := azureblob.NewRootCommand(loggingCmdRoot.CmdClause, g)
@@ -334,12 +381,24 @@ func defineCommands(
 	rateLimitDescribe := ratelimit.NewDescribeCommand(rateLimitCmdRoot.CmdClause, g, m)
 	rateLimitList := ratelimit.NewListCommand(rateLimitCmdRoot.CmdClause, g, m)
 	rateLimitUpdate := ratelimit.NewUpdateCommand(rateLimitCmdRoot.CmdClause, g, m)
+	requestSettingCmdRoot := requestsetting.NewRootCommand(app, g)
+	requestSettingCreate := requestsetting.NewCreateCommand(requestSettingCmdRoot.CmdClause, g, m)
+	requestSettingDelete := requestsetting.NewDeleteCommand(requestSettingCmdRoot.CmdClause, g, m)
+	requestSettingDescribe := requestsetting.NewDescribeCommand(requestSettingCmdRoot.CmdClause, g, m)
+	requestSettingList := requestsetting.NewListCommand(requestSettingCmdRoot.CmdClause, g, m)
+	requestSettingUpdate := requestsetting.NewUpdateCommand(requestSettingCmdRoot.CmdClause, g, m)
 	resourcelinkCmdRoot := resourcelink.NewRootCommand(app, g)
 	resourcelinkCreate := resourcelink.NewCreateCommand(resourcelinkCmdRoot.CmdClause, g, m)
 	resourcelinkDelete := resourcelink.NewDeleteCommand(resourcelinkCmdRoot.CmdClause, g, m)
 	resourcelinkDescribe := resourcelink.NewDescribeCommand(resourcelinkCmdRoot.CmdClause, g, m)
 	resourcelinkList := resourcelink.NewListCommand(resourcelinkCmdRoot.CmdClause, g, m)
 	resourcelinkUpdate := resourcelink.NewUpdateCommand(resourcelinkCmdRoot.CmdClause, g, m)
+	responseObjectCmdRoot := responseobject.NewRootCommand(app, g)
+	responseObjectCreate := responseobject.NewCreateCommand(responseObjectCmdRoot.CmdClause, g, m)
+	responseObjectDelete := responseobject.NewDeleteCommand(responseObjectCmdRoot.CmdClause, g, m)
+	responseObjectDescribe := responseobject.NewDescribeCommand(responseObjectCmdRoot.CmdClause, g, m)
+	responseObjectList := responseobject.NewListCommand(responseObjectCmdRoot.CmdClause, g, m)
+	responseObjectUpdate := responseobject.NewUpdateCommand(responseObjectCmdRoot.CmdClause, g, m)
 	secretstoreCmdRoot := secretstore.NewRootCommand(app, g)
 	secretstoreCreate := secretstore.NewCreateCommand(secretstoreCmdRoot.CmdClause, g, m)
 	secretstoreDescribe := secretstore.NewDescribeCommand(secretstoreCmdRoot.CmdClause, g, m)
@@ -349,6 +408,7 @@ func defineCommands(
 	secretstoreentryCreate := secretstoreentry.NewCreateCommand(secretstoreentryCmdRoot.CmdClause, g, m)
 	secretstoreentryDescribe := secretstoreentry.NewDescribeCommand(secretstoreentryCmdRoot.CmdClause, g, m)
 	secretstoreentryDelete := secretstoreentry.NewDeleteCommand(secretstoreentryCmdRoot.CmdClause, g, m)
+	secretstoreentryImport := secretstoreentry.NewImportCommand(secretstoreentryCmdRoot.CmdClause, g, m)
 	secretstoreentryList := secretstoreentry.NewListCommand(secretstoreentryCmdRoot.CmdClause, g, m)
 	serviceCmdRoot := service.NewRootCommand(app, g)
 	serviceCreate := service.NewCreateCommand(serviceCmdRoot.CmdClause, g)
@@ -365,11 +425,18 @@ func defineCommands(
 	serviceauthUpdate := serviceauth.NewUpdateCommand(serviceauthCmdRoot.CmdClause, g, m)
 	serviceVersionCmdRoot := serviceversion.NewRootCommand(app, g)
 	serviceVersionActivate := serviceversion.NewActivateCommand(serviceVersionCmdRoot.CmdClause, g, m)
+	serviceVersionApply := serviceversion.NewApplyCommand(serviceVersionCmdRoot.CmdClause, g, m)
 	serviceVersionClone := serviceversion.NewCloneCommand(serviceVersionCmdRoot.CmdClause, g, m)
 	serviceVersionDeactivate := serviceversion.NewDeactivateCommand(serviceVersionCmdRoot.CmdClause, g, m)
+	serviceVersionDiff := serviceversion.NewDiffCommand(serviceVersionCmdRoot.CmdClause, g, m)
+	serviceVersionExport := serviceversion.NewExportCommand(serviceVersionCmdRoot.CmdClause, g, m)
 	serviceVersionList := serviceversion.NewListCommand(serviceVersionCmdRoot.CmdClause, g, m)
 	serviceVersionLock := serviceversion.NewLockCommand(serviceVersionCmdRoot.CmdClause, g, m)
+	serviceVersionRollback := serviceversion.NewRollbackCommand(serviceVersionCmdRoot.CmdClause, g, m)
 	serviceVersionUpdate := serviceversion.NewUpdateCommand(serviceVersionCmdRoot.CmdClause, g, m)
+	serviceVersionSettingsCmdRoot := serviceVersionSettings.NewRootCommand(serviceVersionCmdRoot.CmdClause, g)
+	serviceVersionSettingsDescribe := serviceVersionSettings.NewDescribeCommand(serviceVersionSettingsCmdRoot.CmdClause, g, m)
+	serviceVersionSettingsUpdate := serviceVersionSettings.NewUpdateCommand(serviceVersionSettingsCmdRoot.CmdClause, g, m)
 	statsCmdRoot := stats.NewRootCommand(app, g)
 	statsHistorical := stats.NewHistoricalCommand(statsCmdRoot.CmdClause, g, m)
 	statsRealtime := stats.NewRealtimeCommand(statsCmdRoot.CmdClause, g, m)
@@ -418,12 +485,14 @@ func defineCommands(
 	userList := user.NewListCommand(userCmdRoot.CmdClause, g, m)
 	userUpdate := user.NewUpdateCommand(userCmdRoot.CmdClause, g, m)
 	vclCmdRoot := vcl.NewRootCommand(app, g)
+	vclBoilerplate := vcl.NewBoilerplateCommand(vclCmdRoot.CmdClause, g, m)
 	vclCustomCmdRoot := custom.NewRootCommand(vclCmdRoot.CmdClause, g)
 	vclCustomCreate := custom.NewCreateCommand(vclCustomCmdRoot.CmdClause, g, m)
 	vclCustomDelete := custom.NewDeleteCommand(vclCustomCmdRoot.CmdClause, g, m)
 	vclCustomDescribe := custom.NewDescribeCommand(vclCustomCmdRoot.CmdClause, g, m)
 	vclCustomList := custom.NewListCommand(vclCustomCmdRoot.CmdClause, g, m)
 	vclCustomUpdate := custom.NewUpdateCommand(vclCustomCmdRoot.CmdClause, g, m)
+	vclGenerated := vcl.NewGeneratedCommand(vclCmdRoot.CmdClause, g, m)
 	vclSnippetCmdRoot := snippet.NewRootCommand(vclCmdRoot.CmdClause, g)
 	vclSnippetCreate := snippet.NewCreateCommand(vclSnippetCmdRoot.CmdClause, g, m)
 	vclSnippetDelete := snippet.NewDeleteCommand(vclSnippetCmdRoot.CmdClause, g, m)
@@ -458,16 +527,30 @@ func defineCommands(
 		backendDescribe,
 		backendList,
 		backendUpdate,
+		cacheSettingCmdRoot,
+		cacheSettingCreate,
+		cacheSettingDelete,
+		cacheSettingDescribe,
+		cacheSettingList,
+		cacheSettingUpdate,
 		computeBuild,
 		computeCmdRoot,
 		computeDeploy,
 		computeHashsum,
 		computeInit,
+		computeInspect,
 		computePack,
 		computePublish,
 		computeServe,
+		computeTest,
 		computeUpdate,
 		computeValidate,
+		conditionCmdRoot,
+		conditionCreate,
+		conditionDelete,
+		conditionDescribe,
+		conditionList,
+		conditionUpdate,
 		configCmdRoot,
 		configstoreCmdRoot,
 		configstoreCreate,
@@ -494,6 +577,15 @@ func defineCommands(
 		dictionaryEntryUpdate,
 		dictionaryList,
 		dictionaryUpdate,
+		directorCmdRoot,
+		directorCreate,
+		directorDelete,
+		directorDescribe,
+		directorList,
+		directorUpdate,
+		directorBackendCmdRoot,
+		directorBackendAdd,
+		directorBackendRemove,
 		domainCmdRoot,
 		domainCreate,
 		domainDelete,
@@ -501,6 +593,18 @@ func defineCommands(
 		domainList,
 		domainUpdate,
 		domainValidate,
+		gzipCmdRoot,
+		gzipCreate,
+		gzipDelete,
+		gzipDescribe,
+		gzipList,
+		gzipUpdate,
+		headerCmdRoot,
+		headerCreate,
+		headerDelete,
+		headerDescribe,
+		headerList,
+		headerUpdate,
 		healthcheckCmdRoot,
 		healthcheckCreate,
 		healthcheckDelete,
@@ -515,7 +619,10 @@ func defineCommands(
 		kvstoreentryCreate,
 		kvstoreentryDelete,
 		kvstoreentryDescribe,
+		kvstoreentryExport,
+		kvstoreentryImport,
 		kvstoreentryList,
+		kvstoreentrySync,
 		logtailCmdRoot,
 		loggingAzureblobCmdRoot,
 		loggingAzureblobCreate,
@@ -683,12 +790,24 @@ func defineCommands(
 		rateLimitDescribe,
 		rateLimitList,
 		rateLimitUpdate,
+		requestSettingCmdRoot,
+		requestSettingCreate,
+		requestSettingDelete,
+		requestSettingDescribe,
+		requestSettingList,
+		requestSettingUpdate,
 		resourcelinkCmdRoot,
 		resourcelinkCreate,
 		resourcelinkDelete,
 		resourcelinkDescribe,
 		resourcelinkList,
 		resourcelinkUpdate,
+		responseObjectCmdRoot,
+		responseObjectCreate,
+		responseObjectDelete,
+		responseObjectDescribe,
+		responseObjectList,
+		responseObjectUpdate,
 		secretstoreCreate,
 		secretstoreDescribe,
 		secretstoreDelete,
@@ -696,6 +815,7 @@ func defineCommands(
 		secretstoreentryCreate,
 		secretstoreentryDescribe,
 		secretstoreentryDelete,
+		secretstoreentryImport,
 		secretstoreentryList,
 		serviceCmdRoot,
 		serviceCreate,
@@ -711,12 +831,19 @@ func defineCommands(
 		serviceauthList,
 		serviceauthUpdate,
 		serviceVersionActivate,
+		serviceVersionApply,
 		serviceVersionClone,
 		serviceVersionCmdRoot,
 		serviceVersionDeactivate,
+		serviceVersionDiff,
+		serviceVersionExport,
 		serviceVersionList,
 		serviceVersionLock,
+		serviceVersionRollback,
 		serviceVersionUpdate,
+		serviceVersionSettingsCmdRoot,
+		serviceVersionSettingsDescribe,
+		serviceVersionSettingsUpdate,
 		statsCmdRoot,
 		statsHistorical,
 		statsRealtime,
@@ -764,6 +891,7 @@ func defineCommands(
 		userDescribe,
 		userList,
 		userUpdate,
+		vclBoilerplate,
 		vclCmdRoot,
 		vclCustomCmdRoot,
 		vclCustomCreate,
@@ -771,6 +899,7 @@ func defineCommands(
 		vclCustomDescribe,
 		vclCustomList,
 		vclCustomUpdate,
+		vclGenerated,
 		vclSnippetCmdRoot,
 		vclSnippetCreate,
 		vclSnippetDelete,
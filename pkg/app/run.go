@@ -115,6 +115,14 @@ func Run(opts RunOpts) error {
 		md.File.SetQuiet(true)
 	}
 
+	// NOTE: The --env flag is defined by the compute commands, but the
+	// environment overlay has to be applied before the profile is resolved.
+	if g.Flags.Env != "" {
+		if err := md.File.ApplyEnv(g.Flags.Env); err != nil {
+			return err
+		}
+	}
+
 	token, source := g.Token()
 
 	if g.Verbose() {
@@ -122,7 +130,7 @@ func Run(opts RunOpts) error {
 			source,
 			opts.Stdout,
 			env.Token,
-			determineProfile(md.File.Profile, g.Flags.Profile, g.Config.Profiles),
+			determineProfile(md, g.Flags.Profile, g.Config.Profiles),
 		)
 	}
 
@@ -232,8 +240,11 @@ func displayTokenSource(source lookup.Source, out io.Writer, token, profileSourc
 // determineProfile determines if the provided token was acquired via the
 // fastly.toml manifest, the --profile flag, or was a default profile from
 // within the config.toml application configuration.
-func determineProfile(manifestValue, flagValue string, profiles config.Profiles) string {
-	if manifestValue != "" {
+func determineProfile(md manifest.Data, flagValue string, profiles config.Profiles) string {
+	if manifestValue, source := md.Profile(); manifestValue != "" {
+		if source == manifest.SourceOverlay {
+			return manifestValue + " -- via " + md.File.OverlaySource("profile")
+		}
 		return manifestValue + " -- via fastly.toml"
 	}
 	if flagValue != "" {
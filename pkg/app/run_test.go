@@ -61,13 +61,18 @@ acl
 acl-entry
 auth-token
 backend
+cache-setting
 compute
+condition
 config
 config-store
 config-store-entry
 dictionary
 dictionary-entry
+director
 domain
+gzip
+header
 healthcheck
 ip-list
 kv-store
@@ -78,7 +83,9 @@ pops
 profile
 purge
 rate-limit
+request-setting
 resource-link
+response-object
 secret-store
 secret-store-entry
 service
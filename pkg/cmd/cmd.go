@@ -98,6 +98,12 @@ type OptionalInt struct {
 	Value int
 }
 
+// OptionalUint models an optional uint flag value.
+type OptionalUint struct {
+	Optional
+	Value uint
+}
+
 // ServiceDetailsOpts provides data and behaviours required by the
 // ServiceDetails function.
 type ServiceDetailsOpts struct {
@@ -152,6 +158,12 @@ func ServiceID(serviceName OptionalServiceNameID, data manifest.Data, client api
 	flag = "--service-id"
 	serviceID, source = data.ServiceID()
 
+	// NOTE: For an overlay, the 'flag' describes which overlay was used so it
+	// can be displayed by DisplayServiceID.
+	if source == manifest.SourceOverlay {
+		flag = data.File.OverlaySource("service_id")
+	}
+
 	if source == manifest.SourceUndefined {
 		if !serviceName.WasSet {
 			err = fsterr.ErrNoServiceID
@@ -181,6 +193,8 @@ func DisplayServiceID(sid, flag string, s manifest.Source, out io.Writer) {
 		via = fmt.Sprintf(" (via %s)", flag)
 	case manifest.SourceFile:
 		via = fmt.Sprintf(" (via %s)", manifest.Filename)
+	case manifest.SourceOverlay:
+		via = fmt.Sprintf(" (via %s)", flag)
 	case manifest.SourceEnv:
 		via = fmt.Sprintf(" (via %s)", env.ServiceID)
 	case manifest.SourceUndefined:
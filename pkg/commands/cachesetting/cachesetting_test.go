@@ -0,0 +1,323 @@
+package cachesetting_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+func TestCacheSettingCreate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("cache-setting create --service-id 123 --name example"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name:      "validate invalid --action flag",
+			Args:      args("cache-setting create --service-id 123 --version 1 --action lookup"),
+			WantError: "error parsing arguments: enum value must be one of cache,pass,restart, got 'lookup'",
+		},
+		{
+			Name: "validate CreateCacheSetting API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateCacheSettingFn: func(i *fastly.CreateCacheSettingInput) (*fastly.CacheSetting, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("cache-setting create --service-id 123 --version 1 --name example --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate CreateCacheSetting API success",
+			API: mock.API{
+				ListVersionsFn:       testutil.ListVersions,
+				CloneVersionFn:       testutil.CloneVersionResult(4),
+				CreateCacheSettingFn: createCacheSettingOK,
+			},
+			Args:       args("cache-setting create --service-id 123 --version 1 --name example --action pass --ttl 300 --stale-ttl 60 --autoclone"),
+			WantOutput: "Created cache setting example (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestCacheSettingList(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate ListCacheSettings API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListCacheSettingsFn: func(i *fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("cache-setting list --service-id 123 --version 1"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate ListCacheSettings API success",
+			API: mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				ListCacheSettingsFn: listCacheSettingsOK,
+			},
+			Args:       args("cache-setting list --service-id 123 --version 1"),
+			WantOutput: listCacheSettingsShortOutput,
+		},
+		{
+			Name: "validate --verbose flag",
+			API: mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				ListCacheSettingsFn: listCacheSettingsOK,
+			},
+			Args:       args("cache-setting list --service-id 123 --version 1 --verbose"),
+			WantOutput: listCacheSettingsVerboseOutput,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestCacheSettingDescribe(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("cache-setting describe --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate GetCacheSetting API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetCacheSettingFn: func(i *fastly.GetCacheSettingInput) (*fastly.CacheSetting, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("cache-setting describe --service-id 123 --version 1 --name example"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate GetCacheSetting API success",
+			API: mock.API{
+				ListVersionsFn:    testutil.ListVersions,
+				GetCacheSettingFn: getCacheSettingOK,
+			},
+			Args:       args("cache-setting describe --service-id 123 --version 1 --name example"),
+			WantOutput: describeCacheSettingOutput,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestCacheSettingUpdate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("cache-setting update --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate UpdateCacheSetting API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				UpdateCacheSettingFn: func(i *fastly.UpdateCacheSettingInput) (*fastly.CacheSetting, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("cache-setting update --service-id 123 --version 1 --name example --new-name renamed --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate UpdateCacheSetting API success",
+			API: mock.API{
+				ListVersionsFn:       testutil.ListVersions,
+				CloneVersionFn:       testutil.CloneVersionResult(4),
+				UpdateCacheSettingFn: updateCacheSettingOK,
+			},
+			Args:       args("cache-setting update --service-id 123 --version 1 --name example --new-name renamed --action restart --autoclone"),
+			WantOutput: "Updated cache setting renamed (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestCacheSettingDelete(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("cache-setting delete --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate DeleteCacheSetting API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				DeleteCacheSettingFn: func(i *fastly.DeleteCacheSettingInput) error {
+					return testutil.Err
+				},
+			},
+			Args:      args("cache-setting delete --service-id 123 --version 1 --name example --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate DeleteCacheSetting API success",
+			API: mock.API{
+				ListVersionsFn:       testutil.ListVersions,
+				CloneVersionFn:       testutil.CloneVersionResult(4),
+				DeleteCacheSettingFn: func(i *fastly.DeleteCacheSettingInput) error { return nil },
+			},
+			Args:       args("cache-setting delete --service-id 123 --version 1 --name example --autoclone"),
+			WantOutput: "Deleted cache setting example (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func createCacheSettingOK(i *fastly.CreateCacheSettingInput) (*fastly.CacheSetting, error) {
+	if i.Action == nil || *i.Action != fastly.CacheSettingActionPass || *i.TTL != 300 || *i.StaleTTL != 60 {
+		return nil, testutil.Err
+	}
+	return &fastly.CacheSetting{
+		Action:         *i.Action,
+		Name:           *i.Name,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		StaleTTL:       *i.StaleTTL,
+		TTL:            *i.TTL,
+	}, nil
+}
+
+func listCacheSettingsOK(i *fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error) {
+	return []*fastly.CacheSetting{
+		{
+			Action:         fastly.CacheSettingActionCache,
+			CacheCondition: "is-static",
+			Name:           "static",
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+			StaleTTL:       60,
+			TTL:            3600,
+		},
+		{
+			Action:         fastly.CacheSettingActionPass,
+			Name:           "no-cache",
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+		},
+	}, nil
+}
+
+var listCacheSettingsShortOutput = strings.Join([]string{
+	"SERVICE  VERSION  NAME      ACTION  TTL   STALE TTL  CACHE CONDITION",
+	"123      1        static    cache   3600  60         is-static",
+	"123      1        no-cache  pass    0     0",
+}, "\n")
+
+var listCacheSettingsVerboseOutput = strings.Join([]string{
+	"Version: 1",
+	"\tCache setting 1/2",
+	"\t\tName: static",
+	"\t\tAction: cache",
+	"\t\tTTL: 3600",
+	"\t\tStale TTL: 60",
+	"\t\tCache condition: is-static",
+	"\tCache setting 2/2",
+	"\t\tName: no-cache",
+	"\t\tAction: pass",
+}, "\n")
+
+func getCacheSettingOK(i *fastly.GetCacheSettingInput) (*fastly.CacheSetting, error) {
+	return &fastly.CacheSetting{
+		Action:         fastly.CacheSettingActionCache,
+		Name:           i.Name,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		StaleTTL:       60,
+		TTL:            3600,
+	}, nil
+}
+
+var describeCacheSettingOutput = `
+Service ID: 123
+Version: 1
+Name: example
+Action: cache
+TTL: 3600
+Stale TTL: 60
+Cache condition: 
+`
+
+func updateCacheSettingOK(i *fastly.UpdateCacheSettingInput) (*fastly.CacheSetting, error) {
+	if i.Action != fastly.CacheSettingActionRestart {
+		return nil, testutil.Err
+	}
+	return &fastly.CacheSetting{
+		Action:         i.Action,
+		Name:           *i.NewName,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}, nil
+}
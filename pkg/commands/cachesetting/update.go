@@ -0,0 +1,128 @@
+package cachesetting
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// UpdateCommand calls the Fastly API to update cache settings.
+type UpdateCommand struct {
+	cmd.Base
+	manifest       manifest.Data
+	input          fastly.UpdateCacheSettingInput
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	autoClone      cmd.OptionalAutoClone
+
+	NewName        cmd.OptionalString
+	Action         cmd.OptionalString
+	TTL            cmd.OptionalInt
+	StaleTTL       cmd.OptionalInt
+	CacheCondition cmd.OptionalString
+}
+
+// NewUpdateCommand returns a usable command registered under the parent.
+func NewUpdateCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *UpdateCommand {
+	c := UpdateCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+	c.CmdClause = parent.Command("update", "Update a cache setting on a Fastly service version")
+
+	// required
+	c.CmdClause.Flag("name", "Cache setting name").Short('n').Required().StringVar(&c.input.Name)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// optional
+	// NOTE: Actions is defined in the same cachesetting package inside create.go
+	c.CmdClause.Flag("action", "The vcl_fetch behaviour to apply").Action(c.Action.Set).HintOptions(Actions...).EnumVar(&c.Action.Value, Actions...)
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("cache-condition", "Name of the cache condition controlling when this cache setting applies").Action(c.CacheCondition.Set).StringVar(&c.CacheCondition.Value)
+	c.CmdClause.Flag("new-name", "New cache setting name").Action(c.NewName.Set).StringVar(&c.NewName.Value)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("stale-ttl", "Maximum time in seconds to continue to use a stale version of the object if future requests to your backend server fail").Action(c.StaleTTL.Set).IntVar(&c.StaleTTL.Value)
+	c.CmdClause.Flag("ttl", "Maximum time in seconds to consider the object fresh in the cache").Action(c.TTL.Set).IntVar(&c.TTL.Value)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *UpdateCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flags.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	c.input.ServiceID = serviceID
+	c.input.ServiceVersion = serviceVersion.Number
+
+	if c.NewName.WasSet {
+		c.input.NewName = &c.NewName.Value
+	}
+
+	if c.Action.WasSet {
+		c.input.Action = fastly.CacheSettingAction(c.Action.Value)
+	}
+
+	if c.TTL.WasSet {
+		c.input.TTL = &c.TTL.Value
+	}
+
+	if c.StaleTTL.WasSet {
+		c.input.StaleTTL = &c.StaleTTL.Value
+	}
+
+	if c.CacheCondition.WasSet {
+		c.input.CacheCondition = &c.CacheCondition.Value
+	}
+
+	s, err := c.Globals.APIClient.UpdateCacheSetting(&c.input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Updated cache setting %s (service %s version %d)", s.Name, s.ServiceID, s.ServiceVersion)
+	return nil
+}
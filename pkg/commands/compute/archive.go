@@ -0,0 +1,137 @@
+package compute
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/fastly/cli/pkg/filesystem"
+)
+
+// SourceDateEpochEnvVar is the standard environment variable for overriding
+// the timestamp recorded in a reproducible build.
+//
+// See https://reproducible-builds.org/specs/source-date-epoch/
+const SourceDateEpochEnvVar = "SOURCE_DATE_EPOCH"
+
+const (
+	// archiveDirMode is the mode recorded for every directory in a package.
+	archiveDirMode = 0o755
+	// archiveFileMode is the mode recorded for every file in a package.
+	archiveFileMode = 0o644
+)
+
+// writePackageArchive writes the directory tree at root to a tar.gz archive at
+// destination, with the base of root as the top-level directory.
+//
+// NOTE: The archive is reproducible, so identical files produce an identical
+// archive regardless of the machine or the time it was created on. Entries are
+// written in lexical order, every modification time is fixed (to the Unix
+// epoch, or $SOURCE_DATE_EPOCH if set), ownership is removed and modes are
+// normalised. The gzip header records neither a name nor a timestamp.
+func writePackageArchive(root, destination string) (err error) {
+	mtime, err := archiveModTime()
+	if err != nil {
+		return err
+	}
+
+	if err := filesystem.MakeDirectoryIfNotExists(filepath.Dir(destination)); err != nil {
+		return fmt.Errorf("error creating package directory: %w", err)
+	}
+
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as we trust the source of the filepath variable.
+	/* #nosec */
+	f, err := os.Create(destination)
+	if err != nil {
+		return fmt.Errorf("error creating package archive: %w", err)
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil && cerr != nil {
+			err = fmt.Errorf("error closing package archive: %w", cerr)
+		}
+	}()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	parent := filepath.Dir(root)
+	err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(parent, path)
+		if err != nil {
+			return err
+		}
+
+		hdr := &tar.Header{
+			Format:  tar.FormatPAX,
+			ModTime: mtime,
+			Name:    filepath.ToSlash(name),
+		}
+		if entry.IsDir() {
+			hdr.Typeflag = tar.TypeDir
+			hdr.Mode = archiveDirMode
+			hdr.Name += "/"
+			return tw.WriteHeader(hdr)
+		}
+		if !entry.Type().IsRegular() {
+			return fmt.Errorf("unsupported file type: %s", path)
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		hdr.Typeflag = tar.TypeReg
+		hdr.Mode = archiveFileMode
+		hdr.Size = info.Size()
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		// gosec flagged this:
+		// G304 (CWE-22): Potential file inclusion via variable
+		// Disabling as we trust the source of the filepath variable.
+		/* #nosec */
+		src, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer src.Close() // #nosec G307
+		_, err = io.Copy(tw, src)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error writing package archive: %w", err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("error writing package archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("error writing package archive: %w", err)
+	}
+	return nil
+}
+
+// archiveModTime returns the modification time recorded for package entries.
+func archiveModTime() (time.Time, error) {
+	epoch := os.Getenv(SourceDateEpochEnvVar)
+	if epoch == "" {
+		return time.Unix(0, 0).UTC(), nil
+	}
+	seconds, err := strconv.ParseInt(epoch, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid $%s '%s': %w", SourceDateEpochEnvVar, epoch, err)
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
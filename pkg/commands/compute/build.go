@@ -17,7 +17,6 @@ import (
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
 	"github.com/kennygrant/sanitize"
-	"github.com/mholt/archiver/v3"
 )
 
 // IgnoreFilePath is the filepath name of the Fastly ignore file.
@@ -29,6 +28,7 @@ const CustomPostBuildScriptMessage = "This project has a custom post build scrip
 
 // Flags represents the flags defined for the command.
 type Flags struct {
+	Force       bool
 	IncludeSrc  bool
 	Lang        string
 	PackageName string
@@ -54,6 +54,8 @@ func NewBuildCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *Bu
 
 	// NOTE: when updating these flags, be sure to update the composite commands:
 	// `compute publish` and `compute serve`.
+	c.CmdClause.Flag("env", "The environment configuration to use (e.g. stage)").StringVar(&c.Globals.Flags.Env)
+	c.CmdClause.Flag("force", "Rebuild the package even if no source files have changed since the last build").BoolVar(&c.Flags.Force)
 	c.CmdClause.Flag("include-source", "Include source code in built package").BoolVar(&c.Flags.IncludeSrc)
 	c.CmdClause.Flag("language", "Language type").StringVar(&c.Flags.Lang)
 	c.CmdClause.Flag("package-name", "Package name").StringVar(&c.Flags.PackageName)
@@ -167,6 +169,23 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return err
 	}
 
+	dest := filepath.Join("pkg", fmt.Sprintf("%s.tar.gz", packageName))
+
+	// NOTE: A failure to hash the build inputs isn't fatal, the build just
+	// can't be skipped (or skipped next time).
+	hash, err := buildHash(language, c.Flags, c.Globals.Flags.Env, dest)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		hash = ""
+	}
+	if !c.Flags.Force && buildIsCurrent(hash, dest) {
+		text.Info(out, "No changes detected since the last build, skipping the build (use --force to rebuild)")
+		text.Break(out)
+		out = originalOut
+		text.Success(out, "Built package (%s)", dest)
+		return nil
+	}
+
 	if err := language.Build(); err != nil {
 		c.Globals.ErrLog.AddWithContext(err, map[string]any{
 			"Language": language.Name,
@@ -181,8 +200,6 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	msg = "Creating package archive"
 	spinner.Message(msg + "...")
 
-	dest := filepath.Join("pkg", fmt.Sprintf("%s.tar.gz", packageName))
-
 	// NOTE: The minimum package requirement is `fastly.toml` and `main.wasm`.
 	files := []string{
 		manifest.Filename,
@@ -221,11 +238,42 @@ func (c *BuildCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return err
 	}
 
+	if err := writeBuildHash(hash); err != nil {
+		c.Globals.ErrLog.Add(err)
+	}
+
 	out = originalOut
+	c.summariseWasmSize(out)
 	text.Success(out, "Built package (%s)", dest)
 	return nil
 }
 
+// summariseWasmSize displays the size of the Wasm binary and how it changed
+// since the previous build.
+//
+// NOTE: A failure to record the size isn't fatal, the comparison just won't be
+// available next time.
+func (c *BuildCommand) summariseWasmSize(out io.Writer) {
+	fi, err := os.Stat("bin/main.wasm")
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return
+	}
+	size := fi.Size()
+
+	previous, ok, err := recordWasmSize(size)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+	}
+
+	summary := formatSize(size)
+	if ok {
+		summary = fmt.Sprintf("%s (%s since the previous build)", summary, formatSizeChange(size-previous, previous))
+	}
+	text.Info(out, "Wasm binary size: %s. Run `fastly compute inspect` for details.", summary)
+	text.Break(out)
+}
+
 // includeSourceCode calculates what source code files to include in the final
 // package.tar.gz that is uploaded to the Fastly API.
 //
@@ -376,7 +424,24 @@ func language(toolchain string, c *BuildCommand, in io.Reader, out io.Writer, sp
 			),
 		})
 	default:
-		return nil, fmt.Errorf("unsupported language %s", toolchain)
+		def, ok := customLanguages(c.Globals.Config, c.Manifest.File)[toolchain]
+		if !ok {
+			return nil, fmt.Errorf("unsupported language %s", toolchain)
+		}
+		language = NewLanguage(&LanguageOptions{
+			Name:            toolchain,
+			SourceDirectory: def.SourceDirectory,
+			Toolchain: NewCustom(
+				toolchain,
+				def,
+				&c.Manifest.File,
+				c.Globals,
+				c.Flags,
+				in,
+				out,
+				spinner,
+			),
+		})
 	}
 
 	return language, nil
@@ -404,10 +469,12 @@ func binDir(c *BuildCommand) error {
 // CreatePackageArchive packages build artifacts as a Fastly package.
 // The package must be a GZipped Tar archive.
 //
-// Due to a behavior of archiver.Archive() which recursively writes all files in
-// a provided directory to the archive we first copy our input files to a
-// temporary directory to ensure only the specified files are included and not
-// any in the directory which may be ignored.
+// Due to the archive recursively including all files in a provided directory
+// we first copy our input files to a temporary directory to ensure only the
+// specified files are included and not any in the directory which may be
+// ignored.
+//
+// NOTE: The archive is reproducible (see writePackageArchive).
 func CreatePackageArchive(files []string, destination string) error {
 	// Create temporary directory to copy files into.
 	p := make([]byte, 8)
@@ -441,11 +508,7 @@ func CreatePackageArchive(files []string, destination string) error {
 		}
 	}
 
-	tar := archiver.NewTarGz()
-	tar.OverwriteExisting = true //
-	tar.MkdirAll = true          // make destination directory if it doesn't exist
-
-	return tar.Archive([]string{dir}, destination)
+	return writePackageArchive(dir, destination)
 }
 
 // FileNameWithoutExtension returns a filename with its extension stripped.
@@ -0,0 +1,194 @@
+package compute
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/filesystem"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/revision"
+)
+
+// BuildHashFile is the path to the file recording a hash of the inputs to the
+// last successful build.
+const BuildHashFile = "pkg/.build-hash"
+
+// toolchainVersionCommands are the commands whose output identifies the
+// version of each language toolchain.
+var toolchainVersionCommands = map[string][]string{
+	"assemblyscript": {"node --version"},
+	"go":             {"go version", "tinygo version"},
+	"javascript":     {"node --version"},
+	"rust":           {"cargo version --quiet"},
+}
+
+// buildDependencyDirs are the directories under the project root that hold
+// build outputs or fetched dependencies rather than build inputs.
+//
+// NOTE: Rust writes to target/ on every build, so hashing it would mean a
+// build is never current. Dependencies are covered by their lockfiles.
+var buildDependencyDirs = []string{".git", "bin", "node_modules", "pkg", "target"}
+
+// buildHash returns a hash of everything that affects the output of a build:
+// every non-ignored file in the project, the environment manifest, the
+// language toolchain version, the CLI version and the build flags that change
+// the package.
+//
+// An empty hash is returned when the language has no known source directory
+// (e.g. 'other'), as there's no way to tell what the build depends on.
+func buildHash(lang *Language, flags Flags, env, dest string) (string, error) {
+	if lang.SourceDirectory == "" {
+		return "", nil
+	}
+
+	ignoreFiles, err := GetIgnoredFiles(IgnoreFilePath)
+	if err != nil {
+		return "", err
+	}
+	files, err := projectFiles(ignoreFiles)
+	if err != nil {
+		return "", err
+	}
+	files = append(files, manifest.Filename, IgnoreFilePath)
+	if env != "" {
+		files = append(files, manifest.EnvFilename(env))
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	fmt.Fprintf(h, "cli: %s\n", revision.AppVersion)
+	fmt.Fprintf(h, "language: %s\n", lang.Name)
+	fmt.Fprintf(h, "env: %s\n", env)
+	fmt.Fprintf(h, "package: %s\n", dest)
+	fmt.Fprintf(h, "include-source: %t\n", flags.IncludeSrc)
+	versionCommands := toolchainVersionCommands[lang.Name]
+	if custom, ok := lang.Toolchain.(*Custom); ok && custom.definition.ToolchainVersion != "" {
+		versionCommands = []string{custom.definition.ToolchainVersion}
+	}
+	for _, command := range versionCommands {
+		fmt.Fprintf(h, "toolchain: %s\n", toolchainVersion(command))
+	}
+	// NOTE: The manifests are both walked and listed explicitly (in case
+	// they're ignored), so duplicates are skipped.
+	var last string
+	for _, f := range files {
+		if f == last || !filesystem.FileExists(f) {
+			continue
+		}
+		last = f
+		if err := hashFile(h, f); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// projectFiles returns the non-ignored files under the project root, skipping
+// the buildDependencyDirs.
+func projectFiles(ignoredFiles map[string]bool) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			for _, dir := range buildDependencyDirs {
+				if path == dir {
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+		if ignoredFiles[path] {
+			return nil
+		}
+		files = append(files, path)
+		return nil
+	})
+	return files, err
+}
+
+// hashFile writes the path and content of a file to the hash.
+func hashFile(h io.Writer, path string) (err error) {
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as the paths are the user's own project files.
+	/* #nosec */
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		cerr := f.Close()
+		if err == nil {
+			err = cerr
+		}
+	}()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	// NOTE: The size is written along with the path so that content can't
+	// move from one file to the next without changing the hash.
+	fmt.Fprintf(h, "file: %s (%d bytes)\n", filepath.ToSlash(path), fi.Size())
+	_, err = io.Copy(h, f)
+	return err
+}
+
+// toolchainVersion returns the output of a toolchain's version command.
+//
+// NOTE: A toolchain that isn't installed isn't an error here, as the build
+// itself will report the problem.
+func toolchainVersion(command string) string {
+	args := strings.Split(command, " ")
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with function call as argument or cmd arguments
+	// Disabling as we trust the source of the variable.
+	// #nosec
+	// nosemgrep
+	cmd := exec.Command(args[0], args[1:]...)
+	output, err := cmd.Output()
+	if err != nil {
+		return "unavailable"
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// buildIsCurrent reports whether the last successful build used the same
+// inputs and its outputs still exist.
+func buildIsCurrent(hash, dest string) bool {
+	if hash == "" || !filesystem.FileExists("bin/main.wasm") || !filesystem.FileExists(dest) {
+		return false
+	}
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as we trust the source of the filepath variable as it comes
+	// from the BuildHashFile constant.
+	/* #nosec */
+	data, err := os.ReadFile(BuildHashFile)
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(data)) == hash
+}
+
+// writeBuildHash records the hash of the inputs to a successful build.
+func writeBuildHash(hash string) error {
+	if hash == "" {
+		return nil
+	}
+	if err := filesystem.MakeDirectoryIfNotExists(filepath.Dir(BuildHashFile)); err != nil {
+		return err
+	}
+	return os.WriteFile(BuildHashFile, []byte(hash+"\n"), 0o600)
+}
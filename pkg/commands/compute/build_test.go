@@ -807,3 +807,345 @@ func TestBuildOther(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildCustom(t *testing.T) {
+	args := testutil.Args
+	if os.Getenv("TEST_COMPUTE_BUILD") == "" {
+		t.Log("skipping test")
+		t.Skip("Set TEST_COMPUTE_BUILD to run this test")
+	}
+
+	// We're going to chdir to a build environment,
+	// so save the PWD to return to, afterwards.
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create test environment
+	//
+	// NOTE: Our only requirement is that there be a bin directory. The build
+	// commands we're using in the test just create an empty Wasm binary.
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Write: []testutil.FileIO{
+			{Src: "mock content", Dst: "bin/testfile"},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+
+	// Before running the test, chdir into the build environment.
+	// When we're done, chdir back to our original location.
+	// This is so we can reliably copy the testdata/ fixtures.
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	for _, testcase := range []struct {
+		applicationConfig config.File
+		args              []string
+		fastlyManifest    string
+		name              string
+		wantError         string
+		wantOutput        []string
+	}{
+		{
+			name: "language defined in the application config",
+			args: args("compute build --verbose"),
+			applicationConfig: config.File{
+				Language: config.Language{
+					Custom: map[string]config.CustomLanguage{
+						"zig": {
+							Build:  "mkdir -p zig-out/bin && touch zig-out/bin/app.wasm",
+							Output: "zig-out/bin/app.wasm",
+						},
+					},
+				},
+			},
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "zig"`,
+			wantOutput: []string{
+				"The following default build command for zig",
+				"Wasm binary size: 0 B",
+				"Built package",
+			},
+		},
+		{
+			name: "language defined in the manifest",
+			args: args("compute build"),
+			applicationConfig: config.File{
+				Language: config.Language{
+					Custom: map[string]config.CustomLanguage{
+						"zig": {
+							Build: "exit 1",
+						},
+					},
+				},
+			},
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "zig"
+			[custom_languages.zig]
+			build = "touch ./bin/main.wasm"`,
+			wantOutput: []string{
+				"Built package",
+			},
+		},
+		{
+			name: "manifest overrides individual fields",
+			args: args("compute build"),
+			applicationConfig: config.File{
+				Language: config.Language{
+					Custom: map[string]config.CustomLanguage{
+						"zig": {
+							Build:     "touch ./bin/main.wasm",
+							Toolchain: "fastly-test-toolchain-not-installed",
+						},
+					},
+				},
+			},
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "zig"
+			[custom_languages.zig]
+			toolchain = "sh"`,
+			wantOutput: []string{
+				"Built package",
+			},
+		},
+		{
+			name: "toolchain not installed",
+			args: args("compute build"),
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "zig"
+			[custom_languages.zig]
+			build = "touch ./bin/main.wasm"
+			toolchain = "fastly-test-toolchain-not-installed"`,
+			wantError: "`fastly-test-toolchain-not-installed` not found in $PATH",
+		},
+		{
+			name: "no build command",
+			args: args("compute build"),
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "zig"
+			[custom_languages.zig]
+			toolchain = "sh"`,
+			wantError: "no build command found for language 'zig'",
+		},
+		{
+			name: "language not defined",
+			args: args("compute build"),
+			fastlyManifest: `
+			manifest_version = 2
+			name = "test"
+			language = "cobol"`,
+			wantError: "unsupported language cobol",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			if err := os.WriteFile(filepath.Join(rootdir, manifest.Filename), []byte(testcase.fastlyManifest), 0o777); err != nil {
+				t.Fatal(err)
+			}
+
+			var stdout threadsafe.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			opts.ConfigFile = testcase.applicationConfig
+			err = app.Run(opts)
+
+			t.Log(stdout.String())
+
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+		})
+	}
+}
+
+func TestBuildIncremental(t *testing.T) {
+	args := testutil.Args
+	if os.Getenv("TEST_COMPUTE_BUILD") == "" {
+		t.Log("skipping test")
+		t.Skip("Set TEST_COMPUTE_BUILD to run this test")
+	}
+
+	// We're going to chdir to a build environment,
+	// so save the PWD to return to, afterwards.
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// NOTE: The build script doesn't compile anything, it echoes a message so
+	// we can tell whether it was run and then creates the expected binary.
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Write: []testutil.FileIO{
+			{Src: "console.log('hello')", Dst: "src/index.js"},
+			{Src: `
+			manifest_version = 2
+			name = "test"
+			language = "javascript"
+			[scripts]
+			build = "echo running build script && touch ./bin/main.wasm"`, Dst: manifest.Filename},
+			{Src: `service_id = "123"`, Dst: manifest.EnvFilename("stage")},
+			{Src: "module.exports = {}", Dst: "webpack.config.js"},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+
+	// Before running the test, chdir into the build environment.
+	// When we're done, chdir back to our original location.
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	for _, testcase := range []struct {
+		args           []string
+		dontWantOutput []string
+		name           string
+		setup          func(t *testing.T)
+		wantOutput     []string
+	}{
+		{
+			name: "initial build",
+			args: args("compute build --verbose"),
+			wantOutput: []string{
+				"running build script",
+				"Built package",
+			},
+		},
+		{
+			name: "no changes skips the build",
+			args: args("compute build --verbose"),
+			wantOutput: []string{
+				"No changes detected since the last build",
+				"Built package",
+			},
+			dontWantOutput: []string{
+				"running build script",
+			},
+		},
+		{
+			name: "--force rebuilds without changes",
+			args: args("compute build --verbose --force"),
+			wantOutput: []string{
+				"running build script",
+				"Built package",
+			},
+		},
+		{
+			name: "source change rebuilds",
+			args: args("compute build --verbose"),
+			setup: func(t *testing.T) {
+				if err := os.WriteFile(filepath.Join(rootdir, "src", "index.js"), []byte("console.log('changed')"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantOutput: []string{
+				"running build script",
+				"Built package",
+			},
+		},
+		{
+			name: "project root config change rebuilds",
+			args: args("compute build --verbose"),
+			setup: func(t *testing.T) {
+				if err := os.WriteFile(filepath.Join(rootdir, "webpack.config.js"), []byte("module.exports = {mode: 'production'}"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantOutput: []string{
+				"running build script",
+				"Built package",
+			},
+		},
+		{
+			name: "new project file rebuilds",
+			args: args("compute build --verbose"),
+			setup: func(t *testing.T) {
+				if err := os.WriteFile(filepath.Join(rootdir, "build.rs"), []byte("fn main() {}"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantOutput: []string{
+				"running build script",
+				"Built package",
+			},
+		},
+		{
+			name: "switching environment rebuilds",
+			args: args("compute build --verbose --env stage"),
+			wantOutput: []string{
+				"running build script",
+				"Built package",
+			},
+		},
+		{
+			name: "no changes to the environment skips the build",
+			args: args("compute build --verbose --env stage"),
+			wantOutput: []string{
+				"No changes detected since the last build",
+			},
+			dontWantOutput: []string{
+				"running build script",
+			},
+		},
+		{
+			name: "environment manifest change rebuilds",
+			args: args("compute build --verbose --env stage"),
+			setup: func(t *testing.T) {
+				if err := os.WriteFile(filepath.Join(rootdir, manifest.EnvFilename("stage")), []byte(`service_id = "456"`), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantOutput: []string{
+				"running build script",
+				"Built package",
+			},
+		},
+		{
+			name: "missing package rebuilds",
+			args: args("compute build --verbose"),
+			setup: func(t *testing.T) {
+				if err := os.Remove(filepath.Join(rootdir, "pkg", "test.tar.gz")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantOutput: []string{
+				"running build script",
+				"Built package",
+			},
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			if testcase.setup != nil {
+				testcase.setup(t)
+			}
+
+			var stdout threadsafe.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			err = app.Run(opts)
+
+			t.Log(stdout.String())
+
+			testutil.AssertNoError(t, err)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+			for _, s := range testcase.dontWantOutput {
+				testutil.AssertStringDoesntContain(t, stdout.String(), s)
+			}
+		})
+	}
+}
@@ -60,6 +60,63 @@ func createKVStoreItemOK(i *fastly.InsertKVStoreKeyInput) error {
 	return nil
 }
 
+func createConfigStoreOK(i *fastly.CreateConfigStoreInput) (*fastly.ConfigStore, error) {
+	return &fastly.ConfigStore{
+		ID:   "example-store",
+		Name: i.Name,
+	}, nil
+}
+
+func createConfigStoreItemOK(i *fastly.CreateConfigStoreItemInput) (*fastly.ConfigStoreItem, error) {
+	return &fastly.ConfigStoreItem{
+		StoreID: i.StoreID,
+		Key:     i.Key,
+		Value:   i.Value,
+	}, nil
+}
+
+func createACLOK(i *fastly.CreateACLInput) (*fastly.ACL, error) {
+	return &fastly.ACL{
+		ID:             "example-acl",
+		Name:           *i.Name,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}, nil
+}
+
+func createACLEntryOK(i *fastly.CreateACLEntryInput) (*fastly.ACLEntry, error) {
+	return &fastly.ACLEntry{
+		ACLID:     i.ACLID,
+		IP:        *i.IP,
+		ServiceID: i.ServiceID,
+	}, nil
+}
+
+func listKVStoresStoreOne(i *fastly.ListKVStoresInput) (*fastly.ListKVStoresResponse, error) {
+	return &fastly.ListKVStoresResponse{
+		Data: []fastly.KVStore{
+			{ID: "example-store", Name: "store_one"},
+		},
+	}, nil
+}
+
+func listResourcesNone(i *fastly.ListResourcesInput) ([]*fastly.Resource, error) {
+	return []*fastly.Resource{}, nil
+}
+
+func listBackendsFastlyGoogle(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+	return []*fastly.Backend{
+		{Name: "fastly", ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion},
+		{Name: "google", ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion},
+	}, nil
+}
+
+func listDictionariesDictA(i *fastly.ListDictionariesInput) ([]*fastly.Dictionary, error) {
+	return []*fastly.Dictionary{
+		{Name: "dict_a", ServiceID: i.ServiceID, ServiceVersion: i.ServiceVersion},
+	}, nil
+}
+
 func createResourceOK(i *fastly.CreateResourceInput) (*fastly.Resource, error) {
 	return nil, nil
 }
@@ -1,10 +1,13 @@
 package compute_test
 
 import (
+	"archive/tar"
+	"bytes"
 	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/fastly/cli/pkg/commands/compute"
 	"github.com/fastly/cli/pkg/github"
@@ -95,7 +98,6 @@ func TestServeFlagDivergence(t *testing.T) {
 	ignoreServeFlags := []string{
 		"addr",
 		"debug",
-		"env",
 		"file",
 		"skip-build",
 		"viceroy-check",
@@ -182,6 +184,79 @@ func TestCreatePackageArchive(t *testing.T) {
 	testutil.AssertEqual(t, wantFiles, files)
 }
 
+// TestCreatePackageArchiveReproducible validates that identical files produce
+// an identical package, regardless of their modification times and modes.
+func TestCreatePackageArchiveReproducible(t *testing.T) {
+	// we're going to chdir to a build environment,
+	// so save the pwd to return to, afterwards.
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create test environment
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Copy: []testutil.FileIO{
+			{Src: filepath.Join("testdata", "build", "rust", "Cargo.lock"), Dst: "Cargo.lock"},
+			{Src: filepath.Join("testdata", "build", "rust", "Cargo.toml"), Dst: "Cargo.toml"},
+			{Src: filepath.Join("testdata", "build", "rust", "src", "main.rs"), Dst: filepath.Join("src", "main.rs")},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	files := []string{"Cargo.toml", "Cargo.lock", "src/main.rs"}
+	first := filepath.Join("first", "cli.tar.gz")
+	second := filepath.Join("second", "cli.tar.gz")
+
+	err = compute.CreatePackageArchive(files, first)
+	testutil.AssertNoError(t, err)
+
+	mtime := time.Now().Add(time.Hour)
+	for _, f := range files {
+		if err := os.Chtimes(f, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chmod(f, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Listing the files in a different order shouldn't matter either.
+	err = compute.CreatePackageArchive([]string{"src/main.rs", "Cargo.lock", "Cargo.toml"}, second)
+	testutil.AssertNoError(t, err)
+
+	a, err := os.ReadFile(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(a, b) {
+		t.Fatal("expected identical package archives")
+	}
+
+	if err := archiver.Walk(first, func(f archiver.File) error {
+		hdr, ok := f.Header.(*tar.Header)
+		if !ok {
+			t.Fatalf("unexpected header type: %T", f.Header)
+		}
+		if !hdr.ModTime.Equal(time.Unix(0, 0)) || hdr.Uid != 0 || hdr.Gid != 0 || hdr.Uname != "" || hdr.Gname != "" {
+			t.Errorf("unexpected header for %s: %+v", hdr.Name, hdr)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestFileNameWithoutExtension(t *testing.T) {
 	for _, testcase := range []struct {
 		input      string
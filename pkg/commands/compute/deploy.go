@@ -46,10 +46,17 @@ type DeployCommand struct {
 	// values appropriately before calling the Exec() function.
 	Comment            cmd.OptionalString
 	Domain             string
+	DryRun             bool
 	Manifest           manifest.Data
 	Package            string
 	ServiceName        cmd.OptionalServiceNameID
 	ServiceVersion     cmd.OptionalServiceVersion
+	Staged             bool
+	StagedBodyRegex    string
+	StagedMaxLatency   int
+	StagedPasses       int
+	StagedPaths        []string
+	StagedStatusCodes  []int
 	StatusCheckCode    int
 	StatusCheckOff     bool
 	StatusCheckPath    string
@@ -85,7 +92,15 @@ func NewDeployCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *D
 	})
 	c.CmdClause.Flag("comment", "Human-readable comment").Action(c.Comment.Set).StringVar(&c.Comment.Value)
 	c.CmdClause.Flag("domain", "The name of the domain associated to the package").StringVar(&c.Domain)
+	c.CmdClause.Flag("dry-run", "Print a plan of the changes the deploy would make, without making them").BoolVar(&c.DryRun)
+	c.CmdClause.Flag("env", "The environment configuration to use (e.g. stage)").StringVar(&c.Globals.Flags.Env)
 	c.CmdClause.Flag("package", "Path to a package tar.gz").Short('p').StringVar(&c.Package)
+	c.CmdClause.Flag("staged", "Validate the new version before activating it and check it afterwards, re-activating the previous version if the checks fail").BoolVar(&c.Staged)
+	c.CmdClause.Flag("staged-body-regex", "Regular expression the response body must match during a staged deploy").StringVar(&c.StagedBodyRegex)
+	c.CmdClause.Flag("staged-max-latency", "Maximum response time (in milliseconds) allowed during a staged deploy").IntVar(&c.StagedMaxLatency)
+	c.CmdClause.Flag("staged-passes", "Number of consecutive checks the new version must pass during a staged deploy").Default("3").IntVar(&c.StagedPasses)
+	c.CmdClause.Flag("staged-path", "URL path to check during a staged deploy (set flag once per path, defaults to --status-check-path)").StringsVar(&c.StagedPaths)
+	c.CmdClause.Flag("staged-status-code", "Expected status response during a staged deploy (set flag once per status code, defaults to any non-5xx)").IntsVar(&c.StagedStatusCodes)
 	c.CmdClause.Flag("status-check-code", "Set the expected status response for the service availability check").IntVar(&c.StatusCheckCode)
 	c.CmdClause.Flag("status-check-off", "Disable the service availability check").BoolVar(&c.StatusCheckOff)
 	c.CmdClause.Flag("status-check-path", "Specify the URL path for the service availability check").Default("/").StringVar(&c.StatusCheckPath)
@@ -100,6 +115,18 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return err
 	}
 
+	var criteria stagedCriteria
+	if c.Staged {
+		if criteria, err = newStagedCriteria(c); err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+	}
+
+	if c.DryRun {
+		return planDeploy(c, source, serviceID, hashSum, in, out)
+	}
+
 	undoStack := undo.NewStack()
 	undoStack.Push(func() error {
 		// We'll only clean-up the service if it's a new service.
@@ -133,9 +160,10 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return err
 	}
 
-	if err = processSetupConfig(
-		newService, so, serviceID, serviceVersion.Number, c,
-	); err != nil {
+	setupCreated, err := processSetupConfig(
+		newService, so, serviceID, serviceVersion.Number, c, in, out,
+	)
+	if err != nil {
 		return err
 	}
 
@@ -147,13 +175,13 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	}(c.Globals.ErrLog)
 
 	if err = processSetupCreation(
-		newService, so, spinner, c, serviceID, serviceVersion.Number,
+		so, spinner, c, serviceID, serviceVersion.Number,
 	); err != nil {
 		return err
 	}
 
 	cont, err = processPackage(
-		c, hashSum, pkgPath, serviceID, serviceVersion.Number, spinner, out,
+		c, hashSum, pkgPath, serviceID, serviceVersion.Number, setupCreated, spinner, out,
 	)
 	if err != nil {
 		return err
@@ -162,8 +190,12 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return nil
 	}
 
-	if err = processService(c, serviceID, serviceVersion.Number, spinner); err != nil {
-		return err
+	// NOTE: A staged deploy activates the service version once it has checked
+	// the service, which requires the service domain.
+	if !c.Staged {
+		if err = processService(c, serviceID, serviceVersion.Number, spinner); err != nil {
+			return err
+		}
 	}
 
 	domain, err := getServiceDomain(c.Globals.APIClient, serviceID, serviceVersion.Number)
@@ -173,7 +205,14 @@ func (c *DeployCommand) Exec(in io.Reader, out io.Writer) (err error) {
 
 	serviceURL := fmt.Sprintf("https://%s", domain)
 
-	if !c.StatusCheckOff && newService {
+	if c.Staged {
+		err = processStagedActivation(c, criteria, serviceID, serviceVersion.Number, serviceURL, spinner, undoStack, out)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !c.StatusCheckOff && newService && !c.Staged {
 		var status int
 		if status, err = checkingServiceAvailability(serviceURL+c.StatusCheckPath, spinner, c); err != nil {
 			if re, ok := err.(fsterr.RemediationError); ok {
@@ -436,7 +475,13 @@ type activator func(customerID string) error
 func preconfigureActivateTrial(endpoint, token string, httpClient api.HTTPClient) activator {
 	return func(customerID string) error {
 		path := fmt.Sprintf(undocumented.EdgeComputeTrial, customerID)
-		_, err := undocumented.Get(endpoint, path, token, httpClient)
+		_, err := undocumented.Call(undocumented.CallOptions{
+			APIEndpoint: endpoint,
+			HTTPClient:  httpClient,
+			Method:      http.MethodPost,
+			Path:        path,
+			Token:       token,
+		})
 		if err != nil {
 			apiErr, ok := err.(undocumented.APIError)
 			if !ok {
@@ -465,7 +510,7 @@ func serviceManagement(
 		serviceID, serviceVersion, err = manageNoServiceIDFlow(
 			c.Globals.Flags, in, out,
 			c.Globals.APIClient, c.Package, c.Globals.ErrLog,
-			&c.Manifest.File, fnActivateTrial, spinner,
+			&c.Manifest, fnActivateTrial, spinner,
 		)
 		if err != nil {
 			return newService, "", nil, false, err
@@ -474,7 +519,7 @@ func serviceManagement(
 			return newService, "", nil, false, nil // user declined service creation prompt
 		}
 	} else {
-		serviceVersion, err = manageExistingServiceFlow(serviceID, c.ServiceVersion, c.Globals.APIClient, c.Globals.Verbose(), out, c.Globals.ErrLog)
+		serviceVersion, err = manageExistingServiceFlow(serviceID, c.ServiceVersion, c.Globals.APIClient, c.Globals.Verbose(), false, out, c.Globals.ErrLog)
 		if err != nil {
 			return false, "", nil, false, err
 		}
@@ -491,7 +536,7 @@ func manageNoServiceIDFlow(
 	apiClient api.Interface,
 	packageFlag string,
 	errLog fsterr.LogInterface,
-	manifestFile *manifest.File,
+	manifestData *manifest.Data,
 	fnActivateTrial activator,
 	spinner text.Spinner,
 ) (serviceID string, serviceVersion *fastly.Version, err error) {
@@ -500,8 +545,8 @@ func manageNoServiceIDFlow(
 		text.Break(out)
 		text.Output(out, "Press ^C at any time to quit.")
 
-		if manifestFile.Setup.Defined() {
-			text.Info(out, "Processing of the fastly.toml [setup] configuration happens only when there is no existing service. Once a service is created, any further changes to the service or its resources must be made manually.")
+		if setup, _ := manifestData.Setup(); setup.Defined() {
+			text.Info(out, "The fastly.toml [setup] configuration is processed when the service is created. Any resources added to it later are created by subsequent deploys, but changes to existing resources must be made manually.")
 		}
 
 		text.Break(out)
@@ -517,7 +562,7 @@ func manageNoServiceIDFlow(
 		text.Break(out)
 	}
 
-	defaultServiceName := manifestFile.Name
+	defaultServiceName := manifestData.File.Name
 	var serviceName string
 
 	if !f.AcceptDefaults && !f.NonInteractive {
@@ -540,7 +585,7 @@ func manageNoServiceIDFlow(
 		return serviceID, serviceVersion, err
 	}
 
-	err = updateManifestServiceID(manifestFile, manifest.Filename, serviceID)
+	err = updateManifestServiceID(&manifestData.File, manifest.Filename, serviceID)
 
 	// NOTE: Skip error if --package flag is set.
 	//
@@ -671,7 +716,8 @@ func cleanupService(apiClient api.Interface, serviceID string, m manifest.Data,
 	return nil
 }
 
-// updateManifestServiceID updates the Service ID in the manifest.
+// updateManifestServiceID updates the Service ID in the manifest, or in the
+// environment overlay if an environment was selected with --env.
 //
 // There are two scenarios where this function is called. The first is when we
 // have a Service ID to insert into the manifest. The other is when there is an
@@ -679,6 +725,13 @@ func cleanupService(apiClient api.Interface, serviceID string, m manifest.Data,
 // empty string (otherwise the service itself will be deleted while the
 // manifest will continue to hold a reference to it).
 func updateManifestServiceID(m *manifest.File, manifestFilename string, serviceID string) error {
+	if m.Environment() != "" {
+		if err := m.SetEnvServiceID(serviceID); err != nil {
+			return fmt.Errorf("error saving package manifest: %w", err)
+		}
+		return nil
+	}
+
 	if err := m.Read(manifestFilename); err != nil {
 		return fmt.Errorf("error reading package manifest: %w", err)
 	}
@@ -693,11 +746,14 @@ func updateManifestServiceID(m *manifest.File, manifestFilename string, serviceI
 }
 
 // manageExistingServiceFlow clones service version if required.
+//
+// NOTE: When dryRun is set the service version isn't cloned, and so the
+// returned version may not be editable.
 func manageExistingServiceFlow(
 	serviceID string,
 	serviceVersionFlag cmd.OptionalServiceVersion,
 	apiClient api.Interface,
-	verbose bool,
+	verbose, dryRun bool,
 	out io.Writer,
 	errLog fsterr.LogInterface,
 ) (serviceVersion *fastly.Version, err error) {
@@ -735,7 +791,7 @@ func manageExistingServiceFlow(
 	// the compute deploy command is a composite of behaviours, and so as we
 	// already automatically activate a version we should autoclone without
 	// requiring the user to explicitly provide an --autoclone flag.
-	if serviceVersion.Active || serviceVersion.Locked {
+	if (serviceVersion.Active || serviceVersion.Locked) && !dryRun {
 		clonedVersion, err := apiClient.CloneVersion(&fastly.CloneVersionInput{
 			ServiceID:      serviceID,
 			ServiceVersion: serviceVersion.Number,
@@ -838,7 +894,6 @@ func pkgUpload(spinner text.Spinner, client api.Interface, serviceID string, ver
 }
 
 // setupObjects is a collection of backend objects created during setup.
-// Objects may be nil.
 type setupObjects struct {
 	domains      *setup.Domains
 	backends     *setup.Backends
@@ -846,6 +901,33 @@ type setupObjects struct {
 	loggers      *setup.Loggers
 	kvStores     *setup.KVStores
 	secretStores *setup.SecretStores
+	configStores *setup.ConfigStores
+	acls         *setup.ACLs
+}
+
+// setupResources returns the resources defined by the [setup] configuration,
+// in the order they're created.
+func (so setupObjects) setupResources() []setup.Interface {
+	return []setup.Interface{
+		so.backends,
+		so.dictionaries,
+		so.kvStores,
+		so.secretStores,
+		so.configStores,
+		so.acls,
+		so.loggers,
+	}
+}
+
+// missingSetupResources indicates if an existing service is missing resources
+// defined by the [setup] configuration.
+func (so setupObjects) missingSetupResources() bool {
+	for _, r := range so.setupResources() {
+		if r.Missing() {
+			return true
+		}
+	}
+	return false
 }
 
 func constructSetupObjects(
@@ -891,124 +973,249 @@ func constructSetupObjects(
 		return setupObjects{}, fmt.Errorf("error configuring service domains: %w", err)
 	}
 
-	if newService {
-		so.backends = &setup.Backends{
-			APIClient:      c.Globals.APIClient,
-			AcceptDefaults: c.Globals.Flags.AcceptDefaults,
-			NonInteractive: c.Globals.Flags.NonInteractive,
-			ServiceID:      serviceID,
-			ServiceVersion: serviceVersion,
-			Setup:          c.Manifest.File.Setup.Backends,
-			Stdin:          in,
-			Stdout:         out,
-		}
+	setupConfig, _ := c.Manifest.Setup()
 
-		so.dictionaries = &setup.Dictionaries{
-			APIClient:      c.Globals.APIClient,
-			AcceptDefaults: c.Globals.Flags.AcceptDefaults,
-			NonInteractive: c.Globals.Flags.NonInteractive,
-			ServiceID:      serviceID,
-			ServiceVersion: serviceVersion,
-			Setup:          c.Manifest.File.Setup.Dictionaries,
-			Stdin:          in,
-			Stdout:         out,
-		}
+	so.backends = &setup.Backends{
+		APIClient:      c.Globals.APIClient,
+		AcceptDefaults: c.Globals.Flags.AcceptDefaults,
+		NonInteractive: c.Globals.Flags.NonInteractive,
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+		Setup:          setupConfig.Backends,
+		Stdin:          in,
+		Stdout:         out,
+	}
 
-		so.loggers = &setup.Loggers{
-			Setup:  c.Manifest.File.Setup.Loggers,
-			Stdout: out,
-		}
+	so.dictionaries = &setup.Dictionaries{
+		APIClient:      c.Globals.APIClient,
+		AcceptDefaults: c.Globals.Flags.AcceptDefaults,
+		NonInteractive: c.Globals.Flags.NonInteractive,
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+		Setup:          setupConfig.Dictionaries,
+		Stdin:          in,
+		Stdout:         out,
+	}
 
-		so.kvStores = &setup.KVStores{
-			APIClient:      c.Globals.APIClient,
-			AcceptDefaults: c.Globals.Flags.AcceptDefaults,
-			NonInteractive: c.Globals.Flags.NonInteractive,
-			ServiceID:      serviceID,
-			ServiceVersion: serviceVersion,
-			Setup:          c.Manifest.File.Setup.KVStores,
-			Stdin:          in,
-			Stdout:         out,
-		}
+	so.loggers = &setup.Loggers{
+		APIClient:      c.Globals.APIClient,
+		AcceptDefaults: c.Globals.Flags.AcceptDefaults,
+		NonInteractive: c.Globals.Flags.NonInteractive,
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+		Setup:          setupConfig.Loggers,
+		Stdin:          in,
+		Stdout:         out,
+	}
 
-		so.secretStores = &setup.SecretStores{
-			APIClient:      c.Globals.APIClient,
-			AcceptDefaults: c.Globals.Flags.AcceptDefaults,
-			NonInteractive: c.Globals.Flags.NonInteractive,
-			ServiceID:      serviceID,
-			ServiceVersion: serviceVersion,
-			Setup:          c.Manifest.File.Setup.SecretStores,
-			Stdin:          in,
-			Stdout:         out,
+	so.kvStores = &setup.KVStores{
+		APIClient:      c.Globals.APIClient,
+		AcceptDefaults: c.Globals.Flags.AcceptDefaults,
+		NonInteractive: c.Globals.Flags.NonInteractive,
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+		Setup:          setupConfig.KVStores,
+		Stdin:          in,
+		Stdout:         out,
+	}
+
+	so.secretStores = &setup.SecretStores{
+		APIClient:      c.Globals.APIClient,
+		AcceptDefaults: c.Globals.Flags.AcceptDefaults,
+		NonInteractive: c.Globals.Flags.NonInteractive,
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+		Setup:          setupConfig.SecretStores,
+		Stdin:          in,
+		Stdout:         out,
+	}
+
+	so.configStores = &setup.ConfigStores{
+		APIClient:      c.Globals.APIClient,
+		AcceptDefaults: c.Globals.Flags.AcceptDefaults,
+		NonInteractive: c.Globals.Flags.NonInteractive,
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+		Setup:          setupConfig.ConfigStores,
+		Stdin:          in,
+		Stdout:         out,
+	}
+
+	so.acls = &setup.ACLs{
+		APIClient:      c.Globals.APIClient,
+		AcceptDefaults: c.Globals.Flags.AcceptDefaults,
+		NonInteractive: c.Globals.Flags.NonInteractive,
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+		Setup:          setupConfig.ACLs,
+		Stdout:         out,
+	}
+
+	// The [setup] configuration is also reconciled against an existing service,
+	// so that any resources added to it since the service was created are
+	// reported as missing (see processSetupConfig).
+	if !newService {
+		for _, r := range so.setupResources() {
+			if !r.Predefined() {
+				continue
+			}
+			if err = r.Validate(); err != nil {
+				errLogService(c.Globals.ErrLog, err, serviceID, serviceVersion)
+				return setupObjects{}, fmt.Errorf("error checking the service against the [setup] configuration: %w", err)
+			}
 		}
 	}
 
 	return so, nil
 }
 
+// processSetupConfig configures the [setup] resources to be created, and
+// reports whether any will be.
 func processSetupConfig(
 	newService bool,
 	so setupObjects,
 	serviceID string,
 	serviceVersion int,
 	c *DeployCommand,
-) error {
+	in io.Reader,
+	out io.Writer,
+) (created bool, err error) {
 	if so.domains.Missing() {
 		if err := so.domains.Configure(); err != nil {
 			errLogService(c.Globals.ErrLog, err, serviceID, serviceVersion)
-			return fmt.Errorf("error configuring service domains: %w", err)
+			return false, fmt.Errorf("error configuring service domains: %w", err)
 		}
 	}
 
-	// IMPORTANT: The pointer refs in this block are not checked for nil.
-	// We presume if we're dealing with newService they have been set.
-	if newService {
-		// NOTE: A service can't be activated without at least one backend defined.
-		// This explains why the following block of code isn't wrapped in a call to
-		// the .Predefined() method, as the call to .Configure() will ensure the
-		// user is prompted regardless of whether there is a [setup.backends]
-		// defined in the fastly.toml configuration.
-		if err := so.backends.Configure(); err != nil {
+	if !newService {
+		created, err = reconcileSetupConfig(so, serviceID, serviceVersion, c, in, out)
+		return created || so.domains.Missing(), err
+	}
+
+	// NOTE: A service can't be activated without at least one backend defined.
+	// This explains why the following block of code isn't wrapped in a call to
+	// the .Predefined() method, as the call to .Configure() will ensure the
+	// user is prompted regardless of whether there is a [setup.backends]
+	// defined in the fastly.toml configuration.
+	if err := so.backends.Configure(); err != nil {
+		errLogService(c.Globals.ErrLog, err, serviceID, serviceVersion)
+		return false, fmt.Errorf("error configuring service backends: %w", err)
+	}
+
+	if so.dictionaries.Predefined() {
+		if err := so.dictionaries.Configure(); err != nil {
 			errLogService(c.Globals.ErrLog, err, serviceID, serviceVersion)
-			return fmt.Errorf("error configuring service backends: %w", err)
+			return false, fmt.Errorf("error configuring service dictionaries: %w", err)
 		}
+	}
 
-		if so.dictionaries.Predefined() {
-			if err := so.dictionaries.Configure(); err != nil {
-				errLogService(c.Globals.ErrLog, err, serviceID, serviceVersion)
-				return fmt.Errorf("error configuring service dictionaries: %w", err)
-			}
+	if so.loggers.Predefined() {
+		// NOTE: Log endpoints without [setup.log_endpoints.<name>.parameters]
+		// aren't created, the user is instead informed they need to create them
+		// and which provider type they should be.
+		if err := so.loggers.Configure(); err != nil {
+			errLogService(c.Globals.ErrLog, err, serviceID, serviceVersion)
+			return false, fmt.Errorf("error configuring service log endpoints: %w", err)
 		}
+	}
 
-		if so.loggers.Predefined() {
-			// NOTE: We don't handle errors from the Configure() method because we
-			// don't actually do anything other than display a message to the user
-			// informing them that they need to create a log endpoint and which
-			// provider type they should be. The reason we don't implement logic for
-			// creating logging objects is because the API input fields vary
-			// significantly between providers.
-			_ = so.loggers.Configure()
+	if so.kvStores.Predefined() {
+		if err := so.kvStores.Configure(); err != nil {
+			errLogService(c.Globals.ErrLog, err, serviceID, serviceVersion)
+			return false, fmt.Errorf("error configuring service kv stores: %w", err)
 		}
+	}
 
-		if so.kvStores.Predefined() {
-			if err := so.kvStores.Configure(); err != nil {
-				errLogService(c.Globals.ErrLog, err, serviceID, serviceVersion)
-				return fmt.Errorf("error configuring service kv stores: %w", err)
-			}
+	if so.secretStores.Predefined() {
+		if err := so.secretStores.Configure(); err != nil {
+			errLogService(c.Globals.ErrLog, err, serviceID, serviceVersion)
+			return false, fmt.Errorf("error configuring service secret stores: %w", err)
 		}
+	}
 
-		if so.secretStores.Predefined() {
-			if err := so.secretStores.Configure(); err != nil {
-				errLogService(c.Globals.ErrLog, err, serviceID, serviceVersion)
-				return fmt.Errorf("error configuring service secret stores: %w", err)
-			}
+	if so.configStores.Predefined() {
+		if err := so.configStores.Configure(); err != nil {
+			errLogService(c.Globals.ErrLog, err, serviceID, serviceVersion)
+			return false, fmt.Errorf("error configuring service config stores: %w", err)
 		}
 	}
 
-	return nil
+	if so.acls.Predefined() {
+		if err := so.acls.Configure(); err != nil {
+			errLogService(c.Globals.ErrLog, err, serviceID, serviceVersion)
+			return false, fmt.Errorf("error configuring service ACLs: %w", err)
+		}
+	}
+
+	return true, nil
+}
+
+// reconcileSetupConfig configures the resources defined by the [setup]
+// configuration that are missing from an existing service, once the user has
+// confirmed they should be created. It reports whether any resources will be
+// created.
+func reconcileSetupConfig(
+	so setupObjects,
+	serviceID string,
+	serviceVersion int,
+	c *DeployCommand,
+	in io.Reader,
+	out io.Writer,
+) (bool, error) {
+	if !so.missingSetupResources() {
+		return false, nil
+	}
+
+	text.Info(out, "The service is missing resources defined in the fastly.toml [setup] configuration:")
+	text.Break(out)
+	describeSetupResources(so, out)
+	text.Break(out)
+
+	if !c.Globals.Flags.AutoYes && !c.Globals.Flags.NonInteractive {
+		answer, err := text.AskYesNo(out, text.BoldYellow("Create the missing resources: [y/N] "), in)
+		if err != nil {
+			return false, err
+		}
+		if !answer {
+			// NOTE: As no resources are configured, none will be created.
+			return false, nil
+		}
+	}
+
+	for _, r := range so.setupResources() {
+		if !r.Missing() {
+			continue
+		}
+		if err := r.Configure(); err != nil {
+			errLogService(c.Globals.ErrLog, err, serviceID, serviceVersion)
+			return false, fmt.Errorf("error configuring service resources: %w", err)
+		}
+	}
+
+	return true, nil
+}
+
+// describeSetupResources displays the resources defined by the [setup]
+// configuration that will be created.
+//
+// NOTE: For an existing service the [setup] configuration has been reduced to
+// the resources missing from the service (see constructSetupObjects).
+func describeSetupResources(so setupObjects, out io.Writer) {
+	describe := func(kind string, names []string) {
+		if len(names) > 0 {
+			text.Description(out, kind, "Create "+strings.Join(names, ", "))
+		}
+	}
+	describe("Backends", setupNames(so.backends.Setup))
+	describe("Dictionaries", setupNames(so.dictionaries.Setup))
+	describe("KV stores", setupNames(so.kvStores.Setup))
+	describe("Secret stores", setupNames(so.secretStores.Setup))
+	describe("Config stores", setupNames(so.configStores.Setup))
+	describe("ACLs", setupNames(so.acls.Setup))
+	describe("Log endpoints", setupNames(so.loggers.Setup))
 }
 
 func processSetupCreation(
-	newService bool,
 	so setupObjects,
 	spinner text.Spinner,
 	c *DeployCommand,
@@ -1030,48 +1237,19 @@ func processSetupCreation(
 		}
 	}
 
-	// IMPORTANT: The pointer refs in this block are not checked for nil.
-	// We presume if we're dealing with newService they have been set.
-	if newService {
-		so.backends.Spinner = spinner
-		so.dictionaries.Spinner = spinner
-		so.kvStores.Spinner = spinner
-		so.secretStores.Spinner = spinner
-
-		if err := so.backends.Create(); err != nil {
-			c.Globals.ErrLog.AddWithContext(err, map[string]any{
-				"Accept defaults": c.Globals.Flags.AcceptDefaults,
-				"Auto-yes":        c.Globals.Flags.AutoYes,
-				"Non-interactive": c.Globals.Flags.NonInteractive,
-				"Service ID":      serviceID,
-				"Service Version": serviceVersion,
-			})
-			return err
-		}
-
-		if err := so.dictionaries.Create(); err != nil {
-			c.Globals.ErrLog.AddWithContext(err, map[string]any{
-				"Accept defaults": c.Globals.Flags.AcceptDefaults,
-				"Auto-yes":        c.Globals.Flags.AutoYes,
-				"Non-interactive": c.Globals.Flags.NonInteractive,
-				"Service ID":      serviceID,
-				"Service Version": serviceVersion,
-			})
-			return err
-		}
+	// NOTE: For an existing service, only the missing resources the user has
+	// confirmed should be created are configured, and so Create() is a no-op for
+	// the remaining resources.
+	so.backends.Spinner = spinner
+	so.dictionaries.Spinner = spinner
+	so.kvStores.Spinner = spinner
+	so.secretStores.Spinner = spinner
+	so.configStores.Spinner = spinner
+	so.acls.Spinner = spinner
+	so.loggers.Spinner = spinner
 
-		if err := so.kvStores.Create(); err != nil {
-			c.Globals.ErrLog.AddWithContext(err, map[string]any{
-				"Accept defaults": c.Globals.Flags.AcceptDefaults,
-				"Auto-yes":        c.Globals.Flags.AutoYes,
-				"Non-interactive": c.Globals.Flags.NonInteractive,
-				"Service ID":      serviceID,
-				"Service Version": serviceVersion,
-			})
-			return err
-		}
-
-		if err := so.secretStores.Create(); err != nil {
+	for _, r := range so.setupResources() {
+		if err := r.Create(); err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]any{
 				"Accept defaults": c.Globals.Flags.AcceptDefaults,
 				"Auto-yes":        c.Globals.Flags.AutoYes,
@@ -1086,10 +1264,15 @@ func processSetupCreation(
 	return nil
 }
 
+// processPackage uploads the package unless it's identical to the service
+// version's package. It reports whether the service version should then be
+// activated, which is also the case for an identical package when resources
+// from the [setup] configuration were created.
 func processPackage(
 	c *DeployCommand,
 	hashSum, pkgPath, serviceID string,
 	serviceVersion int,
+	setupCreated bool,
 	spinner text.Spinner,
 	out io.Writer,
 ) (cont bool, err error) {
@@ -1103,7 +1286,10 @@ func processPackage(
 		return false, err
 	}
 	if !cont {
-		return false, nil
+		if setupCreated {
+			text.Info(out, "Activating the service version to apply the resources created from the fastly.toml [setup] configuration.")
+		}
+		return setupCreated, nil
 	}
 
 	err = pkgUpload(spinner, c.Globals.APIClient, serviceID, serviceVersion, pkgPath)
@@ -0,0 +1,156 @@
+package compute
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// planDeploy displays the changes the deploy command would make, following
+// the same flow as Exec() but without making any mutating API calls.
+//
+// NOTE: A new service can't be queried for its resources, so its plan is
+// derived from the manifest and flags alone. For an existing service the plan
+// includes the [setup] resources missing from the service.
+func planDeploy(c *DeployCommand, source manifest.Source, serviceID, hashSum string, in io.Reader, out io.Writer) error {
+	text.Info(out, "Dry run: no changes will be made to the service")
+	text.Break(out)
+
+	if source == manifest.SourceUndefined {
+		planNewService(c, out)
+		return nil
+	}
+
+	serviceVersion, err := manageExistingServiceFlow(serviceID, c.ServiceVersion, c.Globals.APIClient, c.Globals.Verbose(), true, out, c.Globals.ErrLog)
+	if err != nil {
+		return err
+	}
+
+	so, err := constructSetupObjects(false, serviceID, serviceVersion.Number, c, in, out)
+	if err != nil {
+		return err
+	}
+
+	changed, err := pkgCompare(c.Globals.APIClient, serviceID, serviceVersion.Number, hashSum, io.Discard)
+	if err != nil {
+		errLogService(c.Globals.ErrLog, err, serviceID, serviceVersion.Number)
+		return err
+	}
+
+	text.Description(out, "Service", fmt.Sprintf("Use existing service %s", serviceID))
+
+	version := fmt.Sprintf("version %d", serviceVersion.Number)
+	if serviceVersion.Active || serviceVersion.Locked {
+		text.Description(out, "Service version", fmt.Sprintf("Clone version %d to a new version", serviceVersion.Number))
+		version = "the cloned version"
+	} else {
+		text.Description(out, "Service version", fmt.Sprintf("Use version %d", serviceVersion.Number))
+	}
+
+	if so.domains.Missing() {
+		text.Description(out, "Domains", planDomain(c.Domain))
+	} else {
+		text.Description(out, "Domains", "No changes")
+	}
+	if so.missingSetupResources() {
+		describeSetupResources(so, out)
+	}
+
+	// NOTE: The service version is activated with an unchanged package when
+	// resources are created, which for missing [setup] resources depends on
+	// the user confirming they should be created.
+	if !changed {
+		text.Description(out, "Package", fmt.Sprintf("No changes (identical to version %d)", serviceVersion.Number))
+		if !so.domains.Missing() && !so.missingSetupResources() {
+			text.Description(out, "Activation", "None (the deploy stops when the package is unchanged)")
+			return nil
+		}
+	} else {
+		text.Description(out, "Package", fmt.Sprintf("Upload package to %s", version))
+	}
+	if c.Staged {
+		text.Description(out, "Activation", fmt.Sprintf("Validate and activate %s, then check the rollout (re-activating the active version if the checks fail)", version))
+		return nil
+	}
+	text.Description(out, "Activation", fmt.Sprintf("Activate %s", version))
+	return nil
+}
+
+// planNewService displays the changes the deploy command would make when
+// creating a new service.
+func planNewService(c *DeployCommand, out io.Writer) {
+	setup, _ := c.Manifest.Setup()
+
+	text.Description(out, "Service", fmt.Sprintf("Create a new service named '%s'", c.Manifest.File.Name))
+	text.Description(out, "Domains", planDomain(c.Domain))
+
+	backends := "None defined in [setup.backends], you'll be prompted for a backend"
+	if c.Globals.Flags.AcceptDefaults || c.Globals.Flags.NonInteractive {
+		backends = "None defined in [setup.backends], an 'originless' backend will be created"
+	}
+	if len(setup.Backends) > 0 {
+		backends = planNames(setup.Backends)
+	}
+	text.Description(out, "Backends", backends)
+	text.Description(out, "Dictionaries", planNames(setup.Dictionaries))
+	text.Description(out, "KV stores", planNames(setup.KVStores))
+	text.Description(out, "Secret stores", planNames(setup.SecretStores))
+	text.Description(out, "Config stores", planNames(setup.ConfigStores))
+	text.Description(out, "ACLs", planNames(setup.ACLs))
+	if len(setup.Loggers) > 0 {
+		var (
+			created = make(map[string]*manifest.SetupLogger)
+			manual  = make(map[string]*manifest.SetupLogger)
+		)
+		for name, l := range setup.Loggers {
+			if len(l.Parameters) > 0 {
+				created[name] = l
+			} else {
+				manual[name] = l
+			}
+		}
+		if len(created) > 0 {
+			text.Description(out, "Log endpoints", planNames(created))
+		}
+		if len(manual) > 0 {
+			text.Description(out, "Log endpoints", planNames(manual)+" (to be created manually)")
+		}
+	}
+
+	text.Description(out, "Package", "Upload package to version 1")
+	if c.Staged {
+		text.Description(out, "Activation", "Validate and activate version 1, then check the rollout")
+		return
+	}
+	text.Description(out, "Activation", "Activate version 1")
+}
+
+// planDomain describes the domain that would be created.
+func planDomain(domain string) string {
+	if domain != "" {
+		return fmt.Sprintf("Create domain '%s'", domain)
+	}
+	return "Create a domain (you'll be prompted for a name, or one will be generated)"
+}
+
+// planNames describes the [setup] resources that would be created.
+func planNames[T any](resources map[string]T) string {
+	if len(resources) == 0 {
+		return "None"
+	}
+	return "Create " + strings.Join(setupNames(resources), ", ")
+}
+
+// setupNames returns the sorted and quoted names of the [setup] resources.
+func setupNames[T any](resources map[string]T) []string {
+	names := make([]string, 0, len(resources))
+	for name := range resources {
+		names = append(names, fmt.Sprintf("'%s'", name))
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,308 @@
+package compute
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/undo"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// stagedCriteria describes what a healthy response looks like during a staged
+// rollout.
+type stagedCriteria struct {
+	bodyRegex   *regexp.Regexp
+	maxLatency  time.Duration
+	passes      int
+	statusCodes []int
+}
+
+// newStagedCriteria validates the staged rollout flags before any changes are
+// made to the service.
+func newStagedCriteria(c *DeployCommand) (stagedCriteria, error) {
+	var sc stagedCriteria
+
+	for _, code := range c.StagedStatusCodes {
+		if !validStatusCodeRange(code) {
+			return sc, fsterr.RemediationError{
+				Inner:       fmt.Errorf("invalid status code: %d", code),
+				Remediation: "Provide a status code between 100 and 999 to the --staged-status-code flag.",
+			}
+		}
+	}
+	sc.statusCodes = c.StagedStatusCodes
+	if len(sc.statusCodes) == 0 && validStatusCodeRange(c.StatusCheckCode) {
+		sc.statusCodes = []int{c.StatusCheckCode}
+	}
+
+	if c.StagedBodyRegex != "" {
+		re, err := regexp.Compile(c.StagedBodyRegex)
+		if err != nil {
+			return sc, fsterr.RemediationError{
+				Inner:       fmt.Errorf("invalid body regex: %w", err),
+				Remediation: "Provide a valid regular expression to the --staged-body-regex flag.",
+			}
+		}
+		sc.bodyRegex = re
+	}
+
+	if c.StagedMaxLatency < 0 {
+		return sc, fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid latency budget: %d", c.StagedMaxLatency),
+			Remediation: "Provide a positive number of milliseconds to the --staged-max-latency flag.",
+		}
+	}
+	sc.maxLatency = time.Duration(c.StagedMaxLatency) * time.Millisecond
+
+	if c.StagedPasses < 1 {
+		return sc, fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid number of consecutive passes: %d", c.StagedPasses),
+			Remediation: "Provide a number greater than zero to the --staged-passes flag.",
+		}
+	}
+	sc.passes = c.StagedPasses
+
+	return sc, nil
+}
+
+// stagedPaths returns the URL paths checked during a staged rollout.
+func stagedPaths(c *DeployCommand) []string {
+	if len(c.StagedPaths) > 0 {
+		return c.StagedPaths
+	}
+	return []string{c.StatusCheckPath}
+}
+
+// processStagedActivation activates the service version as a staged rollout.
+//
+// The version is validated before it's activated. Once activated, the version
+// is checked against the staged criteria and if it fails then the previously
+// active version is re-activated by the undo stack.
+//
+// NOTE: The service can't be checked before activation, as the service domain
+// only serves the active version.
+func processStagedActivation(
+	c *DeployCommand,
+	criteria stagedCriteria,
+	serviceID string,
+	serviceVersion int,
+	serviceURL string,
+	spinner text.Spinner,
+	undoStack undo.Stacker,
+	out io.Writer,
+) error {
+	if err := validateServiceVersion(c, serviceID, serviceVersion, spinner); err != nil {
+		return err
+	}
+
+	previous, err := activeServiceVersion(c.Globals.APIClient, serviceID)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID": serviceID,
+		})
+		return fmt.Errorf("error looking up the active service version: %w", err)
+	}
+
+	if err := processService(c, serviceID, serviceVersion, spinner); err != nil {
+		return err
+	}
+
+	if previous != nil {
+		undoStack.Push(func() error {
+			return reactivateServiceVersion(c, serviceID, previous.Number, out)
+		})
+	}
+
+	if c.StatusCheckOff {
+		return nil
+	}
+	for _, path := range stagedPaths(c) {
+		if err := checkingRollout(serviceURL+path, criteria, spinner, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateServiceVersion checks the service version configuration is valid.
+func validateServiceVersion(c *DeployCommand, serviceID string, serviceVersion int, spinner text.Spinner) error {
+	err := spinner.Start()
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("Validating service (version %d)", serviceVersion)
+	spinner.Message(msg + "...")
+
+	valid, msgs, err := c.Globals.APIClient.ValidateVersion(&fastly.ValidateVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+	})
+	if err == nil && !valid {
+		err = fmt.Errorf("service version %d is invalid: %s", serviceVersion, msgs)
+	}
+	if err != nil {
+		spinner.StopFailMessage(msg)
+		spinErr := spinner.StopFail()
+		if spinErr != nil {
+			return spinErr
+		}
+
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion,
+		})
+		return fmt.Errorf("error validating version: %w", err)
+	}
+
+	spinner.StopMessage(msg)
+	return spinner.Stop()
+}
+
+// activeServiceVersion returns the active service version, or nil if the
+// service has never been activated.
+func activeServiceVersion(client api.Interface, serviceID string) (*fastly.Version, error) {
+	versions, err := client.ListVersions(&fastly.ListVersionsInput{
+		ServiceID: serviceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	v, err := cmd.GetActiveVersion(versions)
+	if err != nil {
+		return nil, nil // no active version
+	}
+	return v, nil
+}
+
+// reactivateServiceVersion rolls the service back to the given version.
+func reactivateServiceVersion(c *DeployCommand, serviceID string, serviceVersion int, out io.Writer) error {
+	text.Warning(out, "Re-activating the previously active service version (%d)", serviceVersion)
+	_, err := c.Globals.APIClient.ActivateVersion(&fastly.ActivateVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion,
+		})
+		return fmt.Errorf("error re-activating service version %d: %w", serviceVersion, err)
+	}
+	text.Info(out, "Re-activated service version %d", serviceVersion)
+	return nil
+}
+
+// checkingRollout pings the service URL until either the responses meet the
+// staged criteria for the required number of consecutive passes or the
+// configured timeout is reached.
+//
+// NOTE: Activation takes time to propagate across Fastly's global network, so
+// early responses may still come from the previously active version. Requiring
+// consecutive passes reduces the chance of a response from the previous
+// version being taken as a successful rollout.
+func checkingRollout(
+	serviceURL string,
+	criteria stagedCriteria,
+	spinner text.Spinner,
+	c *DeployCommand,
+) error {
+	dur := time.Duration(c.StatusCheckTimeout) * time.Second
+	end := time.Now().Add(dur)
+	timeout := time.After(dur)
+	ticker := time.NewTicker(1 * time.Second)
+	defer func() { ticker.Stop() }()
+
+	err := spinner.Start()
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("Checking rollout of %s", serviceURL)
+	spinner.Message(msg + generateTimeout(time.Until(end)))
+
+	var passes int
+	reason := "no response"
+	for {
+		select {
+		case <-timeout:
+			spinner.StopFailMessage(msg + fmt.Sprintf(" (%s)", reason))
+			spinErr := spinner.StopFail()
+			if spinErr != nil {
+				return spinErr
+			}
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("staged rollout check failed for %s: %s", serviceURL, reason),
+				Remediation: "The new service version didn't pass the staged rollout checks before the timeout, so the previously active version (if any) will be re-activated. Check the --staged-* flags and your application code, then deploy again.",
+			}
+		case t := <-ticker.C:
+			var ok bool
+			ok, reason, err = probeServiceURL(serviceURL, c.Globals.HTTPClient, criteria)
+			if err != nil {
+				reason = err.Error()
+			}
+			if !ok || err != nil {
+				passes = 0
+			} else if passes++; passes >= criteria.passes {
+				spinner.StopMessage(msg + fmt.Sprintf(" (%s, %d consecutive passes)", reason, passes))
+				return spinner.Stop()
+			}
+			spinner.Message(msg + generateTimeout(end.Sub(t)))
+		}
+	}
+}
+
+// probeServiceURL indicates if the service returned a response meeting the
+// staged criteria, along with a description of the response.
+func probeServiceURL(serviceURL string, httpClient api.HTTPClient, criteria stagedCriteria) (ok bool, reason string, err error) {
+	req, err := http.NewRequest("GET", serviceURL, nil)
+	if err != nil {
+		return false, "", err
+	}
+
+	start := time.Now()
+	// gosec flagged this:
+	// G107 (CWE-88): Potential HTTP request made with variable url
+	// Disabling as we trust the source of the variable.
+	// #nosec
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return false, "", err
+	}
+	defer resp.Body.Close() // #nosec G307
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, "", err
+	}
+	latency := time.Since(start)
+
+	if !criteria.matchStatus(resp.StatusCode) {
+		return false, fmt.Sprintf("unexpected status: %d", resp.StatusCode), nil
+	}
+	if criteria.bodyRegex != nil && !criteria.bodyRegex.Match(body) {
+		return false, fmt.Sprintf("body doesn't match %q", criteria.bodyRegex.String()), nil
+	}
+	if criteria.maxLatency > 0 && latency > criteria.maxLatency {
+		return false, fmt.Sprintf("latency %v exceeds %v", latency.Round(time.Millisecond), criteria.maxLatency), nil
+	}
+	return true, fmt.Sprintf("status: %d", resp.StatusCode), nil
+}
+
+// matchStatus reports whether the status code is expected. When no status
+// codes are configured, any non-5xx status code is expected.
+func (sc stagedCriteria) matchStatus(status int) bool {
+	if len(sc.statusCodes) == 0 {
+		return status < http.StatusInternalServerError
+	}
+	for _, code := range sc.statusCodes {
+		if code == status {
+			return true
+		}
+	}
+	return false
+}
@@ -77,6 +77,9 @@ func TestDeploy(t *testing.T) {
 	}
 	defer os.Chdir(pwd)
 
+	// The [setup.log_endpoints] parameters can reference environment variables.
+	t.Setenv("FASTLY_TEST_S3_SECRET_KEY", "abc123")
+
 	originalPackageSizeLimit := compute.PackageSizeLimit
 	args := testutil.Args
 	scenarios := []struct {
@@ -476,6 +479,140 @@ func TestDeploy(t *testing.T) {
 				"Skipping package deployment",
 			},
 		},
+		// The following test validates that if the package is unchanged, but the
+		// cloned version has had missing [setup] resources created, then the
+		// package upload is skipped but the version is still activated.
+		{
+			name: "identical package with missing setup resources",
+			args: args("compute deploy --service-id 123 --token 123 --non-interactive"),
+			api: mock.API{
+				ActivateVersionFn:   activateVersionOk,
+				CloneVersionFn:      testutil.CloneVersionResult(4),
+				CreateBackendFn:     createBackendOK,
+				GetPackageFn:        getPackageIdentical,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListBackendsFn:      listBackendsFastlyGoogle,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+			},
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[setup.backends.fastly]
+			address = "fastly.com"
+			[setup.backends.facebook]
+			address = "facebook.com"
+			`,
+			wantOutput: []string{
+				"Creating backend 'facebook' (host: facebook.com, port: 443)",
+				"Skipping package deployment",
+				"Activating service (version 4)",
+				"SUCCESS: Deployed package (service 123, version 4)",
+			},
+			dontWantOutput: []string{
+				"Uploading package",
+			},
+		},
+		// The following tests validate that --dry-run only displays a plan.
+		//
+		// NOTE: The mutating API functions are deliberately not mocked, so the
+		// tests would panic if any of them were called.
+		{
+			name: "dry run with existing service",
+			args: args("compute deploy --service-id 123 --token 123 --dry-run"),
+			api: mock.API{
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+			},
+			wantOutput: []string{
+				"Dry run: no changes will be made to the service",
+				"Use existing service 123",
+				"Clone version 1 to a new version",
+				"Upload package to the cloned version",
+				"Activate the cloned version",
+			},
+			dontWantOutput: []string{
+				"Deployed package",
+			},
+		},
+		{
+			name: "dry run with identical package",
+			args: args("compute deploy --service-id 123 --token 123 --version 3 --dry-run"),
+			api: mock.API{
+				GetPackageFn:        getPackageIdentical,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+			},
+			wantOutput: []string{
+				"Use version 3",
+				"No changes (identical to version 3)",
+				"None (the deploy stops when the package is unchanged)",
+			},
+		},
+		{
+			name: "dry run with identical package and missing setup resources",
+			args: args("compute deploy --service-id 123 --token 123 --version 3 --dry-run"),
+			api: mock.API{
+				GetPackageFn:        getPackageIdentical,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListBackendsFn:      listBackendsFastlyGoogle,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+			},
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[setup.backends.facebook]
+			address = "facebook.com"
+			`,
+			wantOutput: []string{
+				"Create 'facebook'",
+				"No changes (identical to version 3)",
+				"Activate version 3",
+			},
+			dontWantOutput: []string{
+				"the deploy stops when the package is unchanged",
+			},
+		},
+		{
+			name: "dry run with no existing service",
+			args: args("compute deploy --token 123 --domain example.com --dry-run"),
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[setup.backends.backend_a]
+			address = "developer.fastly.com"
+			[setup.dictionaries.dict_a]
+			[setup.kv_stores.store_a]
+			[setup.log_endpoints.logger_a]
+			provider = "BigQuery"
+			`,
+			wantOutput: []string{
+				"Create a new service named 'package'",
+				"Create domain 'example.com'",
+				"Create 'backend_a'",
+				"Create 'dict_a'",
+				"Create 'store_a'",
+				"'logger_a' (to be created manually)",
+				"Activate version 1",
+			},
+			dontWantOutput: []string{
+				"Create new service: [y/N]",
+			},
+		},
 		{
 			name: "success with existing service",
 			args: args("compute deploy --service-id 123 --token 123"),
@@ -509,6 +646,140 @@ func TestDeploy(t *testing.T) {
 				"Deployed package (service 123, version 4)",
 			},
 		},
+		{
+			name: "success with staged activation",
+			args: args("compute deploy --service-id 123 --token 123 --staged --staged-path /health --staged-status-code 200"),
+			api: mock.API{
+				ActivateVersionFn:   activateVersionOk,
+				CloneVersionFn:      testutil.CloneVersionResult(4),
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+				UpdatePackageFn:     updatePackageOk,
+				ValidateVersionFn:   validateVersionOk,
+			},
+			httpClientRes: []*http.Response{
+				{
+					Body:       io.NopCloser(strings.NewReader("success")),
+					Status:     http.StatusText(http.StatusOK),
+					StatusCode: http.StatusOK,
+				},
+			},
+			httpClientErr: []error{
+				nil,
+			},
+			wantOutput: []string{
+				"Uploading package",
+				"Validating service (version 4)",
+				"Activating service (version 4)",
+				"Checking rollout of",
+				"edgecompute.app/health (status: 200, 3 consecutive passes)",
+				"Deployed package (service 123, version 4)",
+			},
+			dontWantOutput: []string{
+				"Checking service availability",
+				"Re-activating",
+			},
+		},
+		// NOTE: The mock HTTP client returns the same response for every request,
+		// so the body can only be read by the first check. The rollout must fail
+		// as the following checks don't pass.
+		{
+			name: "staged activation requires consecutive passes",
+			args: args("compute deploy --service-id 123 --token 123 --staged --staged-body-regex succ --status-check-timeout 5"),
+			api: mock.API{
+				ActivateVersionFn:   activateVersionOk,
+				CloneVersionFn:      testutil.CloneVersionResult(4),
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+				UpdatePackageFn:     updatePackageOk,
+				ValidateVersionFn:   validateVersionOk,
+			},
+			httpClientRes: []*http.Response{
+				{
+					Body:       io.NopCloser(strings.NewReader("success")),
+					Status:     http.StatusText(http.StatusOK),
+					StatusCode: http.StatusOK,
+				},
+			},
+			httpClientErr: []error{
+				nil,
+			},
+			wantError: `body doesn't match "succ"`,
+			wantOutput: []string{
+				"Re-activated service version 1",
+			},
+			dontWantOutput: []string{
+				"Deployed package",
+			},
+		},
+		{
+			name:      "staged activation with invalid number of passes",
+			args:      args("compute deploy --service-id 123 --token 123 --staged --staged-passes 0"),
+			wantError: "invalid number of consecutive passes: 0",
+		},
+		{
+			name: "staged activation re-activates the previous version when the rollout check fails",
+			args: args("compute deploy --service-id 123 --token 123 --staged --staged-status-code 204 --status-check-timeout 2"),
+			api: mock.API{
+				ActivateVersionFn:   activateVersionOk,
+				CloneVersionFn:      testutil.CloneVersionResult(4),
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+				UpdatePackageFn:     updatePackageOk,
+				ValidateVersionFn:   validateVersionOk,
+			},
+			httpClientRes: []*http.Response{
+				{
+					Body:       io.NopCloser(strings.NewReader("success")),
+					Status:     http.StatusText(http.StatusOK),
+					StatusCode: http.StatusOK,
+				},
+			},
+			httpClientErr: []error{
+				nil,
+			},
+			wantError: "unexpected status: 200",
+			wantOutput: []string{
+				"Activating service (version 4)",
+				"Re-activating the previously active service version (1)",
+				"Re-activated service version 1",
+			},
+			dontWantOutput: []string{
+				"Deployed package",
+			},
+		},
+		{
+			name: "staged activation with invalid service version",
+			args: args("compute deploy --service-id 123 --token 123 --staged"),
+			api: mock.API{
+				CloneVersionFn:      testutil.CloneVersionResult(4),
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+				UpdatePackageFn:     updatePackageOk,
+				ValidateVersionFn:   validateVersionInvalid,
+			},
+			wantError: "error validating version: service version 4 is invalid: missing backend",
+			dontWantOutput: []string{
+				"Activating service",
+			},
+		},
+		{
+			name:      "staged activation with invalid body regex",
+			args:      args("compute deploy --service-id 123 --token 123 --staged --staged-body-regex ("),
+			wantError: "invalid body regex",
+		},
 		{
 			name: "success with path",
 			args: args("compute deploy --service-id 123 --token 123 --package pkg/package.tar.gz --version latest"),
@@ -1103,13 +1374,10 @@ func TestDeploy(t *testing.T) {
 			},
 		},
 		// The following test validates that when dealing with an existing service,
-		// no [setup.backends] configuration is utilised.
-		//
-		// i.e. we will not validate the service for missing backends, nor will we
-		// prompt the user to create any backends.
+		// only the [setup.backends] missing from the service are created.
 		{
 			name: "success with setup.backends configuration and existing service",
-			args: args("compute deploy --service-id 123 --token 123"),
+			args: args("compute deploy --service-id 123 --token 123 --non-interactive"),
 			api: mock.API{
 				ActivateVersionFn:   activateVersionOk,
 				CloneVersionFn:      testutil.CloneVersionResult(4),
@@ -1117,6 +1385,7 @@ func TestDeploy(t *testing.T) {
 				GetPackageFn:        getPackageOk,
 				GetServiceFn:        getServiceOK,
 				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListBackendsFn:      listBackendsFastlyGoogle,
 				ListDomainsFn:       listDomainsOk,
 				ListVersionsFn:      testutil.ListVersions,
 				UpdatePackageFn:     updatePackageOk,
@@ -1150,13 +1419,16 @@ func TestDeploy(t *testing.T) {
 			port = 443
 			`,
 			wantOutput: []string{
+				"The service is missing resources defined in the fastly.toml [setup] configuration",
+				"Create 'facebook'",
+				"Creating backend 'facebook' (host: facebook.com, port: 443)",
 				"Uploading package",
 				"Activating service",
 				"SUCCESS: Deployed package (service 123, version 4)",
 			},
 			dontWantOutput: []string{
-				"Creating backend 'google' (host: beep.com, port: 123)",
-				"Creating backend 'facebook' (host: boop.com, port: 456)",
+				"Creating backend 'fastly'",
+				"Creating backend 'google'",
 			},
 		},
 		{
@@ -1169,6 +1441,7 @@ func TestDeploy(t *testing.T) {
 				GetPackageFn:        getPackageOk,
 				GetServiceFn:        getServiceOK,
 				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDictionariesFn:  listDictionariesDictA,
 				ListDomainsFn:       listDomainsOk,
 				ListVersionsFn:      testutil.ListVersions,
 				UpdatePackageFn:     updatePackageOk,
@@ -1203,6 +1476,7 @@ func TestDeploy(t *testing.T) {
 				"SUCCESS: Deployed package (service 123, version 4)",
 			},
 			dontWantOutput: []string{
+				"The service is missing resources",
 				"Configuring dictionary 'dict_a'",
 				"Create a dictionary key called 'foo'",
 				"Create a dictionary key called 'bar'",
@@ -1393,9 +1667,12 @@ func TestDeploy(t *testing.T) {
 				GetPackageFn:        getPackageOk,
 				GetServiceFn:        getServiceOK,
 				GetServiceDetailsFn: getServiceDetailsWasm,
-				ListDomainsFn:       listDomainsOk,
-				ListVersionsFn:      testutil.ListVersions,
-				UpdatePackageFn:     updatePackageOk,
+				ListBigQueriesFn: func(i *fastly.ListBigQueriesInput) ([]*fastly.BigQuery, error) {
+					return []*fastly.BigQuery{{Name: "foo"}}, nil
+				},
+				ListDomainsFn:   listDomainsOk,
+				ListVersionsFn:  testutil.ListVersions,
+				UpdatePackageFn: updatePackageOk,
 			},
 			httpClientRes: []*http.Response{
 				{
@@ -1421,6 +1698,7 @@ func TestDeploy(t *testing.T) {
 				"SUCCESS: Deployed package (service 123, version 4)",
 			},
 			dontWantOutput: []string{
+				"The service is missing resources",
 				"The package code requires the following log endpoints to be created.",
 				"Name: foo",
 				"Provider: BigQuery",
@@ -1576,7 +1854,7 @@ func TestDeploy(t *testing.T) {
 				"SUCCESS: Deployed package (service 12345, version 1)",
 			},
 		},
-		// NOTE: The following test validates [setup] only works for a new service.
+		// NOTE: The following test validates an existing kv store is only linked.
 		{
 			name: "success with setup.kv_stores configuration and existing service",
 			args: args("compute deploy --service-id 123 --token 123"),
@@ -1586,8 +1864,11 @@ func TestDeploy(t *testing.T) {
 				CreateBackendFn:     createBackendOK,
 				GetPackageFn:        getPackageOk,
 				GetServiceFn:        getServiceOK,
+				CreateResourceFn:    createResourceOK,
 				GetServiceDetailsFn: getServiceDetailsWasm,
 				ListDomainsFn:       listDomainsOk,
+				ListKVStoresFn:      listKVStoresStoreOne,
+				ListResourcesFn:     listResourcesNone,
 				ListVersionsFn:      testutil.ListVersions,
 				UpdatePackageFn:     updatePackageOk,
 			},
@@ -1615,7 +1896,13 @@ func TestDeploy(t *testing.T) {
 			value = "my default value for bar"
 			description = "a good description about bar"
 			`,
+			stdin: []string{
+				"Y", // when prompted to create the missing resources
+			},
 			wantOutput: []string{
+				"Create 'store_one'",
+				"Create the missing resources: [y/N]",
+				"Creating resource link between service and kv store 'store_one'",
 				"Uploading package",
 				"Activating service",
 				"SUCCESS: Deployed package (service 123, version 4)",
@@ -1629,6 +1916,83 @@ func TestDeploy(t *testing.T) {
 				"Creating kv store key 'bar'",
 			},
 		},
+		{
+			name: "success with setup.kv_stores configuration and existing service when declining to create the missing resources",
+			args: args("compute deploy --service-id 123 --token 123"),
+			api: mock.API{
+				ActivateVersionFn:   activateVersionOk,
+				CloneVersionFn:      testutil.CloneVersionResult(4),
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListKVStoresFn:      listKVStoresStoreOne,
+				ListResourcesFn:     listResourcesNone,
+				ListVersionsFn:      testutil.ListVersions,
+				UpdatePackageFn:     updatePackageOk,
+			},
+			httpClientRes: []*http.Response{
+				{
+					Body:       io.NopCloser(strings.NewReader("success")),
+					Status:     http.StatusText(http.StatusOK),
+					StatusCode: http.StatusOK,
+				},
+			},
+			httpClientErr: []error{
+				nil,
+			},
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[setup.kv_stores.store_one]
+			[setup.kv_stores.store_two]
+			`,
+			stdin: []string{
+				"N", // when prompted to create the missing resources
+			},
+			wantOutput: []string{
+				"Create 'store_one', 'store_two'",
+				"Create the missing resources: [y/N]",
+				"SUCCESS: Deployed package (service 123, version 4)",
+			},
+			dontWantOutput: []string{
+				"Creating kv store",
+				"Creating resource link",
+			},
+		},
+		{
+			name: "dry run with existing service missing [setup] resources",
+			args: args("compute deploy --service-id 123 --token 123 --dry-run"),
+			api: mock.API{
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListBackendsFn:      listBackendsFastlyGoogle,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+			},
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[setup.backends.fastly]
+			address = "fastly.com"
+			[setup.backends.facebook]
+			address = "facebook.com"
+			`,
+			wantOutput: []string{
+				"Use existing service 123",
+				"Create 'facebook'",
+				"Activate the cloned version",
+			},
+			dontWantOutput: []string{
+				"'fastly'",
+				"Create the missing resources: [y/N]",
+			},
+		},
 		{
 			name: "success with setup.kv_stores configuration and no existing service",
 			args: args("compute deploy --token 123"),
@@ -1801,6 +2165,255 @@ func TestDeploy(t *testing.T) {
 				"my default value for bar",
 			},
 		},
+		{
+			name: "success with setup.config_stores configuration and no existing service",
+			args: args("compute deploy --token 123"),
+			api: mock.API{
+				ActivateVersionFn:       activateVersionOk,
+				CreateBackendFn:         createBackendOK,
+				CreateConfigStoreFn:     createConfigStoreOK,
+				CreateConfigStoreItemFn: createConfigStoreItemOK,
+				CreateResourceFn:        createResourceOK,
+				CreateDomainFn:          createDomainOK,
+				CreateServiceFn:         createServiceOK,
+				GetPackageFn:            getPackageOk,
+				GetServiceFn:            getServiceOK,
+				GetServiceDetailsFn:     getServiceDetailsWasm,
+				ListDomainsFn:           listDomainsOk,
+				ListVersionsFn:          testutil.ListVersions,
+				UpdatePackageFn:         updatePackageOk,
+			},
+			httpClientRes: []*http.Response{
+				{
+					Body:       io.NopCloser(strings.NewReader("success")),
+					Status:     http.StatusText(http.StatusOK),
+					StatusCode: http.StatusOK,
+				},
+			},
+			httpClientErr: []error{
+				nil,
+			},
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[setup.config_stores.store_one]
+			description = "My first config store"
+			[setup.config_stores.store_one.items.foo]
+			value = "my default value for foo"
+			description = "a good description about foo"
+			`,
+			stdin: []string{
+				"Y", // when prompted to create a new service
+			},
+			wantOutput: []string{
+				"Configuring config store 'store_one'",
+				"Create a config store item called 'foo'",
+				"Creating config store 'store_one'",
+				"Creating config store item 'foo'",
+				"Creating resource link between service and config store 'store_one'",
+				"Uploading package",
+				"Activating service",
+				"SUCCESS: Deployed package (service 12345, version 1)",
+			},
+		},
+		{
+			name: "success with setup.acls configuration and no existing service",
+			args: args("compute deploy --non-interactive --token 123"),
+			api: mock.API{
+				ActivateVersionFn:   activateVersionOk,
+				CreateBackendFn:     createBackendOK,
+				CreateACLFn:         createACLOK,
+				CreateACLEntryFn:    createACLEntryOK,
+				CreateDomainFn:      createDomainOK,
+				CreateServiceFn:     createServiceOK,
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+				UpdatePackageFn:     updatePackageOk,
+			},
+			httpClientRes: []*http.Response{
+				{
+					Body:       io.NopCloser(strings.NewReader("success")),
+					Status:     http.StatusText(http.StatusOK),
+					StatusCode: http.StatusOK,
+				},
+			},
+			httpClientErr: []error{
+				nil,
+			},
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[setup.acls.blocklist]
+			entries = [
+				{ ip = "192.0.2.0", subnet = 24, comment = "documentation" },
+				{ ip = "198.51.100.7", negated = true },
+			]
+			`,
+			wantOutput: []string{
+				"Creating ACL 'blocklist'",
+				"Creating ACL entry '192.0.2.0'",
+				"Creating ACL entry '198.51.100.7'",
+				"Uploading package",
+				"Activating service",
+				"SUCCESS: Deployed package (service 12345, version 1)",
+			},
+		},
+		{
+			name: "error with setup.acls configuration containing an invalid IP",
+			args: args("compute deploy --non-interactive --token 123"),
+			api: mock.API{
+				CreateServiceFn:     createServiceOK,
+				DeleteServiceFn:     deleteServiceOK,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+			},
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[setup.acls.blocklist]
+			entries = [{ ip = "not-an-ip" }]
+			`,
+			wantError: "invalid IP address 'not-an-ip' for ACL 'blocklist'",
+		},
+		{
+			name: "error with setup.acls configuration containing an invalid IPv4 subnet",
+			args: args("compute deploy --non-interactive --token 123"),
+			api: mock.API{
+				CreateServiceFn:     createServiceOK,
+				DeleteServiceFn:     deleteServiceOK,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+			},
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[setup.acls.blocklist]
+			entries = [
+				{ ip = "2001:db8::", subnet = 64 },
+				{ ip = "192.0.2.0", subnet = 64 },
+			]
+			`,
+			wantError: "invalid subnet '64' for ACL 'blocklist' entry '192.0.2.0'",
+		},
+		{
+			name: "success with setup.log_endpoints parameters and no existing service",
+			args: args("compute deploy --non-interactive --token 123"),
+			api: mock.API{
+				ActivateVersionFn: activateVersionOk,
+				CreateBackendFn:   createBackendOK,
+				CreateS3Fn: func(i *fastly.CreateS3Input) (*fastly.S3, error) {
+					if *i.Name != "my_logs" || *i.BucketName != "my-bucket" || *i.SecretKey != "abc123" || *i.Period != 60 {
+						return nil, testutil.Err
+					}
+					return &fastly.S3{Name: *i.Name}, nil
+				},
+				CreateDomainFn:      createDomainOK,
+				CreateServiceFn:     createServiceOK,
+				GetPackageFn:        getPackageOk,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+				UpdatePackageFn:     updatePackageOk,
+			},
+			httpClientRes: []*http.Response{
+				{
+					Body:       io.NopCloser(strings.NewReader("success")),
+					Status:     http.StatusText(http.StatusOK),
+					StatusCode: http.StatusOK,
+				},
+			},
+			httpClientErr: []error{
+				nil,
+			},
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[setup.log_endpoints.my_logs]
+			provider = "S3"
+			[setup.log_endpoints.my_logs.parameters]
+			bucket_name = "my-bucket"
+			access_key = "my-access-key"
+			secret_key = "${FASTLY_TEST_S3_SECRET_KEY}"
+			period = 60
+
+			[setup.log_endpoints.other]
+			provider = "BigQuery"
+			`,
+			wantOutput: []string{
+				"Name: other",
+				"Creating s3 log endpoint 'my_logs'",
+				"Uploading package",
+				"Activating service",
+				"SUCCESS: Deployed package (service 12345, version 1)",
+			},
+			dontWantOutput: []string{
+				"Name: my_logs",
+			},
+		},
+		{
+			name: "error with setup.log_endpoints parameters referencing an unset environment variable",
+			args: args("compute deploy --non-interactive --token 123"),
+			api: mock.API{
+				CreateServiceFn:     createServiceOK,
+				DeleteServiceFn:     deleteServiceOK,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+			},
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[setup.log_endpoints.my_logs]
+			provider = "s3"
+			[setup.log_endpoints.my_logs.parameters]
+			secret_key = "${FASTLY_TEST_UNSET_VARIABLE}"
+			`,
+			wantError: "environment variable $FASTLY_TEST_UNSET_VARIABLE (used by 'secret_key' for log endpoint 'my_logs') is not set",
+		},
+		{
+			name: "error with setup.log_endpoints parameters unknown to the provider",
+			args: args("compute deploy --non-interactive --token 123"),
+			api: mock.API{
+				CreateServiceFn:     createServiceOK,
+				DeleteServiceFn:     deleteServiceOK,
+				GetServiceFn:        getServiceOK,
+				GetServiceDetailsFn: getServiceDetailsWasm,
+				ListDomainsFn:       listDomainsOk,
+				ListVersionsFn:      testutil.ListVersions,
+			},
+			manifest: `
+			name = "package"
+			manifest_version = 2
+			language = "rust"
+
+			[setup.log_endpoints.my_logs]
+			provider = "s3"
+			[setup.log_endpoints.my_logs.parameters]
+			bucket = "my-bucket"
+			`,
+			wantError: "invalid parameters for log endpoint 'my_logs'",
+		},
 	}
 	for testcaseIdx := range scenarios {
 		testcase := &scenarios[testcaseIdx]
@@ -1975,6 +2588,14 @@ func activateVersionError(i *fastly.ActivateVersionInput) (*fastly.Version, erro
 	return nil, testutil.Err
 }
 
+func validateVersionOk(i *fastly.ValidateVersionInput) (bool, string, error) {
+	return true, "", nil
+}
+
+func validateVersionInvalid(i *fastly.ValidateVersionInput) (bool, string, error) {
+	return false, "missing backend", nil
+}
+
 func listDomainsError(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
 	return nil, testutil.Err
 }
@@ -11,7 +11,13 @@ import (
 	"github.com/fastly/cli/pkg/text"
 )
 
-// HashsumCommand produces a deployable artifact from files on the local disk.
+// HashsumCommand produces a SHA512 digest from a Compute@Edge package.
+//
+// NOTE: The digest is computed from the fastly.toml and main.wasm within the
+// package rather than the archive itself. As package archives are also
+// reproducible (see writePackageArchive), identical sources produce both an
+// identical digest and an identical package across machines, provided the
+// language toolchain produces an identical Wasm binary.
 type HashsumCommand struct {
 	cmd.Base
 
@@ -27,7 +33,7 @@ func NewHashsumCommand(parent cmd.Registerer, g *global.Data, build *BuildComman
 	c.buildCmd = build
 	c.Globals = g
 	c.Manifest = m
-	c.CmdClause = parent.Command("hashsum", "Generate a SHA512 digest from a Compute@Edge package")
+	c.CmdClause = parent.Command("hashsum", "Generate a SHA512 digest from a Compute@Edge package (identical sources produce an identical digest)")
 	c.CmdClause.Flag("package", "Path to a package tar.gz").Short('p').StringVar(&c.Package)
 	c.CmdClause.Flag("skip-build", "Skip the build step").BoolVar(&c.SkipBuild)
 	return &c
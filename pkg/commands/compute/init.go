@@ -56,7 +56,9 @@ func NewInitCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *Ini
 	c.CmdClause = parent.Command("init", "Initialize a new Compute@Edge package locally")
 	c.CmdClause.Flag("directory", "Destination to write the new package, defaulting to the current directory").Short('p').StringVar(&c.dir)
 	c.CmdClause.Flag("author", "Author(s) of the package").Short('a').StringsVar(&c.manifest.File.Authors)
-	c.CmdClause.Flag("language", "Language of the package").Short('l').HintOptions(Languages...).EnumVar(&c.language, Languages...)
+	// NOTE: Languages is copied so the package-level slice isn't modified.
+	languages := append(append([]string{}, Languages...), customLanguageNames(customLanguages(g.Config, m.File))...)
+	c.CmdClause.Flag("language", "Language of the package").Short('l').HintOptions(languages...).EnumVar(&c.language, languages...)
 	c.CmdClause.Flag("from", "Local project directory, or Git repository URL, or URL referencing a .zip/.tar.gz file, containing a package template").Short('f').StringVar(&c.cloneFrom)
 	c.CmdClause.Flag("branch", "Git branch name to clone from package template repository").Hidden().StringVar(&c.branch)
 	c.CmdClause.Flag("tag", "Git tag name to clone from package template repository").Hidden().StringVar(&c.tag)
@@ -146,7 +148,7 @@ func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return err
 	}
 
-	languages := NewLanguages(c.Globals.Config.StarterKits)
+	languages := NewLanguages(c.Globals.Config.StarterKits, customLanguages(c.Globals.Config, mf))
 
 	var language *Language
 
@@ -172,7 +174,7 @@ func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	// fastly.toml manifest, or the language they selected was "other" (meaning
 	// they're bringing their own project code), then we'll prompt the user to
 	// select a starter kit project.
-	if c.cloneFrom == "" && !mf.Exists() && language.Name != "other" {
+	if c.cloneFrom == "" && !mf.Exists() && !bringYourOwnCode(language) {
 		from, branch, tag, err = promptForStarterKit(c.Globals.Flags, language.StarterKits, in, out)
 		if err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]any{
@@ -218,6 +220,9 @@ func (c *InitCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return err
 	}
 
+	// The package template may define its own language.
+	languages = NewLanguages(c.Globals.Config.StarterKits, customLanguages(c.Globals.Config, mf))
+
 	language, err = initializeLanguage(spinner, language, languages, mf.Language, wd, c.dir)
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
@@ -962,9 +967,10 @@ func updateManifest(
 
 	if err := m.Read(mp); err != nil {
 		if language != nil {
-			if language.Name == "other" {
+			if bringYourOwnCode(language) {
 				// We create a fastly.toml manifest on behalf of the user if they're
-				// bringing their own pre-compiled Wasm binary to be packaged.
+				// bringing their own pre-compiled Wasm binary (or project code) to be
+				// packaged.
 				m.ManifestVersion = manifest.ManifestLatestVersion
 				m.Name = name
 				m.Description = desc
@@ -361,6 +361,24 @@ func TestInit(t *testing.T) {
 				"SUCCESS: Initialized package",
 			},
 		},
+		{
+			name: "with custom language without starter kits",
+			args: args("compute init --language zig"),
+			configFile: config.File{
+				Language: config.Language{
+					Custom: map[string]config.CustomLanguage{
+						"zig": {
+							Build: "zig build",
+						},
+					},
+				},
+			},
+			manifestIncludes: `language = "zig"`,
+			wantOutput: []string{
+				"Initialized package",
+				"SUCCESS: Initialized package",
+			},
+		},
 	}
 	for testcaseIdx := range scenarios {
 		testcase := &scenarios[testcaseIdx]
@@ -0,0 +1,338 @@
+package compute
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/filesystem"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// WasmSizeFile is the path to the file recording the size of the Wasm binary
+// produced by the last two builds.
+const WasmSizeFile = "pkg/.wasm-size"
+
+// wasmBinaryPath is the path to the Wasm binary produced by a build.
+const wasmBinaryPath = "bin/main.wasm"
+
+// fastlyABIModulePrefix identifies the Wasm import modules of the Fastly host
+// ABI (e.g. fastly_http_req).
+const fastlyABIModulePrefix = "fastly"
+
+// InspectCommand reports on the contents of a compiled Wasm binary.
+type InspectCommand struct {
+	cmd.Base
+	cmd.JSONOutput
+
+	file     string
+	manifest manifest.Data
+	pkg      string
+}
+
+// NewInspectCommand returns a usable command registered under the parent.
+func NewInspectCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *InspectCommand {
+	var c InspectCommand
+	c.Globals = g
+	c.manifest = m
+	c.CmdClause = parent.Command("inspect", "Report on the size and contents of a compiled Compute@Edge Wasm binary")
+	c.CmdClause.Flag("file", "Path to the Wasm binary").Default(wasmBinaryPath).StringVar(&c.file)
+	c.RegisterFlagBool(c.JSONFlag()) // --json
+	c.CmdClause.Flag("package", "Path to a package tar.gz, defaulting to the package built from the fastly.toml manifest").Short('p').StringVar(&c.pkg)
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *InspectCommand) Exec(_ io.Reader, out io.Writer) error {
+	m, err := ParseWasmFile(c.file)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"File": c.file,
+		})
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("failed to inspect %s: %w", c.file, err),
+			Remediation: "Run `fastly compute build` to produce a Wasm binary, alternatively use the --file flag to reference a Wasm binary outside of the current project.",
+		}
+	}
+
+	size, err := packageSize(c.file)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	r := newInspectReport(c.file, size, m, isBuiltBinary(c.file))
+
+	name, source := c.manifest.Name()
+	if pkgPath, err := packagePath(c.pkg, name, source); err == nil {
+		if pkgSize, err := packageSize(pkgPath); err == nil {
+			r.Package = &inspectPackage{
+				Path:  pkgPath,
+				Size:  pkgSize,
+				Limit: PackageSizeLimit,
+			}
+		}
+	}
+
+	if ok, err := c.WriteJSON(out, r); ok {
+		return err
+	}
+	r.print(out)
+	return nil
+}
+
+// inspectReport is the report produced by the inspect command.
+type inspectReport struct {
+	File              string          `json:"file"`
+	Size              int64           `json:"size"`
+	PreviousSize      *int64          `json:"previous_size,omitempty"`
+	SizeChange        *int64          `json:"size_change,omitempty"`
+	Package           *inspectPackage `json:"package,omitempty"`
+	Sections          []inspectItem   `json:"sections"`
+	CustomSections    []inspectItem   `json:"custom_sections"`
+	HostFunctions     []inspectImport `json:"host_functions"`
+	OtherImports      []inspectImport `json:"other_imports"`
+	ExportedFunctions []string        `json:"exported_functions"`
+}
+
+// inspectPackage describes the package archive containing the Wasm binary.
+type inspectPackage struct {
+	Path  string `json:"path"`
+	Size  int64  `json:"size"`
+	Limit int64  `json:"limit"`
+}
+
+// inspectItem describes a named section of the Wasm binary.
+type inspectItem struct {
+	Name string `json:"name"`
+	Size int    `json:"size"`
+}
+
+// inspectImport describes an import of the Wasm binary.
+type inspectImport struct {
+	Module string `json:"module"`
+	Name   string `json:"name"`
+	Kind   string `json:"kind"`
+}
+
+// newInspectReport summarises the parsed Wasm binary. The size is only
+// compared with the previous build when the binary is the build output.
+func newInspectReport(file string, size int64, m *WasmModule, built bool) inspectReport {
+	r := inspectReport{
+		File:              file,
+		Size:              size,
+		Sections:          []inspectItem{},
+		CustomSections:    []inspectItem{},
+		HostFunctions:     []inspectImport{},
+		OtherImports:      []inspectImport{},
+		ExportedFunctions: []string{},
+	}
+
+	if previous, ok := previousWasmSize(size); ok && built {
+		change := size - previous
+		r.PreviousSize = &previous
+		r.SizeChange = &change
+	}
+
+	for _, s := range m.Sections {
+		item := inspectItem{Name: s.Name, Size: s.Size}
+		if s.Custom {
+			r.CustomSections = append(r.CustomSections, item)
+		} else {
+			r.Sections = append(r.Sections, item)
+		}
+	}
+	sort.SliceStable(r.Sections, func(i, j int) bool {
+		return r.Sections[i].Size > r.Sections[j].Size
+	})
+
+	for _, i := range m.Imports {
+		imp := inspectImport{Module: i.Module, Name: i.Name, Kind: i.Kind}
+		if i.Kind == "func" && strings.HasPrefix(i.Module, fastlyABIModulePrefix) {
+			r.HostFunctions = append(r.HostFunctions, imp)
+		} else {
+			r.OtherImports = append(r.OtherImports, imp)
+		}
+	}
+
+	for _, e := range m.Exports {
+		if e.Kind == "func" {
+			r.ExportedFunctions = append(r.ExportedFunctions, e.Name)
+		}
+	}
+	return r
+}
+
+// print displays the report as text.
+func (r inspectReport) print(out io.Writer) {
+	text.Description(out, "Wasm binary", r.File)
+	size := formatSize(r.Size)
+	if r.SizeChange != nil {
+		size = fmt.Sprintf("%s (%s since the previous build)", size, formatSizeChange(*r.SizeChange, *r.PreviousSize))
+	}
+	text.Description(out, "Size", size)
+	if r.Package != nil {
+		text.Description(out, "Package", fmt.Sprintf("%s is %s (%.1f%% of the %s upload limit)",
+			r.Package.Path, formatSize(r.Package.Size), float64(r.Package.Size)/float64(r.Package.Limit)*100, formatSize(r.Package.Limit)))
+	}
+
+	text.Output(out, text.Bold("Sections:"))
+	t := text.NewTable(out)
+	t.AddHeader("NAME", "SIZE", "%")
+	for _, s := range r.Sections {
+		percent := "-"
+		if r.Size > 0 {
+			percent = fmt.Sprintf("%.1f", float64(s.Size)/float64(r.Size)*100)
+		}
+		t.AddLine(s.Name, formatSize(int64(s.Size)), percent)
+	}
+	t.Print()
+	text.Break(out)
+
+	if len(r.CustomSections) > 0 {
+		text.Output(out, text.Bold("Custom sections:"))
+		t = text.NewTable(out)
+		t.AddHeader("NAME", "SIZE")
+		for _, s := range r.CustomSections {
+			t.AddLine(s.Name, formatSize(int64(s.Size)))
+		}
+		t.Print()
+		text.Break(out)
+	}
+
+	text.Output(out, text.Bold(fmt.Sprintf("Host functions (%d):", len(r.HostFunctions))))
+	for _, i := range r.HostFunctions {
+		text.Indent(out, 4, "%s::%s", i.Module, i.Name)
+	}
+	text.Break(out)
+
+	if len(r.OtherImports) > 0 {
+		modules := make(map[string]int)
+		for _, i := range r.OtherImports {
+			modules[i.Module]++
+		}
+		names := make([]string, 0, len(modules))
+		for name := range modules {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		text.Output(out, text.Bold(fmt.Sprintf("Other imports (%d):", len(r.OtherImports))))
+		for _, name := range names {
+			text.Indent(out, 4, "%s (%d)", name, modules[name])
+		}
+		text.Break(out)
+	}
+
+	text.Output(out, text.Bold(fmt.Sprintf("Exported functions (%d):", len(r.ExportedFunctions))))
+	for _, name := range r.ExportedFunctions {
+		text.Indent(out, 4, "%s", name)
+	}
+}
+
+// isBuiltBinary reports whether the file is the Wasm binary produced by
+// building the project in the current directory.
+func isBuiltBinary(file string) bool {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return false
+	}
+	built, err := filepath.Abs(wasmBinaryPath)
+	if err != nil {
+		return false
+	}
+	return abs == built
+}
+
+// formatSize renders a size in bytes in a human readable form.
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGT"[exp])
+}
+
+// formatSizeChange renders the change in size from the previous size.
+func formatSizeChange(change, previous int64) string {
+	sign := "+"
+	if change < 0 {
+		sign = "-"
+	}
+	abs := change
+	if abs < 0 {
+		abs = -abs
+	}
+	if previous == 0 {
+		return fmt.Sprintf("%s%s", sign, formatSize(abs))
+	}
+	return fmt.Sprintf("%s%s, %s%.1f%%", sign, formatSize(abs), sign, float64(abs)/float64(previous)*100)
+}
+
+// wasmSizes records the size of the Wasm binary produced by the last two
+// builds.
+type wasmSizes struct {
+	Current  int64 `json:"current"`
+	Previous int64 `json:"previous"`
+}
+
+// readWasmSizes reads the recorded Wasm binary sizes.
+func readWasmSizes() (wasmSizes, bool) {
+	var sizes wasmSizes
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as we trust the source of the filepath variable as it comes
+	// from the WasmSizeFile constant.
+	/* #nosec */
+	data, err := os.ReadFile(WasmSizeFile)
+	if err != nil {
+		return sizes, false
+	}
+	if err := json.Unmarshal(data, &sizes); err != nil {
+		return sizes, false
+	}
+	return sizes, true
+}
+
+// recordWasmSize records the size of the Wasm binary produced by a build and
+// returns the size produced by the previous build (if known).
+func recordWasmSize(size int64) (previous int64, ok bool, err error) {
+	sizes, ok := readWasmSizes()
+	ok = ok && sizes.Current > 0
+
+	data, err := json.Marshal(wasmSizes{Current: size, Previous: sizes.Current})
+	if err != nil {
+		return 0, false, err
+	}
+	if err := filesystem.MakeDirectoryIfNotExists(filepath.Dir(WasmSizeFile)); err != nil {
+		return 0, false, err
+	}
+	return sizes.Current, ok, os.WriteFile(WasmSizeFile, data, 0o600)
+}
+
+// previousWasmSize returns the size of the Wasm binary produced by the build
+// before the one that produced a binary of the given size.
+//
+// NOTE: If the size doesn't match the last build, then the binary was changed
+// outside of a build, so the last build is the previous one.
+func previousWasmSize(size int64) (int64, bool) {
+	sizes, ok := readWasmSizes()
+	if !ok {
+		return 0, false
+	}
+	if sizes.Current == size {
+		return sizes.Previous, sizes.Previous > 0
+	}
+	return sizes.Current, sizes.Current > 0
+}
@@ -0,0 +1,222 @@
+package compute_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/commands/compute"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestInspect(t *testing.T) {
+	args := testutil.Args
+	for _, testcase := range []struct {
+		name           string
+		args           []string
+		wasm           []byte
+		wasmPath       string
+		wasmSizes      string
+		wantError      string
+		wantOutput     []string
+		dontWantOutput []string
+	}{
+		{
+			name: "success",
+			args: args("compute inspect"),
+			wasm: testWasm(),
+			wantOutput: []string{
+				"Size:\n\t",
+				"type",
+				"import",
+				"code",
+				"Custom sections:",
+				"producers",
+				"Host functions (1):",
+				"fastly_http_req::body_downstream_get",
+				"Other imports (1):",
+				"wasi_snapshot_preview1 (1)",
+				"Exported functions (1):",
+				"_start",
+			},
+			dontWantOutput: []string{
+				"since the previous build",
+			},
+		},
+		{
+			name:      "size compared with the previous build",
+			args:      args("compute inspect"),
+			wasm:      testWasm(),
+			wasmSizes: `{"current": 50, "previous": 0}`,
+			wantOutput: []string{
+				"since the previous build",
+			},
+		},
+		{
+			name:      "size not compared for another binary",
+			args:      args("compute inspect --file dist/main.wasm"),
+			wasm:      testWasm(),
+			wasmPath:  filepath.Join("dist", "main.wasm"),
+			wasmSizes: `{"current": 50, "previous": 0}`,
+			dontWantOutput: []string{
+				"since the previous build",
+			},
+		},
+		{
+			name:      "json output",
+			args:      args("compute inspect --json"),
+			wasm:      testWasm(),
+			wasmSizes: `{"current": 1, "previous": 0}`,
+			wantOutput: []string{
+				`"file": "bin/main.wasm"`,
+				`"previous_size": 1`,
+				`"module": "fastly_http_req"`,
+				`"name": "body_downstream_get"`,
+				`"exported_functions": [`,
+			},
+		},
+		{
+			name:      "not a wasm binary",
+			args:      args("compute inspect"),
+			wasm:      []byte("not wasm"),
+			wantError: "failed to inspect bin/main.wasm: not a Wasm binary",
+		},
+		{
+			name:      "missing wasm binary",
+			args:      args("compute inspect --file bin/missing.wasm"),
+			wasm:      testWasm(),
+			wantError: "failed to inspect bin/missing.wasm",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			// We're going to chdir to a test environment,
+			// so save the PWD to return to, afterwards.
+			pwd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wasmPath := testcase.wasmPath
+			if wasmPath == "" {
+				wasmPath = filepath.Join("bin", "main.wasm")
+			}
+			write := []testutil.FileIO{
+				{Src: string(testcase.wasm), Dst: wasmPath},
+				{Src: `name = "test"`, Dst: manifest.Filename},
+			}
+			if testcase.wasmSizes != "" {
+				write = append(write, testutil.FileIO{Src: testcase.wasmSizes, Dst: compute.WasmSizeFile})
+			}
+
+			// Create test environment
+			rootdir := testutil.NewEnv(testutil.EnvOpts{
+				T:     t,
+				Write: write,
+			})
+			defer os.RemoveAll(rootdir)
+
+			// Before running the test, chdir into the test environment.
+			// When we're done, chdir back to our original location.
+			if err := os.Chdir(rootdir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(pwd)
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			err = app.Run(opts)
+
+			t.Log(stdout.String())
+
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+			for _, s := range testcase.dontWantOutput {
+				testutil.AssertStringDoesntContain(t, stdout.String(), s)
+			}
+		})
+	}
+}
+
+func TestParseWasm(t *testing.T) {
+	m, err := compute.ParseWasm(testWasm())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, s := range m.Sections {
+		names = append(names, s.Name)
+	}
+	want, _ := json.Marshal([]string{"type", "import", "function", "export", "code", "producers"})
+	have, _ := json.Marshal(names)
+	testutil.AssertString(t, string(want), string(have))
+
+	if len(m.Imports) != 2 || m.Imports[0].Module != "fastly_http_req" || m.Imports[1].Module != "wasi_snapshot_preview1" {
+		t.Fatalf("unexpected imports: %+v", m.Imports)
+	}
+	if len(m.Exports) != 1 || m.Exports[0].Name != "_start" || m.Exports[0].Kind != "func" {
+		t.Fatalf("unexpected exports: %+v", m.Exports)
+	}
+
+	if _, err := compute.ParseWasm([]byte{0x00, 0x61, 0x73, 0x6d, 0x0d, 0x00, 0x01, 0x00}); err == nil {
+		t.Fatal("expected an error parsing a component binary")
+	}
+}
+
+// TestParseWasmCorrupt validates that the counts and lengths within a corrupt
+// binary are checked against its size, rather than exhausting the memory.
+func TestParseWasmCorrupt(t *testing.T) {
+	preamble := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	huge := []byte{0xff, 0xff, 0xff, 0xff, 0x0f}
+
+	for name, section := range map[string][]byte{
+		"import count":        append([]byte{0x02, 0x05}, huge...),
+		"export count":        append([]byte{0x07, 0x05}, huge...),
+		"import module name":  append([]byte{0x02, 0x06, 0x01}, huge...),
+		"export name":         append([]byte{0x07, 0x06, 0x01}, huge...),
+		"custom section name": append([]byte{0x00, 0x05}, huge...),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := compute.ParseWasm(append(append([]byte{}, preamble...), section...))
+			testutil.AssertErrorContains(t, err, "exceeds the")
+		})
+	}
+}
+
+// testWasm returns a minimal Wasm binary importing a Fastly host function and
+// a WASI function, and exporting a _start function.
+func testWasm() []byte {
+	name := func(s string) []byte {
+		return append([]byte{byte(len(s))}, s...)
+	}
+	section := func(id byte, content ...[]byte) []byte {
+		payload := bytes.Join(content, nil)
+		return append([]byte{id, byte(len(payload))}, payload...)
+	}
+
+	return bytes.Join([][]byte{
+		{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00},
+		// type: () -> ()
+		section(1, []byte{0x01, 0x60, 0x00, 0x00}),
+		// import: two functions of type 0
+		section(2,
+			[]byte{0x02},
+			name("fastly_http_req"), name("body_downstream_get"), []byte{0x00, 0x00},
+			name("wasi_snapshot_preview1"), name("proc_exit"), []byte{0x00, 0x00},
+		),
+		// function: one function of type 0
+		section(3, []byte{0x01, 0x00}),
+		// export: _start (function index 2)
+		section(7, []byte{0x01}, name("_start"), []byte{0x00, 0x02}),
+		// code: an empty function body
+		section(10, []byte{0x01, 0x02, 0x00, 0x0b}),
+		// custom: producers
+		section(0, name("producers"), []byte{0x00}),
+	}, nil)
+}
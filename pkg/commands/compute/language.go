@@ -14,14 +14,17 @@ import (
 // NOTE: The 'timeout' value zero is passed into each New<Language> call as it's
 // only useful during the `compute build` phase and is expected to be
 // provided by the user via a flag on the build command.
-func NewLanguages(kits config.StarterKitLanguages) []*Language {
+//
+// Languages defined as data (see customLanguages) are listed, in name order,
+// after the languages built into the CLI but before 'other'.
+func NewLanguages(kits config.StarterKitLanguages, custom map[string]config.CustomLanguage) []*Language {
 	// WARNING: Do not reorder these options as they affect the rendered output.
 	// They are placed in order of language maturity/importance.
 	//
 	// A change to this order will also break the tests, as the logic defaults to
 	// the first language in the list if nothing entered at the relevant language
 	// prompt.
-	return []*Language{
+	languages := []*Language{
 		NewLanguage(&LanguageOptions{
 			Name:        "rust",
 			DisplayName: "Rust",
@@ -42,11 +45,26 @@ func NewLanguages(kits config.StarterKitLanguages) []*Language {
 			DisplayName: "AssemblyScript",
 			StarterKits: kits.AssemblyScript,
 		}),
-		NewLanguage(&LanguageOptions{
-			Name:        "other",
-			DisplayName: "Other ('bring your own' Wasm binary)",
-		}),
 	}
+
+	for _, name := range customLanguageNames(custom) {
+		def := custom[name]
+		displayName := def.DisplayName
+		if displayName == "" {
+			displayName = name
+		}
+		languages = append(languages, NewLanguage(&LanguageOptions{
+			Name:            name,
+			DisplayName:     displayName,
+			StarterKits:     def.StarterKits,
+			SourceDirectory: def.SourceDirectory,
+		}))
+	}
+
+	return append(languages, NewLanguage(&LanguageOptions{
+		Name:        "other",
+		DisplayName: "Other ('bring your own' Wasm binary)",
+	}))
 }
 
 // NewLanguage constructs a new Language from a LangaugeOptions.
@@ -0,0 +1,221 @@
+package compute
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"github.com/fastly/cli/pkg/config"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/filesystem"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// CustomVersionPattern extracts the version from the output of a custom
+// language's toolchain version command.
+const CustomVersionPattern = `(?P<version>\d+\.\d+(?:\.\d+)?[^\s]*)`
+
+// customLanguages returns the languages defined as data, keyed by name.
+//
+// The CLI application configuration ([language.custom.<T>]) is overridden,
+// field by field, by the fastly.toml manifest ([custom_languages.<T>]). A
+// definition can't replace a language built into the CLI.
+func customLanguages(cfg config.File, m manifest.File) map[string]config.CustomLanguage {
+	defs := make(map[string]config.CustomLanguage)
+	for name, def := range cfg.Language.Custom {
+		defs[name] = def
+	}
+	for name, def := range m.CustomLanguages {
+		merged := defs[name]
+		override(&merged.Build, def.Build)
+		override(&merged.DisplayName, def.DisplayName)
+		override(&merged.Output, def.Output)
+		override(&merged.SourceDirectory, def.SourceDirectory)
+		override(&merged.Toolchain, def.Toolchain)
+		override(&merged.ToolchainConstraint, def.ToolchainConstraint)
+		override(&merged.ToolchainVersion, def.ToolchainVersion)
+		defs[name] = merged
+	}
+	for _, name := range Languages {
+		delete(defs, name)
+	}
+	return defs
+}
+
+// override replaces the field with the value when the value is set.
+func override(field *string, value string) {
+	if value != "" {
+		*field = value
+	}
+}
+
+// customLanguageNames returns the sorted names of the custom languages.
+func customLanguageNames(defs map[string]config.CustomLanguage) []string {
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// isCustomLanguage reports whether the language isn't built into the CLI.
+func isCustomLanguage(name string) bool {
+	for _, l := range Languages {
+		if l == name {
+			return false
+		}
+	}
+	return true
+}
+
+// bringYourOwnCode reports whether the user is bringing their own project code
+// for the language, rather than fetching a starter kit. This is the case for
+// "other" and for a custom language without any starter kits.
+func bringYourOwnCode(language *Language) bool {
+	return language.Name == "other" || (isCustomLanguage(language.Name) && len(language.StarterKits) == 0)
+}
+
+// NewCustom constructs a new toolchain for a language defined as data.
+func NewCustom(
+	name string,
+	definition config.CustomLanguage,
+	fastlyManifest *manifest.File,
+	globals *global.Data,
+	flags Flags,
+	in io.Reader,
+	out io.Writer,
+	spinner text.Spinner,
+) *Custom {
+	return &Custom{
+		Shell: Shell{},
+
+		autoYes:        globals.Flags.AutoYes,
+		build:          fastlyManifest.Scripts.Build,
+		definition:     definition,
+		errlog:         globals.ErrLog,
+		input:          in,
+		name:           name,
+		nonInteractive: globals.Flags.NonInteractive,
+		output:         out,
+		postBuild:      fastlyManifest.Scripts.PostBuild,
+		spinner:        spinner,
+		timeout:        flags.Timeout,
+		verbose:        globals.Verbose(),
+	}
+}
+
+// Custom implements a Toolchain for a language defined as data, either in the
+// CLI application configuration or the fastly.toml manifest.
+type Custom struct {
+	Shell
+
+	// autoYes is the --auto-yes flag.
+	autoYes bool
+	// build is a shell command defined in fastly.toml using [scripts.build].
+	build string
+	// definition is the language definition.
+	definition config.CustomLanguage
+	// errlog is an abstraction for recording errors to disk.
+	errlog fsterr.LogInterface
+	// input is the user's terminal stdin stream
+	input io.Reader
+	// name is the language name.
+	name string
+	// nonInteractive is the --non-interactive flag.
+	nonInteractive bool
+	// output is the users terminal stdout stream
+	output io.Writer
+	// postBuild is a custom script executed after the build but before the Wasm
+	// binary is added to the .tar.gz archive.
+	postBuild string
+	// spinner is a terminal progress status indicator.
+	spinner text.Spinner
+	// timeout is the build execution threshold.
+	timeout int
+	// verbose indicates if the user set --verbose
+	verbose bool
+}
+
+// Build compiles the user's source code into a Wasm binary.
+func (c *Custom) Build() error {
+	if c.build == "" {
+		c.build = c.definition.Build
+		if c.build != "" && c.verbose {
+			text.Info(c.output, "No [scripts.build] found in fastly.toml. The following default build command for %s will be used: `%s`\n", c.name, c.build)
+		}
+	}
+	if c.build == "" {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("no build command found for language '%s'", c.name),
+			Remediation: "Add a [scripts.build] to the fastly.toml manifest, or a 'build' command to the language definition.",
+		}
+	}
+
+	if c.definition.Toolchain != "" {
+		if _, err := exec.LookPath(c.definition.Toolchain); err != nil {
+			c.errlog.Add(err)
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("`%s` not found in $PATH", c.definition.Toolchain),
+				Remediation: fmt.Sprintf("The '%s' language requires a local installation of %s.", c.name, c.definition.Toolchain),
+			}
+		}
+	}
+
+	if c.definition.ToolchainVersion != "" && c.definition.ToolchainConstraint != "" {
+		toolchain := c.definition.Toolchain
+		if toolchain == "" {
+			toolchain = c.name
+		}
+		checkToolchainConstraint(
+			c.output, c.verbose, toolchain, c.definition.ToolchainVersion, CustomVersionPattern, c.definition.ToolchainConstraint,
+		)
+	}
+
+	bt := BuildToolchain{
+		autoYes:        c.autoYes,
+		buildFn:        c.Shell.Build,
+		buildScript:    c.build,
+		errlog:         c.errlog,
+		in:             c.input,
+		nonInteractive: c.nonInteractive,
+		out:            c.output,
+		postBuild:      c.postBuild,
+		spinner:        c.spinner,
+		timeout:        c.timeout,
+		verbose:        c.verbose,
+	}
+	if c.definition.Output != "" {
+		bt.internalPostBuildCallback = c.ProcessLocation
+	}
+
+	return bt.Build()
+}
+
+// ProcessLocation ensures the generated Wasm binary is moved to the required
+// location for packaging.
+func (c *Custom) ProcessLocation() error {
+	dir, err := os.Getwd()
+	if err != nil {
+		c.errlog.Add(err)
+		return fmt.Errorf("getting current working directory: %w", err)
+	}
+
+	src := filepath.Join(dir, c.definition.Output)
+	dst := filepath.Join(dir, "bin", "main.wasm")
+	if src == dst {
+		return nil
+	}
+
+	err = filesystem.CopyFile(src, dst)
+	if err != nil {
+		c.errlog.Add(err)
+		return fmt.Errorf("failed to copy wasm binary: %w", err)
+	}
+	return nil
+}
@@ -3,11 +3,7 @@ package compute
 import (
 	"fmt"
 	"io"
-	"os/exec"
-	"regexp"
-	"strings"
 
-	"github.com/Masterminds/semver/v3"
 	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/global"
@@ -126,49 +122,6 @@ func (g *Go) Build() error {
 }
 
 // toolchainConstraint warns the user if the required constraint is not met.
-//
-// NOTE: We don't stop the build as their toolchain may compile successfully.
-// The warning is to help a user know something isn't quite right and gives them
-// the opportunity to do something about it if they choose.
 func (g *Go) toolchainConstraint(toolchain, pattern, constraint string) {
-	if g.verbose {
-		text.Info(g.output, "The Fastly CLI requires a %s version '%s'. ", toolchain, constraint)
-	}
-
-	versionCommand := fmt.Sprintf("%s version", toolchain)
-	args := strings.Split(versionCommand, " ")
-
-	// gosec flagged this:
-	// G204 (CWE-78): Subprocess launched with function call as argument or cmd arguments
-	// Disabling as we trust the source of the variable.
-	// #nosec
-	// nosemgrep
-	cmd := exec.Command(args[0], args[1:]...)
-	stdoutStderr, err := cmd.CombinedOutput()
-	output := string(stdoutStderr)
-	if err != nil {
-		return
-	}
-
-	versionPattern := regexp.MustCompile(pattern)
-	match := versionPattern.FindStringSubmatch(output)
-	if len(match) < 2 { // We expect a pattern with one capture group.
-		return
-	}
-	version := match[1]
-
-	v, err := semver.NewVersion(version)
-	if err != nil {
-		return
-	}
-
-	c, err := semver.NewConstraint(constraint)
-	if err != nil {
-		return
-	}
-
-	if !c.Check(v) {
-		text.Warning(g.output, "The %s version '%s' didn't meet the constraint '%s'", toolchain, version, constraint)
-		text.Break(g.output)
-	}
+	checkToolchainConstraint(g.output, g.verbose, toolchain, fmt.Sprintf("%s version", toolchain), pattern, constraint)
 }
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
+	"regexp"
 	"strings"
 	"time"
 
+	"github.com/Masterminds/semver/v3"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	fstexec "github.com/fastly/cli/pkg/exec"
 	"github.com/fastly/cli/pkg/text"
@@ -235,3 +238,52 @@ func (bt BuildToolchain) promptForBuildContinue(msg, script string, out io.Write
 	text.Break(out)
 	return nil
 }
+
+// checkToolchainConstraint warns the user if the version of the toolchain,
+// extracted from the output of the versionCommand using the pattern, doesn't
+// meet the required constraint.
+//
+// NOTE: We don't stop the build as their toolchain may compile successfully.
+// The warning is to help a user know something isn't quite right and gives them
+// the opportunity to do something about it if they choose.
+func checkToolchainConstraint(out io.Writer, verbose bool, toolchain, versionCommand, pattern, constraint string) {
+	if verbose {
+		text.Info(out, "The Fastly CLI requires a %s version '%s'. ", toolchain, constraint)
+	}
+
+	args := strings.Split(versionCommand, " ")
+
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with function call as argument or cmd arguments
+	// Disabling as we trust the source of the variable.
+	// #nosec
+	// nosemgrep
+	cmd := exec.Command(args[0], args[1:]...)
+	stdoutStderr, err := cmd.CombinedOutput()
+	output := string(stdoutStderr)
+	if err != nil {
+		return
+	}
+
+	versionPattern := regexp.MustCompile(pattern)
+	match := versionPattern.FindStringSubmatch(output)
+	if len(match) < 2 { // We expect a pattern with one capture group.
+		return
+	}
+	version := match[1]
+
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return
+	}
+
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return
+	}
+
+	if !c.Check(v) {
+		text.Warning(out, "The %s version '%s' didn't meet the constraint '%s'", toolchain, version, constraint)
+		text.Break(out)
+	}
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/fastly/cli/pkg/global"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
-	"github.com/mholt/archiver/v3"
 )
 
 // PackCommand takes a .wasm and builds the required tar/gzip package ready to be uploaded.
@@ -156,13 +155,10 @@ func (c *PackCommand) Exec(_ io.Reader, out io.Writer) (err error) {
 	msg = "Creating package.tar.gz file"
 	spinner.Message(msg + "...")
 
-	tar := archiver.NewTarGz()
-	tar.OverwriteExisting = true
 	{
 		dir := "pkg/package"
-		src := []string{dir}
 		dst := fmt.Sprintf("%s.tar.gz", dir)
-		if err = tar.Archive(src, dst); err != nil {
+		if err = writePackageArchive(dir, dst); err != nil {
 			c.Globals.ErrLog.AddWithContext(err, map[string]any{
 				"Tar source":      dir,
 				"Tar destination": dst,
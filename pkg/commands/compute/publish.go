@@ -17,6 +17,7 @@ type PublishCommand struct {
 	deploy   *DeployCommand
 
 	// Build fields
+	force       cmd.OptionalBool
 	includeSrc  cmd.OptionalBool
 	lang        cmd.OptionalString
 	packageName cmd.OptionalString
@@ -25,9 +26,16 @@ type PublishCommand struct {
 	// Deploy fields
 	comment            cmd.OptionalString
 	domain             cmd.OptionalString
+	dryRun             cmd.OptionalBool
 	pkg                cmd.OptionalString
 	serviceName        cmd.OptionalServiceNameID
 	serviceVersion     cmd.OptionalServiceVersion
+	staged             cmd.OptionalBool
+	stagedBodyRegex    cmd.OptionalString
+	stagedMaxLatency   cmd.OptionalInt
+	stagedPasses       int
+	stagedPaths        cmd.OptionalStringSlice
+	stagedStatusCodes  []int
 	statusCheckCode    int
 	statusCheckOff     bool
 	statusCheckPath    string
@@ -45,6 +53,9 @@ func NewPublishCommand(parent cmd.Registerer, g *global.Data, build *BuildComman
 
 	c.CmdClause.Flag("comment", "Human-readable comment").Action(c.comment.Set).StringVar(&c.comment.Value)
 	c.CmdClause.Flag("domain", "The name of the domain associated to the package").Action(c.domain.Set).StringVar(&c.domain.Value)
+	c.CmdClause.Flag("dry-run", "Print a plan of the changes the deploy would make, without making them").Action(c.dryRun.Set).BoolVar(&c.dryRun.Value)
+	c.CmdClause.Flag("env", "The environment configuration to use (e.g. stage)").StringVar(&c.Globals.Flags.Env)
+	c.CmdClause.Flag("force", "Rebuild the package even if no source files have changed since the last build").Action(c.force.Set).BoolVar(&c.force.Value)
 	c.CmdClause.Flag("include-source", "Include source code in built package").Action(c.includeSrc.Set).BoolVar(&c.includeSrc.Value)
 	c.CmdClause.Flag("language", "Language type").Action(c.lang.Set).StringVar(&c.lang.Value)
 	c.CmdClause.Flag("package", "Path to a package tar.gz").Short('p').Action(c.pkg.Set).StringVar(&c.pkg.Value)
@@ -61,6 +72,12 @@ func NewPublishCommand(parent cmd.Registerer, g *global.Data, build *BuildComman
 		Description: cmd.FlagServiceDesc,
 		Dst:         &c.serviceName.Value,
 	})
+	c.CmdClause.Flag("staged", "Validate the new version before activating it and check it afterwards, re-activating the previous version if the checks fail").Action(c.staged.Set).BoolVar(&c.staged.Value)
+	c.CmdClause.Flag("staged-body-regex", "Regular expression the response body must match during a staged deploy").Action(c.stagedBodyRegex.Set).StringVar(&c.stagedBodyRegex.Value)
+	c.CmdClause.Flag("staged-max-latency", "Maximum response time (in milliseconds) allowed during a staged deploy").Action(c.stagedMaxLatency.Set).IntVar(&c.stagedMaxLatency.Value)
+	c.CmdClause.Flag("staged-passes", "Number of consecutive checks the new version must pass during a staged deploy").Default("3").IntVar(&c.stagedPasses)
+	c.CmdClause.Flag("staged-path", "URL path to check during a staged deploy (set flag once per path, defaults to --status-check-path)").Action(c.stagedPaths.Set).StringsVar(&c.stagedPaths.Value)
+	c.CmdClause.Flag("staged-status-code", "Expected status response during a staged deploy (set flag once per status code, defaults to any non-5xx)").IntsVar(&c.stagedStatusCodes)
 	c.CmdClause.Flag("status-check-code", "Set the expected status response for the service availability check to the root path").IntVar(&c.statusCheckCode)
 	c.CmdClause.Flag("status-check-off", "Disable the service availability check").BoolVar(&c.statusCheckOff)
 	c.CmdClause.Flag("status-check-path", "Specify the URL path for the service availability check").Default("/").StringVar(&c.statusCheckPath)
@@ -85,6 +102,9 @@ func NewPublishCommand(parent cmd.Registerer, g *global.Data, build *BuildComman
 // the progress indicator.
 func (c *PublishCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	// Reset the fields on the BuildCommand based on PublishCommand values.
+	if c.force.WasSet {
+		c.build.Flags.Force = c.force.Value
+	}
 	if c.includeSrc.WasSet {
 		c.build.Flags.IncludeSrc = c.includeSrc.Value
 	}
@@ -120,10 +140,29 @@ func (c *PublishCommand) Exec(in io.Reader, out io.Writer) (err error) {
 	if c.domain.WasSet {
 		c.deploy.Domain = c.domain.Value
 	}
+	if c.dryRun.WasSet {
+		c.deploy.DryRun = c.dryRun.Value
+	}
 	if c.comment.WasSet {
 		c.deploy.Comment = c.comment
 	}
 	c.deploy.Manifest = c.manifest
+	if c.staged.WasSet {
+		c.deploy.Staged = c.staged.Value
+	}
+	if c.stagedBodyRegex.WasSet {
+		c.deploy.StagedBodyRegex = c.stagedBodyRegex.Value
+	}
+	if c.stagedMaxLatency.WasSet {
+		c.deploy.StagedMaxLatency = c.stagedMaxLatency.Value
+	}
+	c.deploy.StagedPasses = c.stagedPasses
+	if c.stagedPaths.WasSet {
+		c.deploy.StagedPaths = c.stagedPaths.Value
+	}
+	if len(c.stagedStatusCodes) > 0 {
+		c.deploy.StagedStatusCodes = c.stagedStatusCodes
+	}
 	if c.statusCheckCode > 0 {
 		c.deploy.StatusCheckCode = c.statusCheckCode
 	}
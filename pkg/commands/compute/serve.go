@@ -41,6 +41,7 @@ type ServeCommand struct {
 	av       github.AssetVersioner
 
 	// Build fields
+	force       cmd.OptionalBool
 	includeSrc  cmd.OptionalBool
 	lang        cmd.OptionalString
 	packageName cmd.OptionalString
@@ -49,7 +50,6 @@ type ServeCommand struct {
 	// Serve fields
 	addr           string
 	debug          bool
-	env            cmd.OptionalString
 	file           string
 	skipBuild      bool
 	viceroyBinPath string
@@ -71,8 +71,9 @@ func NewServeCommand(parent cmd.Registerer, g *global.Data, build *BuildCommand,
 
 	c.CmdClause.Flag("addr", "The IPv4 address and port to listen on").Default("127.0.0.1:7676").StringVar(&c.addr)
 	c.CmdClause.Flag("debug", "Run the server in Debug Adapter mode").Hidden().BoolVar(&c.debug)
-	c.CmdClause.Flag("env", "The environment configuration to use (e.g. stage)").Action(c.env.Set).StringVar(&c.env.Value)
+	c.CmdClause.Flag("env", "The environment configuration to use (e.g. stage)").StringVar(&c.Globals.Flags.Env)
 	c.CmdClause.Flag("file", "The Wasm file to run").Default("bin/main.wasm").StringVar(&c.file)
+	c.CmdClause.Flag("force", "Rebuild the package even if no source files have changed since the last build").Action(c.force.Set).BoolVar(&c.force.Value)
 	c.CmdClause.Flag("include-source", "Include source code in built package").Action(c.includeSrc.Set).BoolVar(&c.includeSrc.Value)
 	c.CmdClause.Flag("language", "Language type").Action(c.lang.Set).StringVar(&c.lang.Value)
 	c.CmdClause.Flag("package-name", "Package name").Action(c.packageName.Set).StringVar(&c.packageName.Value)
@@ -131,8 +132,23 @@ func (c *ServeCommand) Exec(in io.Reader, out io.Writer) (err error) {
 		return err
 	}
 
+	manifestPath, err := viceroyManifestPath(c.Globals.Flags.Env)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	manifestPath, mockDirs, stopMocks, err := StartMockBackends(manifestPath, out)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Manifest": manifestPath,
+		})
+		return err
+	}
+	defer stopMocks()
+
 	for {
-		err = local(bin, c.file, c.addr, c.env.Value, c.debug, c.watch, c.watchDir, c.Globals.Verbose(), out, c.Globals.ErrLog)
+		err = local(bin, c.file, c.addr, manifestPath, c.debug, c.watch, c.watchDir, mockDirs, c.Globals.Verbose(), out, c.Globals.ErrLog)
 		if err != nil {
 			if err != fsterr.ErrViceroyRestart {
 				if err == fsterr.ErrSignalInterrupt || err == fsterr.ErrSignalKilled {
@@ -158,6 +174,9 @@ func (c *ServeCommand) Exec(in io.Reader, out io.Writer) (err error) {
 // Build constructs and executes the build logic.
 func (c *ServeCommand) Build(in io.Reader, out io.Writer) error {
 	// Reset the fields on the BuildCommand based on ServeCommand values.
+	if c.force.WasSet {
+		c.build.Flags.Force = c.force.Value
+	}
 	if c.includeSrc.WasSet {
 		c.build.Flags.IncludeSrc = c.includeSrc.Value
 	}
@@ -189,7 +208,7 @@ func (c *ServeCommand) setBackendsWithDefaultOverrideHostIfMissing(out io.Writer
 	var missingOverrideHost bool
 
 	for k, backend := range c.Globals.Manifest.File.LocalServer.Backends {
-		if backend.OverrideHost == "" {
+		if backend.OverrideHost == "" && backend.Mock == "" {
 			if u, err := url.Parse(backend.URL); err == nil {
 				segs := strings.Split(u.Host, ":") // avoid parsing IP with port
 				if ip := net.ParseIP(segs[0]); ip == nil {
@@ -217,6 +236,9 @@ func (c *ServeCommand) setBackendsWithDefaultOverrideHostIfMissing(out io.Writer
 //
 // In the case of a network failure we fallback to the latest installed version of the
 // Viceroy binary as long as one is installed and has the correct permissions.
+//
+// If the fastly.toml [local_server] pins a viceroy_version, then the matching
+// version is used instead (see getPinnedViceroy).
 func GetViceroy(
 	spinner text.Spinner,
 	out io.Writer,
@@ -246,6 +268,11 @@ func GetViceroy(
 		return filepath.Abs(path)
 	}
 
+	// Allows a project to pin the version of Viceroy it's served with.
+	if pin := g.Manifest.File.LocalServer.ViceroyVersion; pin != "" {
+		return getPinnedViceroy(spinner, out, av, g, pin, viceroyCheck)
+	}
+
 	bin = filepath.Join(InstallDir, av.BinaryName())
 
 	// NOTE: When checking if Viceroy is installed we don't use
@@ -564,19 +591,28 @@ func setBinPerms(bin string) error {
 	return nil
 }
 
-// local spawns a subprocess that runs the compiled binary.
-func local(bin, file, addr, env string, debug, watch bool, watchDir cmd.OptionalString, verbose bool, out io.Writer, errLog fsterr.LogInterface) error {
-	if env != "" {
-		env = "." + env
-	}
-
+// viceroyManifestPath returns the absolute path to the manifest Viceroy should
+// be configured with.
+//
+// NOTE: An environment may be defined by an [env.<T>] section of the
+// fastly.toml rather than a fastly.<T>.toml, in which case Viceroy uses the
+// fastly.toml as normal.
+func viceroyManifestPath(env string) (string, error) {
 	wd, err := os.Getwd()
 	if err != nil {
-		errLog.Add(err)
-		return err
+		return "", err
+	}
+	manifestPath := filepath.Join(wd, manifest.Filename)
+	if env != "" {
+		if p := filepath.Join(wd, manifest.EnvFilename(env)); filesystem.FileExists(p) {
+			manifestPath = p
+		}
 	}
+	return manifestPath, nil
+}
 
-	manifestPath := filepath.Join(wd, fmt.Sprintf("fastly%s.toml", env))
+// local spawns a subprocess that runs the compiled binary.
+func local(bin, file, addr, manifestPath string, debug, watch bool, watchDir cmd.OptionalString, skipDirs []string, verbose bool, out io.Writer, errLog fsterr.LogInterface) error {
 	args := []string{"-C", manifestPath, "--addr", addr, file}
 
 	if debug {
@@ -628,7 +664,7 @@ func local(bin, file, addr, env string, debug, watch bool, watchDir cmd.Optional
 		}
 
 		gi := ignoreFiles(watchDir)
-		go watchFiles(root, gi, verbose, s, out, restart)
+		go watchFiles(root, gi, skipDirs, verbose, s, out, restart)
 	}
 
 	// NOTE: Once we run the viceroy executable, then it can be stopped by one of
@@ -674,7 +710,10 @@ func local(bin, file, addr, env string, debug, watch bool, watchDir cmd.Optional
 
 // watchFiles watches the language source directory and restarts the viceroy
 // executable when changes are detected.
-func watchFiles(root string, gi *ignore.GitIgnore, verbose bool, s *fstexec.Streaming, out io.Writer, restart chan<- bool) {
+//
+// NOTE: The skipDirs (absolute paths) aren't watched (e.g. the fixtures of a
+// mocked backend, which are written to while recording).
+func watchFiles(root string, gi *ignore.GitIgnore, skipDirs []string, verbose bool, s *fstexec.Streaming, out io.Writer, restart chan<- bool) {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		log.Fatal(err)
@@ -760,6 +799,17 @@ func watchFiles(root string, gi *ignore.GitIgnore, verbose bool, s *fstexec.Stre
 		if err != nil {
 			return fmt.Errorf("error configuring watching for file changes: %w", err)
 		}
+		if entry.IsDir() && len(skipDirs) > 0 {
+			// NOTE: The skipDirs are absolute, so the path is too before comparing
+			// them, as the --watch-dir may be either relative or absolute.
+			if abs, err := filepath.Abs(path); err == nil {
+				for _, dir := range skipDirs {
+					if abs == dir {
+						return filepath.SkipDir
+					}
+				}
+			}
+		}
 		// If there's no ignore file, we'll default to watching all directories
 		// within the specified top-level directory.
 		//
@@ -0,0 +1,379 @@
+package compute
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/filesystem"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	toml "github.com/pelletier/go-toml"
+)
+
+// MockManifestFilename is the manifest generated for Viceroy when any
+// [local_server.backends] are mocked. It's written to the project directory so
+// that relative paths within the manifest still resolve.
+const MockManifestFilename = ".fastly-serve.toml"
+
+// MockFixture is a recorded request/response pair served by a mocked backend.
+//
+// NOTE: The response body is stored as text, unless it isn't valid UTF-8 in
+// which case it's stored base64 encoded.
+type MockFixture struct {
+	Request  MockRequest  `json:"request"`
+	Response MockResponse `json:"response"`
+}
+
+// MockRequest identifies the requests a fixture is served for.
+type MockRequest struct {
+	Method string `json:"method,omitempty"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+}
+
+// MockResponse is the response served for a fixture.
+type MockResponse struct {
+	Status     int         `json:"status"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+// mockBackend is a local stub server for a backend declared with `mock`.
+type mockBackend struct {
+	// dir is the directory containing the backend fixtures.
+	dir string
+	// httpClient is used to record fixtures.
+	httpClient *http.Client
+	// listener accepts connections for the stub server.
+	listener net.Listener
+	// mu serialises recording fixtures.
+	mu sync.Mutex
+	// name is the backend name.
+	name string
+	// record indicates if a request without a fixture is recorded.
+	record bool
+	// server is the stub server.
+	server *http.Server
+	// upstream is the URL requests are recorded against.
+	upstream string
+}
+
+// StartMockBackends starts a stub server for every backend the manifest at
+// manifestPath mocks and returns the path to a manifest pointing Viceroy at
+// them, the absolute paths of their fixture directories and a function to stop
+// them. If no backends are mocked, the manifestPath is returned as-is.
+//
+// NOTE: The backends are read from the manifest Viceroy is configured with
+// (e.g. a fastly.<T>.toml when --env is set), rather than the fastly.toml, so
+// that the backends mocked are those the rewritten manifest declares.
+func StartMockBackends(manifestPath string, out io.Writer) (string, []string, func(), error) {
+	tree, err := toml.LoadFile(manifestPath)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("error reading %s: %w", manifestPath, err)
+	}
+	var m manifest.File
+	if err := tree.Unmarshal(&m); err != nil {
+		return "", nil, nil, fmt.Errorf("error parsing %s: %w", manifestPath, err)
+	}
+	backends := m.LocalServer.Backends
+
+	var names []string
+	for name, b := range backends {
+		if b.Mock != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return manifestPath, nil, func() {}, nil
+	}
+	sort.Strings(names)
+
+	var (
+		mockDirs []string
+		mocks    []*mockBackend
+	)
+	stop := func() {
+		for _, m := range mocks {
+			_ = m.server.Close()
+		}
+	}
+
+	for _, name := range names {
+		m, err := newMockBackend(name, backends[name])
+		if err != nil {
+			stop()
+			return "", nil, nil, err
+		}
+		mocks = append(mocks, m)
+		if dir, err := filepath.Abs(m.dir); err == nil {
+			mockDirs = append(mockDirs, dir)
+		}
+		go func() {
+			_ = m.server.Serve(m.listener)
+		}()
+
+		addr := fmt.Sprintf("http://%s", m.listener.Addr().String())
+		key := []string{"local_server", "backends", name}
+		tree.SetPath(append(key, "url"), addr)
+		for _, field := range []string{"mock", "record"} {
+			if tree.HasPath(append(key, field)) {
+				_ = tree.DeletePath(append(key, field))
+			}
+		}
+
+		msg := fmt.Sprintf("Mocking backend '%s' from %s (%s)", name, m.dir, addr)
+		if m.record {
+			msg += fmt.Sprintf(", recording missing fixtures from %s", m.upstream)
+		}
+		text.Info(out, msg)
+	}
+	text.Break(out)
+
+	data, err := tree.Marshal()
+	if err != nil {
+		stop()
+		return "", nil, nil, err
+	}
+	path := filepath.Join(filepath.Dir(manifestPath), MockManifestFilename)
+	if err := os.WriteFile(path, data, manifest.FilePermissions); err != nil {
+		stop()
+		return "", nil, nil, fmt.Errorf("error writing %s: %w", path, err)
+	}
+
+	return path, mockDirs, func() {
+		stop()
+		_ = os.Remove(path)
+	}, nil
+}
+
+// newMockBackend validates the backend and listens on a free local port.
+func newMockBackend(name string, b manifest.LocalBackend) (*mockBackend, error) {
+	if b.Record && b.URL == "" {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("[local_server.backends.%s] sets `record` without a `url`", name),
+			Remediation: "Set the `url` to record fixtures from, or remove `record`.",
+		}
+	}
+	if !b.Record && !filesystem.FileExists(b.Mock) {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("[local_server.backends.%s] mock directory not found: %s", name, b.Mock),
+			Remediation: "Create the directory with recorded fixtures, or set `record = true` to record them from the `url`.",
+		}
+	}
+	if err := filesystem.MakeDirectoryIfNotExists(b.Mock); err != nil {
+		return nil, err
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("error starting mock backend '%s': %w", name, err)
+	}
+
+	m := &mockBackend{
+		dir:        b.Mock,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		listener:   l,
+		name:       name,
+		record:     b.Record,
+		upstream:   strings.TrimSuffix(b.URL, "/"),
+	}
+	m.server = &http.Server{
+		Handler:           m,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return m, nil
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (m *mockBackend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req := MockRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query().Encode(),
+	}
+
+	f, err := m.lookup(req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("mock backend '%s': %s", m.name, err), http.StatusBadGateway)
+		return
+	}
+	if f == nil {
+		if !m.record {
+			http.Error(w, fmt.Sprintf("mock backend '%s': no fixture found in %s for %s %s", m.name, m.dir, req.Method, r.URL.RequestURI()), http.StatusBadGateway)
+			return
+		}
+		if f, err = m.recordFixture(req, r); err != nil {
+			http.Error(w, fmt.Sprintf("mock backend '%s': error recording fixture: %s", m.name, err), http.StatusBadGateway)
+			return
+		}
+	}
+
+	body := []byte(f.Response.Body)
+	if f.Response.BodyBase64 != "" {
+		if body, err = base64.StdEncoding.DecodeString(f.Response.BodyBase64); err != nil {
+			http.Error(w, fmt.Sprintf("mock backend '%s': invalid fixture body: %s", m.name, err), http.StatusBadGateway)
+			return
+		}
+	}
+	for k, vs := range f.Response.Headers {
+		for _, v := range vs {
+			w.Header().Add(k, v)
+		}
+	}
+	status := f.Response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// lookup returns the fixture matching the request, or nil if there isn't one.
+//
+// NOTE: The fixtures are read for every request so they can be edited without
+// restarting the server.
+func (m *mockBackend) lookup(req MockRequest) (*MockFixture, error) {
+	paths, err := filepath.Glob(filepath.Join(m.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		// gosec flagged this:
+		// G304 (CWE-22): Potential file inclusion via variable
+		// Disabling as the fixtures are the user's own project files.
+		/* #nosec */
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var f MockFixture
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("error parsing fixture %s: %w", path, err)
+		}
+		if f.Request.matches(req) {
+			return &f, nil
+		}
+	}
+	return nil, nil
+}
+
+// matches reports whether the fixture request matches the request. A fixture
+// without a method matches GET requests.
+func (fr MockRequest) matches(req MockRequest) bool {
+	method := fr.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	return strings.EqualFold(method, req.Method) && fr.Path == req.Path && fr.Query == req.Query
+}
+
+// recordFixture forwards the request to the upstream URL and records the
+// response as a fixture.
+//
+// NOTE: Concurrent requests missing the same fixture are serialised, so only
+// the first is forwarded upstream and the rest are served its fixture.
+func (m *mockBackend) recordFixture(req MockRequest, r *http.Request) (*MockFixture, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	f, err := m.lookup(req)
+	if err != nil || f != nil {
+		return f, err
+	}
+
+	u := m.upstream + r.URL.RequestURI()
+	upstreamReq, err := http.NewRequest(r.Method, u, r.Body)
+	if err != nil {
+		return nil, err
+	}
+	for k, vs := range r.Header {
+		if k == "Connection" {
+			continue
+		}
+		upstreamReq.Header[k] = vs
+	}
+
+	// gosec flagged this:
+	// G107 (CWE-88): Potential HTTP request made with variable url
+	// Disabling as the URL is the user's own backend.
+	// #nosec
+	resp, err := m.httpClient.Do(upstreamReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // #nosec G307
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := resp.Header.Clone()
+	for _, k := range []string{"Connection", "Content-Length", "Transfer-Encoding"} {
+		headers.Del(k)
+	}
+	f = &MockFixture{
+		Request: req,
+		Response: MockResponse{
+			Status:  resp.StatusCode,
+			Headers: headers,
+		},
+	}
+	if utf8.Valid(body) {
+		f.Response.Body = string(body)
+	} else {
+		f.Response.BodyBase64 = base64.StdEncoding.EncodeToString(body)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(f); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(m.dir, fixtureFilename(req))
+	if filesystem.FileExists(path) {
+		return nil, errors.New("fixture already exists: " + path)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// fixtureFilename returns the name of the file a request is recorded to.
+func fixtureFilename(req MockRequest) string {
+	h := sha256.Sum256([]byte(req.Method + " " + req.Path + "?" + req.Query))
+	slug := strings.Trim(strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '-'
+	}, req.Path), "-")
+	if len(slug) > 40 {
+		slug = slug[:40]
+	}
+	if slug == "" {
+		slug = "root"
+	}
+	return fmt.Sprintf("%s-%s-%s.json", strings.ToLower(req.Method), slug, hex.EncodeToString(h[:])[:12])
+}
@@ -2,18 +2,26 @@ package compute_test
 
 import (
 	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/fastly/cli/pkg/commands/compute"
 	"github.com/fastly/cli/pkg/config"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/mock"
 	"github.com/fastly/cli/pkg/testutil"
 	"github.com/fastly/cli/pkg/text"
+	toml "github.com/pelletier/go-toml"
 )
 
 // TestGetViceroy validates that Viceroy is installed to the appropriate
@@ -112,3 +120,335 @@ func TestGetViceroy(t *testing.T) {
 		t.Fatalf("binary was not moved to the install directory: %s", err)
 	}
 }
+
+// TestGetViceroyPinned validates that the Viceroy version pinned by the
+// fastly.toml [local_server] viceroy_version is installed side by side with
+// other versions and used.
+func TestGetViceroyPinned(t *testing.T) {
+	for _, testcase := range []struct {
+		name         string
+		pin          string
+		installed    []string
+		releases     []string
+		viceroyCheck bool
+		wantVersion  string
+		wantOutput   string
+		wantError    string
+	}{
+		{
+			name:        "exact version is downloaded",
+			pin:         "0.4.5",
+			wantVersion: "0.4.5",
+			wantOutput:  "Fetching Viceroy 0.4.5",
+		},
+		{
+			name:        "exact version already installed",
+			pin:         "v0.4.5",
+			installed:   []string{"0.4.5"},
+			wantVersion: "0.4.5",
+		},
+		{
+			name:        "range uses the latest installed match",
+			pin:         "~0.4",
+			installed:   []string{"0.3.9", "0.4.1", "0.4.3", "0.5.0"},
+			wantVersion: "0.4.3",
+		},
+		{
+			name:        "range without an installed match checks releases",
+			pin:         "^0.5.0",
+			installed:   []string{"0.4.1"},
+			releases:    []string{"0.6.0", "0.5.2", "0.5.1", "0.4.1"},
+			wantVersion: "0.5.2",
+			wantOutput:  "Fetching Viceroy 0.5.2",
+		},
+		{
+			name:         "range with --viceroy-check checks releases",
+			pin:          "~0.4",
+			installed:    []string{"0.4.1"},
+			releases:     []string{"0.4.2", "0.4.1"},
+			viceroyCheck: true,
+			wantVersion:  "0.4.2",
+			wantOutput:   "Checking Viceroy releases matching ~0.4",
+		},
+		{
+			name:      "range without a matching release",
+			pin:       ">= 1.0.0",
+			releases:  []string{"0.4.1"},
+			wantError: "no Viceroy release matches the [local_server] viceroy_version '>= 1.0.0'",
+		},
+		{
+			name:      "invalid pin",
+			pin:       "latest",
+			wantError: "invalid [local_server] viceroy_version 'latest'",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			var dirs []string
+			for _, v := range testcase.installed {
+				dirs = append(dirs, filepath.Join("install", compute.ViceroyVersionsDir, v))
+			}
+			write := []testutil.FileIO{{Src: "...", Dst: "downloaded"}}
+			for _, v := range testcase.installed {
+				write = append(write, testutil.FileIO{Src: "...", Dst: filepath.Join("install", compute.ViceroyVersionsDir, v, "viceroy")})
+			}
+
+			rootdir := testutil.NewEnv(testutil.EnvOpts{
+				T:     t,
+				Dirs:  dirs,
+				Write: write,
+			})
+			defer os.RemoveAll(rootdir)
+
+			installDir := compute.InstallDir
+			compute.InstallDir = filepath.Join(rootdir, "install")
+			defer func() {
+				compute.InstallDir = installDir
+			}()
+
+			av := mock.AssetVersioner{
+				AssetVersions:  testcase.releases,
+				BinaryFilename: "viceroy",
+				DownloadOK:     true,
+				DownloadedFile: filepath.Join(rootdir, "downloaded"),
+			}
+
+			var g global.Data
+			g.ErrLog = fsterr.MockLog{}
+			g.Manifest.File.LocalServer.ViceroyVersion = testcase.pin
+
+			var out bytes.Buffer
+			spinner, err := text.NewSpinner(&out)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bin, err := compute.GetViceroy(spinner, &out, av, &g, "", testcase.viceroyCheck)
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			testutil.AssertStringContains(t, out.String(), testcase.wantOutput)
+			if testcase.wantError != "" {
+				return
+			}
+
+			testutil.AssertString(t, compute.PinnedViceroyPath("viceroy", testcase.wantVersion), bin)
+			if _, err := os.Stat(bin); err != nil {
+				t.Fatalf("binary was not installed: %s", err)
+			}
+		})
+	}
+}
+
+// TestStartMockBackends validates that a backend declared with `mock` is served
+// from its fixtures, and that a missing fixture is recorded from the backend URL
+// when `record` is set.
+func TestStartMockBackends(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var upstreamRequests int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&upstreamRequests, 1)
+		w.Header().Set("X-Upstream", "true")
+		fmt.Fprintf(w, "upstream %s?%s", r.URL.Path, r.URL.RawQuery)
+	}))
+
+	manifestContent := fmt.Sprintf(`name = "test"
+
+[local_server.backends.origin]
+mock = "fixtures/origin"
+
+[local_server.backends.recorded]
+url = "%s"
+mock = "fixtures/recorded"
+record = true
+
+[local_server.backends.real]
+url = "https://example.com"
+`, upstream.URL)
+
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Write: []testutil.FileIO{
+			{Src: manifestContent, Dst: manifest.Filename},
+			{
+				Src: `{"request": {"path": "/users"}, "response": {"status": 201, "headers": {"Content-Type": ["application/json"]}, "body": "[]"}}`,
+				Dst: filepath.Join("fixtures", "origin", "users.json"),
+			},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	var out bytes.Buffer
+	manifestPath, mockDirs, stop, err := compute.StartMockBackends(filepath.Join(rootdir, manifest.Filename), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	testutil.AssertString(t, filepath.Join(rootdir, compute.MockManifestFilename), manifestPath)
+	testutil.AssertStringContains(t, out.String(), "Mocking backend 'origin'")
+	testutil.AssertStringContains(t, out.String(), "recording missing fixtures from "+upstream.URL)
+
+	projectDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertEqual(t, []string{
+		filepath.Join(projectDir, "fixtures", "origin"),
+		filepath.Join(projectDir, "fixtures", "recorded"),
+	}, mockDirs)
+
+	var m manifest.File
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := toml.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	if m.LocalServer.Backends["origin"].Mock != "" || m.LocalServer.Backends["recorded"].Record {
+		t.Fatalf("expected mock settings to be removed: %+v", m.LocalServer.Backends)
+	}
+	testutil.AssertString(t, "https://example.com", m.LocalServer.Backends["real"].URL)
+
+	get := func(u string) (int, string) {
+		resp, err := http.Get(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, string(body)
+	}
+
+	origin := m.LocalServer.Backends["origin"].URL
+	status, body := get(origin + "/users")
+	testutil.AssertEqual(t, http.StatusCreated, status)
+	testutil.AssertString(t, "[]", body)
+
+	status, body = get(origin + "/missing")
+	testutil.AssertEqual(t, http.StatusBadGateway, status)
+	testutil.AssertStringContains(t, body, "no fixture found")
+
+	recorded := m.LocalServer.Backends["recorded"].URL
+	status, body = get(recorded + "/items?b=2&a=1")
+	testutil.AssertEqual(t, http.StatusOK, status)
+	testutil.AssertString(t, "upstream /items?b=2&a=1", body)
+
+	fixtures, err := filepath.Glob(filepath.Join("fixtures", "recorded", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertEqual(t, 1, len(fixtures))
+
+	// Concurrent requests missing the same fixture only record it once.
+	var wg sync.WaitGroup
+	errs := make([]error, 5)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := http.Get(recorded + "/concurrent")
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				errs[i] = fmt.Errorf("unexpected status: %d", resp.StatusCode)
+			}
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		testutil.AssertNoError(t, err)
+	}
+	testutil.AssertEqual(t, int32(2), atomic.LoadInt32(&upstreamRequests))
+
+	// The recorded fixture is served once the upstream is no longer available.
+	upstream.Close()
+	status, body = get(recorded + "/items?a=1&b=2")
+	testutil.AssertEqual(t, http.StatusOK, status)
+	testutil.AssertString(t, "upstream /items?b=2&a=1", body)
+
+	stop()
+	if _, err := os.Stat(manifestPath); !os.IsNotExist(err) {
+		t.Fatalf("expected %s to be removed", manifestPath)
+	}
+}
+
+// TestStartMockBackendsEnv validates that the backends mocked are those of the
+// environment manifest Viceroy is configured with, rather than the fastly.toml.
+func TestStartMockBackendsEnv(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Write: []testutil.FileIO{
+			{Src: `name = "test"
+
+[local_server.backends.origin]
+mock = "fixtures/origin"
+`, Dst: manifest.Filename},
+			{Src: `name = "test"
+
+[local_server.backends.origin]
+url = "https://example.com"
+
+[local_server.backends.stage]
+mock = "fixtures/stage"
+`, Dst: manifest.EnvFilename("stage")},
+			{
+				Src: `{"request": {"path": "/"}, "response": {"status": 200}}`,
+				Dst: filepath.Join("fixtures", "stage", "index.json"),
+			},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	var out bytes.Buffer
+	manifestPath, mockDirs, stop, err := compute.StartMockBackends(filepath.Join(rootdir, manifest.EnvFilename("stage")), &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	testutil.AssertStringContains(t, out.String(), "Mocking backend 'stage'")
+	testutil.AssertStringDoesntContain(t, out.String(), "Mocking backend 'origin'")
+
+	projectDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertEqual(t, []string{filepath.Join(projectDir, "fixtures", "stage")}, mockDirs)
+
+	var m manifest.File
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := toml.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertString(t, "https://example.com", m.LocalServer.Backends["origin"].URL)
+	if m.LocalServer.Backends["stage"].Mock != "" {
+		t.Fatalf("expected mock settings to be removed: %+v", m.LocalServer.Backends)
+	}
+}
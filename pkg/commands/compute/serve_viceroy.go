@@ -0,0 +1,214 @@
+package compute
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/filesystem"
+	"github.com/fastly/cli/pkg/github"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ViceroyVersionsDir is the directory (within the InstallDir) where the
+// Viceroy versions pinned by projects are installed side by side.
+const ViceroyVersionsDir = "viceroy-versions"
+
+// getPinnedViceroy returns the path to the Viceroy binary matching the version
+// pinned by the fastly.toml [local_server] viceroy_version, installing it if
+// necessary.
+//
+// NOTE: The versions pinned by projects are installed independently of the
+// latest version (see GetViceroy) and are never updated. When the pin is a
+// range, the latest installed version satisfying it is used, unless none is
+// installed or viceroyCheck is set, in which case the Viceroy releases are
+// checked for the latest version satisfying it.
+func getPinnedViceroy(
+	spinner text.Spinner,
+	out io.Writer,
+	av github.AssetVersioner,
+	g *global.Data,
+	pin string,
+	viceroyCheck bool,
+) (bin string, err error) {
+	version, err := resolveViceroyVersion(spinner, av, pin, viceroyCheck)
+	if err != nil {
+		g.ErrLog.AddWithContext(err, map[string]any{
+			"Viceroy version": pin,
+		})
+		return "", err
+	}
+
+	bin = PinnedViceroyPath(av.BinaryName(), version)
+	if !filesystem.FileExists(bin) {
+		err := installPinnedViceroy(spinner, av, version, bin)
+		if err != nil {
+			g.ErrLog.Add(err)
+			return bin, err
+		}
+	}
+
+	if g.Verbose() {
+		text.Info(out, "Using Viceroy %s as pinned by the fastly.toml [local_server] viceroy_version (%s): %s", version, pin, bin)
+		text.Break(out)
+	}
+
+	err = setBinPerms(bin)
+	if err != nil {
+		g.ErrLog.Add(err)
+		return bin, err
+	}
+	return bin, nil
+}
+
+// PinnedViceroyPath returns the path to a pinned version of Viceroy.
+func PinnedViceroyPath(binaryName, version string) string {
+	return filepath.Join(InstallDir, ViceroyVersionsDir, version, binaryName)
+}
+
+// resolveViceroyVersion returns the Viceroy version matching the pin.
+func resolveViceroyVersion(spinner text.Spinner, av github.AssetVersioner, pin string, viceroyCheck bool) (string, error) {
+	pin = strings.TrimSpace(pin)
+	if v, err := semver.StrictNewVersion(strings.TrimPrefix(pin, "v")); err == nil {
+		return v.String(), nil
+	}
+
+	c, err := semver.NewConstraint(pin)
+	if err != nil {
+		return "", fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid [local_server] viceroy_version '%s': %w", pin, err),
+			Remediation: "Set viceroy_version to a version (e.g. 0.4.5) or semver range (e.g. ~0.4) in the fastly.toml manifest.",
+		}
+	}
+
+	if !viceroyCheck {
+		if v := latestMatchingVersion(installedViceroyVersions(), c); v != "" {
+			return v, nil
+		}
+	}
+
+	err = spinner.Start()
+	if err != nil {
+		return "", err
+	}
+	msg := fmt.Sprintf("Checking Viceroy releases matching %s", pin)
+	spinner.Message(msg + "...")
+
+	versions, err := av.Versions()
+	if err != nil {
+		spinner.StopFailMessage(msg)
+		spinErr := spinner.StopFail()
+		if spinErr != nil {
+			return "", spinErr
+		}
+		return "", fsterr.RemediationError{
+			Inner:       fmt.Errorf("error fetching Viceroy releases: %w", err),
+			Remediation: fsterr.NetworkRemediation,
+		}
+	}
+
+	v := latestMatchingVersion(versions, c)
+	if v == "" {
+		spinner.StopFailMessage(msg)
+		spinErr := spinner.StopFail()
+		if spinErr != nil {
+			return "", spinErr
+		}
+		return "", fsterr.RemediationError{
+			Inner:       fmt.Errorf("no Viceroy release matches the [local_server] viceroy_version '%s'", pin),
+			Remediation: "Check the available versions at https://github.com/fastly/Viceroy/releases and update the fastly.toml manifest.",
+		}
+	}
+
+	spinner.StopMessage(msg)
+	return v, spinner.Stop()
+}
+
+// installedViceroyVersions returns the pinned Viceroy versions installed.
+func installedViceroyVersions() []string {
+	entries, err := os.ReadDir(filepath.Join(InstallDir, ViceroyVersionsDir))
+	if err != nil {
+		return nil
+	}
+	var versions []string
+	for _, e := range entries {
+		if e.IsDir() {
+			versions = append(versions, e.Name())
+		}
+	}
+	return versions
+}
+
+// latestMatchingVersion returns the latest of the versions satisfying the
+// constraint, or an empty string if none do.
+func latestMatchingVersion(versions []string, c *semver.Constraints) string {
+	var matches []*semver.Version
+	for _, s := range versions {
+		v, err := semver.StrictNewVersion(s)
+		if err != nil {
+			continue
+		}
+		if c.Check(v) {
+			matches = append(matches, v)
+		}
+	}
+	if len(matches) == 0 {
+		return ""
+	}
+	sort.Sort(semver.Collection(matches))
+	return matches[len(matches)-1].String()
+}
+
+// installPinnedViceroy downloads a specific Viceroy release from GitHub.
+func installPinnedViceroy(spinner text.Spinner, av github.AssetVersioner, version, bin string) error {
+	err := spinner.Start()
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("Fetching Viceroy %s", version)
+	spinner.Message(msg + "...")
+
+	tmpBin, err := av.DownloadVersion(version)
+	if err != nil {
+		spinner.StopFailMessage(msg)
+		spinErr := spinner.StopFail()
+		if spinErr != nil {
+			return spinErr
+		}
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("error downloading Viceroy %s: %w", version, err),
+			Remediation: "Check the version exists at https://github.com/fastly/Viceroy/releases. " + fsterr.NetworkRemediation,
+		}
+	}
+	defer os.RemoveAll(tmpBin)
+
+	if err := filesystem.MakeDirectoryIfNotExists(filepath.Dir(bin)); err != nil {
+		spinner.StopFailMessage(msg)
+		spinErr := spinner.StopFail()
+		if spinErr != nil {
+			return spinErr
+		}
+		return fmt.Errorf("error creating Viceroy install directory: %w", err)
+	}
+
+	if err := os.Rename(tmpBin, bin); err != nil {
+		if err := filesystem.CopyFile(tmpBin, bin); err != nil {
+			spinner.StopFailMessage(msg)
+			spinErr := spinner.StopFail()
+			if spinErr != nil {
+				return spinErr
+			}
+			return fmt.Errorf("error moving Viceroy %s binary in place: %w", version, err)
+		}
+	}
+
+	spinner.StopMessage(msg)
+	return spinner.Stop()
+}
@@ -0,0 +1,221 @@
+package setup
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"sort"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// ACLs represents the service state related to ACLs defined within the
+// fastly.toml [setup] configuration.
+//
+// NOTE: It implements the setup.Interface interface.
+type ACLs struct {
+	// Public
+	APIClient      api.Interface
+	AcceptDefaults bool
+	NonInteractive bool
+	Spinner        text.Spinner
+	ServiceID      string
+	ServiceVersion int
+	Setup          map[string]*manifest.SetupACL
+	Stdout         io.Writer
+
+	// Private
+	missing  bool
+	required []ACL
+}
+
+// ACL represents the configuration parameters for creating an ACL via the API
+// client.
+type ACL struct {
+	Name    string
+	Entries []ACLEntry
+}
+
+// ACLEntry represents the configuration parameters for creating ACL entries
+// via the API client.
+type ACLEntry struct {
+	Comment string
+	IP      string
+	Negated bool
+	Subnet  int
+}
+
+// Configure validates the ACL entries declared in the fastly.toml.
+//
+// NOTE: Unlike other resources the user isn't prompted for values, as the ACL
+// entries are fully declared.
+func (a *ACLs) Configure() error {
+	names := make([]string, 0, len(a.Setup))
+	for name := range a.Setup {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		settings := a.Setup[name]
+		if !a.AcceptDefaults && !a.NonInteractive {
+			text.Break(a.Stdout)
+			text.Output(a.Stdout, "Configuring ACL '%s' (%d entries)", name, len(settings.Entries))
+			if settings.Description != "" {
+				text.Output(a.Stdout, settings.Description)
+			}
+		}
+
+		var entries []ACLEntry
+
+		for _, entry := range settings.Entries {
+			ip := net.ParseIP(entry.IP)
+			if ip == nil {
+				return fmt.Errorf("invalid IP address '%s' for ACL '%s'", entry.IP, name)
+			}
+			maxSubnet := 128
+			if ip.To4() != nil {
+				maxSubnet = 32
+			}
+			if entry.Subnet < 0 || entry.Subnet > maxSubnet {
+				return fmt.Errorf("invalid subnet '%d' for ACL '%s' entry '%s'", entry.Subnet, name, entry.IP)
+			}
+			entries = append(entries, ACLEntry{
+				Comment: entry.Comment,
+				IP:      entry.IP,
+				Negated: entry.Negated,
+				Subnet:  entry.Subnet,
+			})
+		}
+
+		a.required = append(a.required, ACL{
+			Name:    name,
+			Entries: entries,
+		})
+	}
+
+	return nil
+}
+
+// Create calls the relevant API to create the service resource(s).
+func (a *ACLs) Create() error {
+	if a.Spinner == nil {
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("internal logic error: no text.Progress configured for setup.ACLs"),
+			Remediation: errors.BugRemediation,
+		}
+	}
+
+	for _, acl := range a.required {
+		err := a.Spinner.Start()
+		if err != nil {
+			return err
+		}
+		msg := fmt.Sprintf("Creating ACL '%s'", acl.Name)
+		a.Spinner.Message(msg + "...")
+
+		created, err := a.APIClient.CreateACL(&fastly.CreateACLInput{
+			ServiceID:      a.ServiceID,
+			ServiceVersion: a.ServiceVersion,
+			Name:           fastly.String(acl.Name),
+		})
+		if err != nil {
+			a.Spinner.StopFailMessage(msg)
+			err := a.Spinner.StopFail()
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("error creating ACL: %w", err)
+		}
+
+		a.Spinner.StopMessage(msg)
+		err = a.Spinner.Stop()
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range acl.Entries {
+			err := a.Spinner.Start()
+			if err != nil {
+				return err
+			}
+			msg := fmt.Sprintf("Creating ACL entry '%s'", entry.IP)
+			a.Spinner.Message(msg + "...")
+
+			input := &fastly.CreateACLEntryInput{
+				ServiceID: a.ServiceID,
+				ACLID:     created.ID,
+				IP:        fastly.String(entry.IP),
+				Negated:   fastly.CBool(entry.Negated),
+			}
+			if entry.Subnet > 0 {
+				input.Subnet = fastly.Int(entry.Subnet)
+			}
+			if entry.Comment != "" {
+				input.Comment = fastly.String(entry.Comment)
+			}
+
+			_, err = a.APIClient.CreateACLEntry(input)
+			if err != nil {
+				a.Spinner.StopFailMessage(msg)
+				err := a.Spinner.StopFail()
+				if err != nil {
+					return err
+				}
+				return fmt.Errorf("error creating ACL entry: %w", err)
+			}
+
+			a.Spinner.StopMessage(msg)
+			err = a.Spinner.Stop()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Predefined indicates if the service resource has been specified within the
+// fastly.toml file using a [setup] configuration block.
+func (a *ACLs) Predefined() bool {
+	return len(a.Setup) > 0
+}
+
+// Missing indicates if there are missing resources that need to be created.
+func (a *ACLs) Missing() bool {
+	return a.missing
+}
+
+// Validate checks if the service has the required resources.
+//
+// NOTE: The [setup.acls] configuration is reduced to the ACLs missing from the
+// service, so that only those are created. The entries of an existing ACL are
+// not reconciled.
+func (a *ACLs) Validate() error {
+	available, err := a.APIClient.ListACLs(&fastly.ListACLsInput{
+		ServiceID:      a.ServiceID,
+		ServiceVersion: a.ServiceVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("error fetching service ACLs: %w", err)
+	}
+	names := make(map[string]bool, len(available))
+	for _, acl := range available {
+		names[acl.Name] = true
+	}
+
+	missing := make(map[string]*manifest.SetupACL)
+	for name, settings := range a.Setup {
+		if !names[name] {
+			missing[name] = settings
+		}
+	}
+	a.Setup = missing
+	a.missing = len(missing) > 0
+	return nil
+}
@@ -31,6 +31,7 @@ type Backends struct {
 	Stdout         io.Writer
 
 	// Private
+	missing  bool
 	required []Backend
 }
 
@@ -128,6 +129,39 @@ func (b *Backends) Predefined() bool {
 	return len(b.Setup) > 0
 }
 
+// Missing indicates if there are missing resources that need to be created.
+func (b *Backends) Missing() bool {
+	return b.missing
+}
+
+// Validate checks if the service has the required resources.
+//
+// NOTE: The [setup.backends] configuration is reduced to the backends missing
+// from the service, so that only those are configured and created.
+func (b *Backends) Validate() error {
+	available, err := b.APIClient.ListBackends(&fastly.ListBackendsInput{
+		ServiceID:      b.ServiceID,
+		ServiceVersion: b.ServiceVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("error fetching service backends: %w", err)
+	}
+	names := make(map[string]bool, len(available))
+	for _, backend := range available {
+		names[backend.Name] = true
+	}
+
+	missing := make(map[string]*manifest.SetupBackend)
+	for name, settings := range b.Setup {
+		if !names[name] {
+			missing[name] = settings
+		}
+	}
+	b.Setup = missing
+	b.missing = len(missing) > 0
+	return nil
+}
+
 // isOriginless indicates if the required backend is originless.
 func (b *Backends) isOriginless() bool {
 	return len(b.required) == 1 && b.required[0].Name == "originless" && b.required[0].Address == "127.0.0.1"
@@ -0,0 +1,245 @@
+package setup
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// ConfigStores represents the service state related to config stores defined
+// within the fastly.toml [setup] configuration.
+//
+// NOTE: It implements the setup.Interface interface.
+type ConfigStores struct {
+	// Public
+	APIClient      api.Interface
+	AcceptDefaults bool
+	NonInteractive bool
+	Spinner        text.Spinner
+	ServiceID      string
+	ServiceVersion int
+	Setup          map[string]*manifest.SetupConfigStore
+	Stdin          io.Reader
+	Stdout         io.Writer
+
+	// Private
+	existing map[string]string
+	missing  bool
+	required []ConfigStore
+}
+
+// ConfigStore represents the configuration parameters for creating a
+// config store via the API client.
+type ConfigStore struct {
+	// ID is set when the store already exists, in which case it's only
+	// linked to the service.
+	ID    string
+	Name  string
+	Items []ConfigStoreItem
+}
+
+// ConfigStoreItem represents the configuration parameters for creating
+// config store items via the API client.
+type ConfigStoreItem struct {
+	Key   string
+	Value string
+}
+
+// Configure prompts the user for specific values related to the service resource.
+func (c *ConfigStores) Configure() error {
+	for name, settings := range c.Setup {
+		if id, ok := c.existing[name]; ok {
+			c.required = append(c.required, ConfigStore{Name: name, ID: id})
+			continue
+		}
+
+		if !c.AcceptDefaults && !c.NonInteractive {
+			text.Break(c.Stdout)
+			text.Output(c.Stdout, "Configuring config store '%s'", name)
+			if settings.Description != "" {
+				text.Output(c.Stdout, settings.Description)
+			}
+		}
+
+		var items []ConfigStoreItem
+
+		for key, item := range settings.Items {
+			dv := "example"
+			if item.Value != "" {
+				dv = item.Value
+			}
+			prompt := text.BoldYellow(fmt.Sprintf("Value: [%s] ", dv))
+
+			var (
+				value string
+				err   error
+			)
+
+			if !c.AcceptDefaults && !c.NonInteractive {
+				text.Break(c.Stdout)
+				text.Output(c.Stdout, "Create a config store item called '%s'", key)
+				if item.Description != "" {
+					text.Output(c.Stdout, item.Description)
+				}
+				text.Break(c.Stdout)
+
+				value, err = text.Input(c.Stdout, prompt, c.Stdin)
+				if err != nil {
+					return fmt.Errorf("error reading prompt input: %w", err)
+				}
+			}
+
+			if value == "" {
+				value = dv
+			}
+
+			items = append(items, ConfigStoreItem{
+				Key:   key,
+				Value: value,
+			})
+		}
+
+		c.required = append(c.required, ConfigStore{
+			Name:  name,
+			Items: items,
+		})
+	}
+
+	return nil
+}
+
+// Create calls the relevant API to create the service resource(s).
+func (c *ConfigStores) Create() error {
+	if c.Spinner == nil {
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("internal logic error: no text.Progress configured for setup.ConfigStores"),
+			Remediation: errors.BugRemediation,
+		}
+	}
+
+	for _, configStore := range c.required {
+		if configStore.ID != "" {
+			err := createResourceLink(c.APIClient, c.Spinner, c.ServiceID, c.ServiceVersion, "config store", configStore.Name, configStore.ID)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		err := c.Spinner.Start()
+		if err != nil {
+			return err
+		}
+		msg := fmt.Sprintf("Creating config store '%s'", configStore.Name)
+		c.Spinner.Message(msg + "...")
+
+		store, err := c.APIClient.CreateConfigStore(&fastly.CreateConfigStoreInput{
+			Name: configStore.Name,
+		})
+		if err != nil {
+			c.Spinner.StopFailMessage(msg)
+			err := c.Spinner.StopFail()
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("error creating config store: %w", err)
+		}
+
+		c.Spinner.StopMessage(msg)
+		err = c.Spinner.Stop()
+		if err != nil {
+			return err
+		}
+
+		if len(configStore.Items) > 0 {
+			for _, item := range configStore.Items {
+				err := c.Spinner.Start()
+				if err != nil {
+					return err
+				}
+				msg := fmt.Sprintf("Creating config store item '%s'", item.Key)
+				c.Spinner.Message(msg + "...")
+
+				_, err = c.APIClient.CreateConfigStoreItem(&fastly.CreateConfigStoreItemInput{
+					StoreID: store.ID,
+					Key:     item.Key,
+					Value:   item.Value,
+				})
+				if err != nil {
+					c.Spinner.StopFailMessage(msg)
+					err := c.Spinner.StopFail()
+					if err != nil {
+						return err
+					}
+					return fmt.Errorf("error creating config store item: %w", err)
+				}
+
+				c.Spinner.StopMessage(msg)
+				err = c.Spinner.Stop()
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		err = createResourceLink(c.APIClient, c.Spinner, c.ServiceID, c.ServiceVersion, "config store", configStore.Name, store.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Predefined indicates if the service resource has been specified within the
+// fastly.toml file using a [setup] configuration block.
+func (c *ConfigStores) Predefined() bool {
+	return len(c.Setup) > 0
+}
+
+// Missing indicates if there are missing resources that need to be created.
+func (c *ConfigStores) Missing() bool {
+	return c.missing
+}
+
+// Validate checks if the service has the required resources.
+//
+// NOTE: The [setup.config_stores] configuration is reduced to the config stores
+// not linked to the service. A config store that already exists is only linked,
+// its contents are left unchanged.
+func (c *ConfigStores) Validate() error {
+	linked, err := linkedResources(c.APIClient, c.ServiceID, c.ServiceVersion)
+	if err != nil {
+		return err
+	}
+
+	available, err := c.APIClient.ListConfigStores()
+	if err != nil {
+		return fmt.Errorf("error fetching config stores: %w", err)
+	}
+	stores := make(map[string]string, len(available))
+	for _, store := range available {
+		stores[store.Name] = store.ID
+	}
+
+	c.existing = make(map[string]string)
+	missing := make(map[string]*manifest.SetupConfigStore)
+	for name, settings := range c.Setup {
+		id, ok := stores[name]
+		if ok && linked[id] {
+			continue
+		}
+		if ok {
+			c.existing[name] = id
+		}
+		missing[name] = settings
+	}
+	c.Setup = missing
+	c.missing = len(missing) > 0
+	return nil
+}
@@ -28,6 +28,7 @@ type Dictionaries struct {
 	Stdout         io.Writer
 
 	// Private
+	missing  bool
 	required []Dictionary
 }
 
@@ -183,3 +184,36 @@ func (d *Dictionaries) Create() error {
 func (d *Dictionaries) Predefined() bool {
 	return len(d.Setup) > 0
 }
+
+// Missing indicates if there are missing resources that need to be created.
+func (d *Dictionaries) Missing() bool {
+	return d.missing
+}
+
+// Validate checks if the service has the required resources.
+//
+// NOTE: The [setup.dictionaries] configuration is reduced to the dictionaries
+// missing from the service, so that only those are configured and created.
+func (d *Dictionaries) Validate() error {
+	available, err := d.APIClient.ListDictionaries(&fastly.ListDictionariesInput{
+		ServiceID:      d.ServiceID,
+		ServiceVersion: d.ServiceVersion,
+	})
+	if err != nil {
+		return fmt.Errorf("error fetching service dictionaries: %w", err)
+	}
+	names := make(map[string]bool, len(available))
+	for _, dictionary := range available {
+		names[dictionary.Name] = true
+	}
+
+	missing := make(map[string]*manifest.SetupDictionary)
+	for name, settings := range d.Setup {
+		if !names[name] {
+			missing[name] = settings
+		}
+	}
+	d.Setup = missing
+	d.missing = len(missing) > 0
+	return nil
+}
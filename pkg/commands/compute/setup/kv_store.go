@@ -28,12 +28,17 @@ type KVStores struct {
 	Stdout         io.Writer
 
 	// Private
+	existing map[string]string
+	missing  bool
 	required []KVStore
 }
 
 // KVStore represents the configuration parameters for creating an
 // kv store via the API client.
 type KVStore struct {
+	// ID is set when the store already exists, in which case it's only
+	// linked to the service.
+	ID    string
 	Name  string
 	Items []KVStoreItem
 }
@@ -48,6 +53,11 @@ type KVStoreItem struct {
 // Configure prompts the user for specific values related to the service resource.
 func (o *KVStores) Configure() error {
 	for name, settings := range o.Setup {
+		if id, ok := o.existing[name]; ok {
+			o.required = append(o.required, KVStore{Name: name, ID: id})
+			continue
+		}
+
 		if !o.AcceptDefaults && !o.NonInteractive {
 			text.Break(o.Stdout)
 			text.Output(o.Stdout, "Configuring kv store '%s'", name)
@@ -113,6 +123,14 @@ func (o *KVStores) Create() error {
 	}
 
 	for _, kvStore := range o.required {
+		if kvStore.ID != "" {
+			err := createResourceLink(o.APIClient, o.Spinner, o.ServiceID, o.ServiceVersion, "kv store", kvStore.Name, kvStore.ID)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		err := o.Spinner.Start()
 		if err != nil {
 			return err
@@ -169,31 +187,7 @@ func (o *KVStores) Create() error {
 			}
 		}
 
-		err = o.Spinner.Start()
-		if err != nil {
-			return err
-		}
-		msg = fmt.Sprintf("Creating resource link between service and kv store '%s'...", kvStore.Name)
-		o.Spinner.Message(msg)
-
-		// IMPORTANT: We need to link the kv store to the C@E Service.
-		_, err = o.APIClient.CreateResource(&fastly.CreateResourceInput{
-			ServiceID:      o.ServiceID,
-			ServiceVersion: o.ServiceVersion,
-			Name:           fastly.String(store.Name),
-			ResourceID:     fastly.String(store.ID),
-		})
-		if err != nil {
-			o.Spinner.StopFailMessage(msg)
-			err := o.Spinner.StopFail()
-			if err != nil {
-				return err
-			}
-			return fmt.Errorf("error creating resource link between the service '%s' and the kv store '%s': %w", o.ServiceID, store.Name, err)
-		}
-
-		o.Spinner.StopMessage(msg)
-		err = o.Spinner.Stop()
+		err = createResourceLink(o.APIClient, o.Spinner, o.ServiceID, o.ServiceVersion, "kv store", kvStore.Name, store.ID)
 		if err != nil {
 			return err
 		}
@@ -207,3 +201,51 @@ func (o *KVStores) Create() error {
 func (o *KVStores) Predefined() bool {
 	return len(o.Setup) > 0
 }
+
+// Missing indicates if there are missing resources that need to be created.
+func (o *KVStores) Missing() bool {
+	return o.missing
+}
+
+// Validate checks if the service has the required resources.
+//
+// NOTE: The [setup.kv_stores] configuration is reduced to the kv stores not
+// linked to the service. A kv store that already exists is only linked, its
+// contents are left unchanged.
+func (o *KVStores) Validate() error {
+	linked, err := linkedResources(o.APIClient, o.ServiceID, o.ServiceVersion)
+	if err != nil {
+		return err
+	}
+
+	stores := make(map[string]string)
+	var cursor string
+	for {
+		page, err := o.APIClient.ListKVStores(&fastly.ListKVStoresInput{Cursor: cursor})
+		if err != nil {
+			return fmt.Errorf("error fetching kv stores: %w", err)
+		}
+		for _, store := range page.Data {
+			stores[store.Name] = store.ID
+		}
+		if cursor = page.Meta["next_cursor"]; cursor == "" {
+			break
+		}
+	}
+
+	o.existing = make(map[string]string)
+	missing := make(map[string]*manifest.SetupKVStore)
+	for name, settings := range o.Setup {
+		id, ok := stores[name]
+		if ok && linked[id] {
+			continue
+		}
+		if ok {
+			o.existing[name] = id
+		}
+		missing[name] = settings
+	}
+	o.Setup = missing
+	o.missing = len(missing) > 0
+	return nil
+}
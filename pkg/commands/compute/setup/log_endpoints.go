@@ -0,0 +1,94 @@
+package setup
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/fastly/cli/pkg/api"
+)
+
+// logEndpointProvider creates a log endpoint for a specific provider.
+type logEndpointProvider struct {
+	// input returns a pointer to a zero value of the API input.
+	input func() any
+	// create calls the API with the input returned by input.
+	create func(api.Interface, any) error
+	// list returns the names of the provider's log endpoints on a service
+	// version.
+	list func(c api.Interface, serviceID string, serviceVersion int) ([]string, error)
+}
+
+// provider returns a logEndpointProvider for the API input type T and list
+// input type L.
+//
+// NOTE: Every provider's list input has ServiceID and ServiceVersion fields,
+// and every log endpoint has a Name field, which are accessed via reflection.
+func provider[T, R, L, E any](
+	create func(api.Interface, *T) (R, error),
+	list func(api.Interface, *L) ([]E, error),
+) logEndpointProvider {
+	return logEndpointProvider{
+		input: func() any { return new(T) },
+		create: func(c api.Interface, i any) error {
+			_, err := create(c, i.(*T))
+			return err
+		},
+		list: func(c api.Interface, serviceID string, serviceVersion int) ([]string, error) {
+			input := new(L)
+			v := reflect.ValueOf(input).Elem()
+			v.FieldByName("ServiceID").SetString(serviceID)
+			v.FieldByName("ServiceVersion").SetInt(int64(serviceVersion))
+
+			endpoints, err := list(c, input)
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(endpoints))
+			for _, e := range endpoints {
+				names = append(names, reflect.Indirect(reflect.ValueOf(e)).FieldByName("Name").String())
+			}
+			return names, nil
+		},
+	}
+}
+
+// logEndpointProviders maps the name of each provider (matching its `fastly
+// logging` subcommand) to the API used to create the log endpoint.
+var logEndpointProviders = map[string]logEndpointProvider{
+	"azureblob":     provider(api.Interface.CreateBlobStorage, api.Interface.ListBlobStorages),
+	"bigquery":      provider(api.Interface.CreateBigQuery, api.Interface.ListBigQueries),
+	"cloudfiles":    provider(api.Interface.CreateCloudfiles, api.Interface.ListCloudfiles),
+	"datadog":       provider(api.Interface.CreateDatadog, api.Interface.ListDatadog),
+	"digitalocean":  provider(api.Interface.CreateDigitalOcean, api.Interface.ListDigitalOceans),
+	"elasticsearch": provider(api.Interface.CreateElasticsearch, api.Interface.ListElasticsearch),
+	"ftp":           provider(api.Interface.CreateFTP, api.Interface.ListFTPs),
+	"gcs":           provider(api.Interface.CreateGCS, api.Interface.ListGCSs),
+	"googlepubsub":  provider(api.Interface.CreatePubsub, api.Interface.ListPubsubs),
+	"heroku":        provider(api.Interface.CreateHeroku, api.Interface.ListHerokus),
+	"honeycomb":     provider(api.Interface.CreateHoneycomb, api.Interface.ListHoneycombs),
+	"https":         provider(api.Interface.CreateHTTPS, api.Interface.ListHTTPS),
+	"kafka":         provider(api.Interface.CreateKafka, api.Interface.ListKafkas),
+	"kinesis":       provider(api.Interface.CreateKinesis, api.Interface.ListKinesis),
+	"loggly":        provider(api.Interface.CreateLoggly, api.Interface.ListLoggly),
+	"logshuttle":    provider(api.Interface.CreateLogshuttle, api.Interface.ListLogshuttles),
+	"newrelic":      provider(api.Interface.CreateNewRelic, api.Interface.ListNewRelic),
+	"openstack":     provider(api.Interface.CreateOpenstack, api.Interface.ListOpenstack),
+	"papertrail":    provider(api.Interface.CreatePapertrail, api.Interface.ListPapertrails),
+	"s3":            provider(api.Interface.CreateS3, api.Interface.ListS3s),
+	"scalyr":        provider(api.Interface.CreateScalyr, api.Interface.ListScalyrs),
+	"sftp":          provider(api.Interface.CreateSFTP, api.Interface.ListSFTPs),
+	"splunk":        provider(api.Interface.CreateSplunk, api.Interface.ListSplunks),
+	"sumologic":     provider(api.Interface.CreateSumologic, api.Interface.ListSumologics),
+	"syslog":        provider(api.Interface.CreateSyslog, api.Interface.ListSyslogs),
+}
+
+// LogEndpointProviders returns the sorted names of the providers a log
+// endpoint can be created for from the [setup] configuration.
+func LogEndpointProviders() []string {
+	names := make([]string, 0, len(logEndpointProviders))
+	for name := range logEndpointProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -1,46 +1,192 @@
 package setup
 
 import (
+	"fmt"
 	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
 
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/manifest"
 	"github.com/fastly/cli/pkg/text"
+	"github.com/mitchellh/mapstructure"
 )
 
+// envPlaceholder matches a log endpoint parameter value to be read from the
+// environment (e.g. ${AWS_SECRET_ACCESS_KEY}).
+var envPlaceholder = regexp.MustCompile(`^\$\{([A-Za-z_][A-Za-z0-9_]*)\}$`)
+
 // Loggers represents the service state related to log entries defined within
 // the fastly.toml [setup] configuration.
 //
 // NOTE: It implements the setup.Interface interface.
 type Loggers struct {
-	Setup  map[string]*manifest.SetupLogger
-	Stdout io.Writer
+	// Public
+	APIClient      api.Interface
+	AcceptDefaults bool
+	NonInteractive bool
+	Spinner        text.Spinner
+	ServiceID      string
+	ServiceVersion int
+	Setup          map[string]*manifest.SetupLogger
+	Stdin          io.Reader
+	Stdout         io.Writer
+
+	// Private
+	missing  bool
+	required []Logger
 }
 
-// Logger represents the configuration parameters for creating a dictionary
+// Logger represents the configuration parameters for creating a log endpoint
 // via the API client.
 type Logger struct {
-	Provider string
+	Name       string
+	Parameters map[string]any
+	Provider   string
 }
 
 // Configure prompts the user for specific values related to the service resource.
+//
+// NOTE: Log endpoints declared without any parameters can't be created, as the
+// API input fields vary significantly between providers, so we only display a
+// message informing the user that they need to create them manually.
 func (l *Loggers) Configure() error {
+	names := make([]string, 0, len(l.Setup))
+	for name := range l.Setup {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var manual []string
+
+	for _, name := range names {
+		settings := l.Setup[name]
+		if len(settings.Parameters) == 0 {
+			manual = append(manual, name)
+			continue
+		}
+
+		provider := strings.ToLower(settings.Provider)
+		if _, ok := logEndpointProviders[provider]; !ok {
+			return errors.RemediationError{
+				Inner:       fmt.Errorf("unsupported provider '%s' for log endpoint '%s'", settings.Provider, name),
+				Remediation: fmt.Sprintf("Set the provider to one of: %s", strings.Join(LogEndpointProviders(), ", ")),
+			}
+		}
+
+		params := make(map[string]any, len(settings.Parameters))
+		for key, value := range settings.Parameters {
+			if s, ok := value.(string); ok {
+				if m := envPlaceholder.FindStringSubmatch(s); m != nil {
+					v, err := l.lookupEnv(name, key, m[1])
+					if err != nil {
+						return err
+					}
+					value = v
+				}
+			}
+			params[key] = value
+		}
+
+		logger := Logger{
+			Name:       name,
+			Parameters: params,
+			Provider:   provider,
+		}
+		// Validate the parameters before any resources are created.
+		if _, err := logger.input(l.ServiceID, l.ServiceVersion); err != nil {
+			return err
+		}
+		l.required = append(l.required, logger)
+	}
+
+	if len(manual) > 0 {
+		text.Break(l.Stdout)
+		text.Info(l.Stdout, "The package code requires the following log endpoints to be created.")
+		text.Break(l.Stdout)
+
+		for _, name := range manual {
+			text.Output(l.Stdout, "%s %s", text.Bold("Name:"), name)
+			if provider := l.Setup[name].Provider; provider != "" {
+				text.Output(l.Stdout, "%s %s", text.Bold("Provider:"), provider)
+			}
+			text.Break(l.Stdout)
+		}
+
+		text.Description(
+			l.Stdout,
+			"Refer to the help documentation for each provider (if no provider shown, then select your own)",
+			"fastly logging <provider> create --help",
+		)
+	}
+
+	return nil
+}
+
+// lookupEnv returns the value of the environment variable referenced by a log
+// endpoint parameter, prompting the user for the value if it's not set.
+func (l *Loggers) lookupEnv(name, key, env string) (string, error) {
+	if v, ok := os.LookupEnv(env); ok {
+		return v, nil
+	}
+	if l.AcceptDefaults || l.NonInteractive {
+		return "", fmt.Errorf("environment variable $%s (used by '%s' for log endpoint '%s') is not set", env, key, name)
+	}
+
 	text.Break(l.Stdout)
-	text.Info(l.Stdout, "The package code requires the following log endpoints to be created.")
+	text.Output(l.Stdout, "The '%s' of log endpoint '%s' is read from $%s, which is not set", key, name, env)
 	text.Break(l.Stdout)
 
-	for name, settings := range l.Setup {
-		text.Output(l.Stdout, "%s %s", text.Bold("Name:"), name)
-		if settings.Provider != "" {
-			text.Output(l.Stdout, "%s %s", text.Bold("Provider:"), settings.Provider)
+	value, err := text.InputSecure(l.Stdout, text.BoldYellow("Value: "), l.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("error reading prompt input: %w", err)
+	}
+	if value == "" {
+		return "", fmt.Errorf("value for '%s' of log endpoint '%s' cannot be blank", key, name)
+	}
+	return value, nil
+}
+
+// Create calls the relevant API to create the service resource(s).
+func (l *Loggers) Create() error {
+	if l.Spinner == nil {
+		return errors.RemediationError{
+			Inner:       fmt.Errorf("internal logic error: no text.Progress configured for setup.Loggers"),
+			Remediation: errors.BugRemediation,
 		}
-		text.Break(l.Stdout)
 	}
 
-	text.Description(
-		l.Stdout,
-		"Refer to the help documentation for each provider (if no provider shown, then select your own)",
-		"fastly logging <provider> create --help",
-	)
+	for _, logger := range l.required {
+		err := l.Spinner.Start()
+		if err != nil {
+			return err
+		}
+		msg := fmt.Sprintf("Creating %s log endpoint '%s'", logger.Provider, logger.Name)
+		l.Spinner.Message(msg + "...")
+
+		input, err := logger.input(l.ServiceID, l.ServiceVersion)
+		if err == nil {
+			err = logEndpointProviders[logger.Provider].create(l.APIClient, input)
+		}
+		if err != nil {
+			l.Spinner.StopFailMessage(msg)
+			err := l.Spinner.StopFail()
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("error creating log endpoint: %w", err)
+		}
+
+		l.Spinner.StopMessage(msg)
+		err = l.Spinner.Stop()
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -50,3 +196,77 @@ func (l *Loggers) Configure() error {
 func (l *Loggers) Predefined() bool {
 	return len(l.Setup) > 0
 }
+
+// Missing indicates if there are missing resources that need to be created.
+func (l *Loggers) Missing() bool {
+	return l.missing
+}
+
+// Validate checks if the service has the required resources.
+//
+// NOTE: The [setup.log_endpoints] configuration is reduced to the log
+// endpoints missing from the service. A log endpoint without a supported
+// provider can't be checked, and so it's excluded.
+func (l *Loggers) Validate() error {
+	available := make(map[string]map[string]bool)
+	missing := make(map[string]*manifest.SetupLogger)
+
+	for name, settings := range l.Setup {
+		provider := strings.ToLower(settings.Provider)
+		p, ok := logEndpointProviders[provider]
+		if !ok {
+			continue
+		}
+		if _, ok := available[provider]; !ok {
+			names, err := p.list(l.APIClient, l.ServiceID, l.ServiceVersion)
+			if err != nil {
+				return fmt.Errorf("error fetching service %s log endpoints: %w", provider, err)
+			}
+			available[provider] = make(map[string]bool, len(names))
+			for _, n := range names {
+				available[provider][n] = true
+			}
+		}
+		if !available[provider][name] {
+			missing[name] = settings
+		}
+	}
+
+	l.Setup = missing
+	l.missing = len(missing) > 0
+	return nil
+}
+
+// input decodes the parameters into the API input for the provider.
+//
+// NOTE: The parameters are the API fields for the provider, as they're encoded
+// in the API request (e.g. bucket_name), so an unknown parameter is an error.
+func (lg Logger) input(serviceID string, serviceVersion int) (any, error) {
+	input := logEndpointProviders[lg.Provider].input()
+
+	params := make(map[string]any, len(lg.Parameters)+1)
+	for k, v := range lg.Parameters {
+		params[k] = v
+	}
+	params["name"] = lg.Name
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused: true,
+		Result:      input,
+		TagName:     "url",
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(params); err != nil {
+		return nil, errors.RemediationError{
+			Inner:       fmt.Errorf("invalid parameters for log endpoint '%s': %w", lg.Name, err),
+			Remediation: fmt.Sprintf("Refer to the API documentation for the %s provider (see `fastly logging %s create --help`).", lg.Provider, lg.Provider),
+		}
+	}
+
+	v := reflect.ValueOf(input).Elem()
+	v.FieldByName("ServiceID").SetString(serviceID)
+	v.FieldByName("ServiceVersion").SetInt(int64(serviceVersion))
+	return input, nil
+}
@@ -0,0 +1,61 @@
+package setup
+
+import (
+	"fmt"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// linkedResources returns the IDs of the resources (e.g. kv stores) linked to
+// the service version.
+func linkedResources(client api.Interface, serviceID string, serviceVersion int) (map[string]bool, error) {
+	links, err := client.ListResources(&fastly.ListResourcesInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error fetching service resource links: %w", err)
+	}
+	ids := make(map[string]bool, len(links))
+	for _, link := range links {
+		ids[link.ResourceID] = true
+	}
+	return ids, nil
+}
+
+// createResourceLink links a resource (e.g. a kv store) to the service
+// version, otherwise the service will not have access to the resource.
+func createResourceLink(
+	client api.Interface,
+	spinner text.Spinner,
+	serviceID string,
+	serviceVersion int,
+	kind, name, id string,
+) error {
+	err := spinner.Start()
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("Creating resource link between service and %s '%s'", kind, name)
+	spinner.Message(msg + "...")
+
+	_, err = client.CreateResource(&fastly.CreateResourceInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion,
+		Name:           fastly.String(name),
+		ResourceID:     fastly.String(id),
+	})
+	if err != nil {
+		spinner.StopFailMessage(msg)
+		err := spinner.StopFail()
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("error creating resource link between the service '%s' and the %s '%s': %w", serviceID, kind, name, err)
+	}
+
+	spinner.StopMessage(msg)
+	return spinner.Stop()
+}
@@ -29,12 +29,17 @@ type SecretStores struct {
 	Stdout         io.Writer
 
 	// Private
+	existing map[string]string
+	missing  bool
 	required []SecretStore
 }
 
 // SecretStore represents the configuration parameters for creating a
 // secret store via the API client.
 type SecretStore struct {
+	// ID is set when the store already exists, in which case it's only
+	// linked to the service.
+	ID      string
 	Name    string
 	Entries []SecretStoreEntry
 }
@@ -55,6 +60,11 @@ func (s *SecretStores) Predefined() bool {
 // Configure prompts the user for specific values related to the service resource.
 func (s *SecretStores) Configure() error {
 	for name, settings := range s.Setup {
+		if id, ok := s.existing[name]; ok {
+			s.required = append(s.required, SecretStore{Name: name, ID: id})
+			continue
+		}
+
 		if !s.AcceptDefaults && !s.NonInteractive {
 			text.Break(s.Stdout)
 			text.Output(s.Stdout, "Configuring secret store '%s'", name)
@@ -115,6 +125,14 @@ func (s *SecretStores) Create() error {
 	}
 
 	for _, secretStore := range s.required {
+		if secretStore.ID != "" {
+			err := createResourceLink(s.APIClient, s.Spinner, s.ServiceID, s.ServiceVersion, "secret store", secretStore.Name, secretStore.ID)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		if err := s.Spinner.Start(); err != nil {
 			return err
 		}
@@ -162,33 +180,59 @@ func (s *SecretStores) Create() error {
 			}
 		}
 
-		if err = s.Spinner.Start(); err != nil {
+		err = createResourceLink(s.APIClient, s.Spinner, s.ServiceID, s.ServiceVersion, "secret store", secretStore.Name, store.ID)
+		if err != nil {
 			return err
 		}
-		msg = fmt.Sprintf("Creating resource link between service and secret store '%s'...", store.Name)
-		s.Spinner.Message(msg)
+	}
 
-		// We need to link the secret store to the C@E Service, otherwise the service
-		// will not have access to the store.
-		_, err = s.APIClient.CreateResource(&fastly.CreateResourceInput{
-			ServiceID:      s.ServiceID,
-			ServiceVersion: s.ServiceVersion,
-			Name:           fastly.String(store.Name),
-			ResourceID:     fastly.String(store.ID),
-		})
+	return nil
+}
+
+// Missing indicates if there are missing resources that need to be created.
+func (s *SecretStores) Missing() bool {
+	return s.missing
+}
+
+// Validate checks if the service has the required resources.
+//
+// NOTE: The [setup.secret_stores] configuration is reduced to the secret stores
+// not linked to the service. A secret store that already exists is only linked,
+// its contents are left unchanged.
+func (s *SecretStores) Validate() error {
+	linked, err := linkedResources(s.APIClient, s.ServiceID, s.ServiceVersion)
+	if err != nil {
+		return err
+	}
+
+	stores := make(map[string]string)
+	var cursor string
+	for {
+		page, err := s.APIClient.ListSecretStores(&fastly.ListSecretStoresInput{Cursor: cursor})
 		if err != nil {
-			s.Spinner.StopFailMessage(msg)
-			if serr := s.Spinner.StopFail(); serr != nil {
-				return serr
-			}
-			return fmt.Errorf("error creating resource link between the service %q and the secret store %q: %w", s.ServiceID, store.Name, err)
+			return fmt.Errorf("error fetching secret stores: %w", err)
 		}
-
-		s.Spinner.StopMessage(msg)
-		if err = s.Spinner.Stop(); err != nil {
-			return err
+		for _, store := range page.Data {
+			stores[store.Name] = store.ID
+		}
+		if cursor = page.Meta.NextCursor; cursor == "" {
+			break
 		}
 	}
 
+	s.existing = make(map[string]string)
+	missing := make(map[string]*manifest.SetupSecretStore)
+	for name, settings := range s.Setup {
+		id, ok := stores[name]
+		if ok && linked[id] {
+			continue
+		}
+		if ok {
+			s.existing[name] = id
+		}
+		missing[name] = settings
+	}
+	s.Setup = missing
+	s.missing = len(missing) > 0
 	return nil
 }
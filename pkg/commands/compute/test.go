@@ -0,0 +1,294 @@
+package compute
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	fstexec "github.com/fastly/cli/pkg/exec"
+	"github.com/fastly/cli/pkg/github"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/cli/pkg/threadsafe"
+)
+
+// TestViceroyEnvVar is the environment variable exposing the path of the
+// Viceroy binary to the test command.
+const TestViceroyEnvVar = "FASTLY_VICEROY"
+
+// TestManifestEnvVar is the environment variable exposing the path of the
+// fastly.toml manifest Viceroy is configured with to the test command.
+const TestManifestEnvVar = "FASTLY_MANIFEST"
+
+// TestURLEnvVar is the environment variable exposing the URL of the local
+// server Viceroy runs the project's Wasm binary on, for languages whose tests
+// make requests to the project rather than running inside Viceroy.
+const TestURLEnvVar = "FASTLY_URL"
+
+// testServerTimeout is how long to wait for Viceroy to start listening.
+const testServerTimeout = 30 * time.Second
+
+// TestCommand runs the project's unit tests through Viceroy.
+type TestCommand struct {
+	cmd.Base
+	manifest manifest.Data
+	av       github.AssetVersioner
+	build    *BuildCommand
+
+	args           []string
+	lang           string
+	timeout        int
+	viceroyBinPath string
+	viceroyCheck   bool
+}
+
+// NewTestCommand returns a usable command registered under the parent.
+func NewTestCommand(parent cmd.Registerer, g *global.Data, build *BuildCommand, av github.AssetVersioner, m manifest.Data) *TestCommand {
+	var c TestCommand
+	c.av = av
+	c.build = build
+	c.Globals = g
+	c.manifest = m
+	c.CmdClause = parent.Command("test", "Run the unit tests of a Compute@Edge project through Viceroy")
+	c.CmdClause.Flag("env", "The environment configuration to use (e.g. stage)").StringVar(&c.Globals.Flags.Env)
+	c.CmdClause.Flag("language", "Language type").StringVar(&c.lang)
+	c.CmdClause.Flag("timeout", "Timeout, in seconds, for the test execution").IntVar(&c.timeout)
+	c.CmdClause.Flag("viceroy-check", "Force the CLI to check for a newer version of the Viceroy binary").BoolVar(&c.viceroyCheck)
+	c.CmdClause.Flag("viceroy-path", "The path to a user installed version of the Viceroy binary").StringVar(&c.viceroyBinPath)
+	c.CmdClause.Arg("args", "Additional arguments passed to the test command (e.g. `fastly compute test -- --nocapture`)").StringsVar(&c.args)
+	return &c
+}
+
+// Exec implements the command interface.
+func (c *TestCommand) Exec(in io.Reader, out io.Writer) error {
+	if runtime.GOARCH == "386" {
+		return fsterr.RemediationError{
+			Inner:       errors.New("this command doesn't support the '386' architecture"),
+			Remediation: "Although the Fastly CLI supports '386', the `compute test` command requires https://github.com/fastly/Viceroy which does not.",
+		}
+	}
+
+	err := c.Globals.Manifest.File.ReadError()
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = fsterr.ErrReadingManifest
+		}
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	lang := c.lang
+	if lang == "" {
+		lang = c.Globals.Manifest.File.Language
+	}
+	lang = strings.ToLower(strings.TrimSpace(lang))
+
+	spinner, err := text.NewSpinner(out)
+	if err != nil {
+		return err
+	}
+
+	bin, err := GetViceroy(spinner, out, c.av, c.Globals, c.viceroyBinPath, c.viceroyCheck)
+	if err != nil {
+		return err
+	}
+
+	manifestPath, err := viceroyManifestPath(c.Globals.Flags.Env)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	command, args, env, err := testCommand(lang, c.Globals.Manifest.File.Scripts.Test, bin, manifestPath, c.args)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Language": lang,
+		})
+		return err
+	}
+	env = append(env, TestViceroyEnvVar+"="+bin, TestManifestEnvVar+"="+manifestPath)
+
+	script := c.Globals.Manifest.File.Scripts.Test
+	if script == "" && servesForTests(lang) {
+		if c.lang != "" {
+			c.build.Flags.Lang = c.lang
+		}
+		if err := c.build.Exec(in, out); err != nil {
+			return err
+		}
+		text.Break(out)
+
+		url, stop, err := startTestServer(bin, manifestPath)
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"Manifest": manifestPath,
+			})
+			return err
+		}
+		defer stop()
+		if c.Globals.Verbose() {
+			text.Info(out, "Viceroy is serving the Wasm binary on %s", url)
+		}
+		env = append(env, TestURLEnvVar+"="+url)
+	}
+
+	if c.Globals.Verbose() {
+		text.Description(out, "Test command", strings.Join(append([]string{command}, args...), " "))
+	}
+
+	s := &fstexec.Streaming{
+		Args:        args,
+		Command:     command,
+		Env:         env,
+		ForceOutput: true,
+		Output:      out,
+		Timeout:     time.Duration(c.timeout) * time.Second,
+		Verbose:     c.Globals.Verbose(),
+	}
+	if err := s.Exec(); err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Command": command,
+			"Args":    args,
+		})
+		err = fmt.Errorf("tests failed: %w", err)
+
+		// NOTE: The exit code of the test command is passed through, so a failure
+		// is distinguishable from, for example, a test command that's missing.
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+			return fsterr.ExitCodeError{Code: exitErr.ExitCode(), Err: fsterr.RemediationError{Inner: err}}
+		}
+		return err
+	}
+
+	text.Success(out, "Tests passed")
+	return nil
+}
+
+// testCommand returns the command (along with its arguments and environment
+// variables) that runs the unit tests for the language through Viceroy.
+//
+// A [scripts.test] defined in the fastly.toml manifest replaces the default
+// command for the language, and is executed by the shell.
+//
+// NOTE: Go projects are tested with the standard Go toolchain (which requires
+// Go 1.21+ for the wasip1 port) as TinyGo doesn't support a custom runner.
+// JavaScript and AssemblyScript can't compile their tests to a Wasm binary, so
+// their tests are run by npm against the project's Wasm binary served by
+// Viceroy (see servesForTests).
+func testCommand(language, script, viceroy, manifestPath string, extra []string) (command string, args, env []string, err error) {
+	if script != "" {
+		if len(extra) > 0 {
+			script = script + " " + strings.Join(extra, " ")
+		}
+		command, args = Shell{}.Build(script)
+		return command, args, nil, nil
+	}
+
+	// NOTE: The runner is passed the test binary (and its arguments) after the
+	// `--` separator.
+	runner := []string{viceroy, "run", "-C", manifestPath, "--"}
+
+	switch language {
+	case "rust":
+		quoted := make([]string, len(runner))
+		for i, s := range runner {
+			quoted[i] = strconv.Quote(s)
+		}
+		return "cargo", append([]string{
+			"test",
+			"--target", "wasm32-wasi",
+			"--config", fmt.Sprintf("target.wasm32-wasi.runner=[%s]", strings.Join(quoted, ", ")),
+		}, extra...), nil, nil
+	case "go":
+		// NOTE: The -exec flag supports quoting but not escaping.
+		quoted := make([]string, len(runner))
+		for i, s := range runner {
+			quoted[i] = `"` + s + `"`
+		}
+		args = append([]string{"test", "-exec", strings.Join(quoted, " ")}, extra...)
+		if len(extra) == 0 {
+			args = append(args, "./...")
+		}
+		return "go", args, []string{"GOOS=wasip1", "GOARCH=wasm"}, nil
+	case "javascript", "assemblyscript":
+		return "npm", append([]string{"test", "--"}, extra...), nil, nil
+	case "":
+		return "", nil, nil, fmt.Errorf("language cannot be empty, please provide a language")
+	}
+
+	return "", nil, nil, fsterr.RemediationError{
+		Inner:       fmt.Errorf("no default test command for language '%s'", language),
+		Remediation: fmt.Sprintf("Add a [scripts.test] to the fastly.toml manifest. The path to the Viceroy binary is available to it as $%s.", TestViceroyEnvVar),
+	}
+}
+
+// servesForTests reports whether the default test command for the language
+// runs against the project's Wasm binary served by Viceroy.
+func servesForTests(language string) bool {
+	return language == "javascript" || language == "assemblyscript"
+}
+
+// startTestServer runs the project's Wasm binary with Viceroy on a free local
+// port, and returns the URL once Viceroy is listening along with a function to
+// stop it.
+func startTestServer(viceroy, manifestPath string) (url string, stop func(), err error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, fmt.Errorf("error finding a free port for Viceroy: %w", err)
+	}
+	addr := l.Addr().String()
+	if err := l.Close(); err != nil {
+		return "", nil, err
+	}
+
+	var output threadsafe.Buffer
+	// gosec flagged this:
+	// G204 (CWE-78): Subprocess launched with variable
+	// Disabling as the variables come from trusted sources.
+	// #nosec
+	// nosemgrep
+	cmd := exec.Command(viceroy, "-C", manifestPath, "--addr", addr, "bin/main.wasm")
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Start(); err != nil {
+		return "", nil, fmt.Errorf("error starting Viceroy: %w", err)
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+	stop = func() {
+		_ = cmd.Process.Kill()
+		<-exited
+	}
+
+	deadline := time.Now().Add(testServerTimeout)
+	for {
+		select {
+		case err := <-exited:
+			return "", nil, fmt.Errorf("viceroy exited before listening on %s: %v\n%s", addr, err, output.String())
+		default:
+		}
+		if conn, err := net.DialTimeout("tcp", addr, 100*time.Millisecond); err == nil {
+			_ = conn.Close()
+			return "http://" + addr, stop, nil
+		}
+		if time.Now().After(deadline) {
+			stop()
+			return "", nil, fmt.Errorf("timed out waiting for Viceroy to listen on %s", addr)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
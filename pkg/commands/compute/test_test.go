@@ -0,0 +1,125 @@
+package compute_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/testutil"
+)
+
+func TestTest(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test scripts require a POSIX shell")
+	}
+
+	args := testutil.Args
+	for _, testcase := range []struct {
+		name         string
+		args         []string
+		manifest     string
+		wantError    string
+		wantExitCode int
+		wantOutput   []string
+	}{
+		{
+			name: "test script",
+			args: args("compute test --viceroy-path viceroy"),
+			manifest: `name = "test"
+language = "rust"
+
+[scripts]
+test = "echo runner=$FASTLY_VICEROY"`,
+			wantOutput: []string{
+				"runner=/",
+				"Tests passed",
+			},
+		},
+		{
+			name: "test script with additional arguments",
+			args: args("compute test --viceroy-path viceroy -- --nocapture"),
+			manifest: `name = "test"
+language = "rust"
+
+[scripts]
+test = "echo args:"`,
+			wantOutput: []string{
+				"args: --nocapture",
+			},
+		},
+		{
+			name: "failing tests",
+			args: args("compute test --viceroy-path viceroy"),
+			manifest: `name = "test"
+language = "rust"
+
+[scripts]
+test = "exit 3"`,
+			wantError:    "exit status 3",
+			wantExitCode: 3,
+		},
+		{
+			name: "language without a default test command",
+			args: args("compute test --viceroy-path viceroy"),
+			manifest: `name = "test"
+language = "other"`,
+			wantError: "no default test command for language 'other'",
+		},
+		{
+			name:      "missing manifest",
+			args:      args("compute test --viceroy-path viceroy"),
+			wantError: "error reading package manifest",
+		},
+	} {
+		t.Run(testcase.name, func(t *testing.T) {
+			// We're going to chdir to a test environment,
+			// so save the PWD to return to, afterwards.
+			pwd, err := os.Getwd()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var write []testutil.FileIO
+			if testcase.manifest != "" {
+				write = append(write, testutil.FileIO{Src: testcase.manifest, Dst: manifest.Filename})
+			}
+
+			// Create test environment
+			rootdir := testutil.NewEnv(testutil.EnvOpts{
+				T:     t,
+				Write: write,
+			})
+			defer os.RemoveAll(rootdir)
+
+			// Before running the test, chdir into the test environment.
+			// When we're done, chdir back to our original location.
+			if err := os.Chdir(rootdir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(pwd)
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.args, &stdout)
+			err = app.Run(opts)
+
+			t.Log(stdout.String())
+
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			if testcase.wantExitCode != 0 {
+				var codeErr fsterr.ExitCodeError
+				if !errors.As(err, &codeErr) {
+					t.Fatalf("want an exit code error, have %#v", err)
+				}
+				testutil.AssertEqual(t, testcase.wantExitCode, codeErr.Code)
+			}
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+		})
+	}
+}
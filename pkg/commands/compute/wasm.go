@@ -0,0 +1,286 @@
+package compute
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// wasmMagic is the preamble of every Wasm binary.
+var wasmMagic = []byte{0x00, 0x61, 0x73, 0x6d}
+
+// wasmSectionNames are the names of the known (non-custom) Wasm sections,
+// indexed by section ID.
+var wasmSectionNames = map[byte]string{
+	1:  "type",
+	2:  "import",
+	3:  "function",
+	4:  "table",
+	5:  "memory",
+	6:  "global",
+	7:  "export",
+	8:  "start",
+	9:  "element",
+	10: "code",
+	11: "data",
+	12: "data count",
+	13: "tag",
+}
+
+// wasmExternalKinds are the names of the import/export kinds.
+var wasmExternalKinds = map[byte]string{
+	0: "func",
+	1: "table",
+	2: "memory",
+	3: "global",
+	4: "tag",
+}
+
+// WasmModule describes the contents of a Wasm core module binary.
+type WasmModule struct {
+	Exports  []WasmExternal
+	Imports  []WasmExternal
+	Sections []WasmSection
+}
+
+// WasmSection describes a section of a Wasm binary.
+type WasmSection struct {
+	// Custom indicates if the section is a custom section (e.g. 'name').
+	Custom bool
+	// Name is the name of the section, or the name of a custom section.
+	Name string
+	// Size is the size of the section content in bytes.
+	Size int
+}
+
+// WasmExternal describes an import or export of a Wasm binary.
+type WasmExternal struct {
+	// Kind is the kind of the import/export (e.g. 'func').
+	Kind string
+	// Module is the name of the module an import comes from.
+	Module string
+	// Name is the name of the import/export.
+	Name string
+}
+
+// ParseWasmFile parses the Wasm binary at path.
+func ParseWasmFile(path string) (*WasmModule, error) {
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable
+	// Disabling as we trust the source of the filepath variable.
+	/* #nosec */
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWasm(data)
+}
+
+// ParseWasm parses a Wasm core module binary.
+//
+// NOTE: Only the sections needed to describe the binary are decoded (i.e. the
+// import, export and custom section names). Everything else is skipped.
+func ParseWasm(data []byte) (*WasmModule, error) {
+	if len(data) < 8 || !bytes.Equal(data[:4], wasmMagic) {
+		return nil, errors.New("not a Wasm binary")
+	}
+	if version := binary.LittleEndian.Uint32(data[4:8]); version != 1 {
+		return nil, fmt.Errorf("unsupported Wasm binary version: %#x (only core modules are supported)", version)
+	}
+
+	var m WasmModule
+	r := bytes.NewReader(data[8:])
+	for r.Len() > 0 {
+		id, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		size, err := readWasmU32(r)
+		if err != nil {
+			return nil, fmt.Errorf("error reading section size: %w", err)
+		}
+		if int(size) > r.Len() {
+			return nil, fmt.Errorf("section %d exceeds the binary size", id)
+		}
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return nil, err
+		}
+
+		s := WasmSection{Name: wasmSectionNames[id], Size: int(size)}
+		pr := bytes.NewReader(payload)
+		switch id {
+		case 0:
+			s.Custom = true
+			if s.Name, err = readWasmName(pr); err != nil {
+				return nil, fmt.Errorf("error reading custom section name: %w", err)
+			}
+		case 2:
+			if m.Imports, err = readWasmImports(pr); err != nil {
+				return nil, fmt.Errorf("error reading import section: %w", err)
+			}
+		case 7:
+			if m.Exports, err = readWasmExports(pr); err != nil {
+				return nil, fmt.Errorf("error reading export section: %w", err)
+			}
+		default:
+			if s.Name == "" {
+				return nil, fmt.Errorf("unknown section ID: %d", id)
+			}
+		}
+		m.Sections = append(m.Sections, s)
+	}
+	return &m, nil
+}
+
+// readWasmImports decodes the import section.
+//
+// NOTE: The counts and lengths within a section are checked against the bytes
+// remaining, rather than used to preallocate, as a corrupt binary could
+// otherwise exhaust the available memory.
+func readWasmImports(r *bytes.Reader) ([]WasmExternal, error) {
+	count, err := readWasmCount(r)
+	if err != nil {
+		return nil, err
+	}
+	var imports []WasmExternal
+	for i := uint32(0); i < count; i++ {
+		module, err := readWasmName(r)
+		if err != nil {
+			return nil, err
+		}
+		name, err := readWasmName(r)
+		if err != nil {
+			return nil, err
+		}
+		kind, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if err := skipWasmImportDesc(r, kind); err != nil {
+			return nil, err
+		}
+		imports = append(imports, WasmExternal{Kind: wasmExternalKinds[kind], Module: module, Name: name})
+	}
+	return imports, nil
+}
+
+// skipWasmImportDesc skips over the type description of an import.
+func skipWasmImportDesc(r *bytes.Reader, kind byte) error {
+	switch kind {
+	case 0: // func: type index
+		_, err := readWasmU32(r)
+		return err
+	case 1: // table: reference type, limits
+		if _, err := r.ReadByte(); err != nil {
+			return err
+		}
+		return skipWasmLimits(r)
+	case 2: // memory: limits
+		return skipWasmLimits(r)
+	case 3: // global: value type, mutability
+		if r.Len() < 2 {
+			return io.ErrUnexpectedEOF
+		}
+		_, err := r.Seek(2, io.SeekCurrent)
+		return err
+	case 4: // tag: attribute, type index
+		if _, err := r.ReadByte(); err != nil {
+			return err
+		}
+		_, err := readWasmU32(r)
+		return err
+	}
+	return fmt.Errorf("unknown import kind: %d", kind)
+}
+
+// skipWasmLimits skips over a limits description (a flag, a minimum and an
+// optional maximum).
+func skipWasmLimits(r *bytes.Reader) error {
+	flag, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if _, err := readWasmU64(r); err != nil {
+		return err
+	}
+	if flag&0x01 != 0 {
+		_, err = readWasmU64(r)
+	}
+	return err
+}
+
+// readWasmExports decodes the export section.
+func readWasmExports(r *bytes.Reader) ([]WasmExternal, error) {
+	count, err := readWasmCount(r)
+	if err != nil {
+		return nil, err
+	}
+	var exports []WasmExternal
+	for i := uint32(0); i < count; i++ {
+		name, err := readWasmName(r)
+		if err != nil {
+			return nil, err
+		}
+		kind, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := readWasmU32(r); err != nil {
+			return nil, err
+		}
+		exports = append(exports, WasmExternal{Kind: wasmExternalKinds[kind], Name: name})
+	}
+	return exports, nil
+}
+
+// readWasmName decodes a length prefixed UTF-8 name.
+func readWasmName(r *bytes.Reader) (string, error) {
+	n, err := readWasmCount(r)
+	if err != nil {
+		return "", err
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// readWasmCount decodes a count (or length) of the items that follow it,
+// rejecting a count that exceeds the bytes remaining as every item is at least
+// one byte.
+func readWasmCount(r *bytes.Reader) (uint32, error) {
+	n, err := readWasmU32(r)
+	if err != nil {
+		return 0, err
+	}
+	if int64(n) > int64(r.Len()) {
+		return 0, fmt.Errorf("count %d exceeds the %d bytes remaining", n, r.Len())
+	}
+	return n, nil
+}
+
+// readWasmU32 decodes an unsigned LEB128 encoded 32-bit integer.
+func readWasmU32(r io.ByteReader) (uint32, error) {
+	v, err := readWasmU64(r)
+	if err != nil {
+		return 0, err
+	}
+	if v > 1<<32-1 {
+		return 0, errors.New("integer overflows 32 bits")
+	}
+	return uint32(v), nil
+}
+
+// readWasmU64 decodes an unsigned LEB128 encoded 64-bit integer.
+func readWasmU64(r io.ByteReader) (uint64, error) {
+	v, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, fmt.Errorf("malformed integer: %w", err)
+	}
+	return v, nil
+}
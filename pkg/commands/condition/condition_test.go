@@ -0,0 +1,375 @@
+package condition_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+func TestConditionCreate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("condition create --service-id 123 --name always_false"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name:      "validate invalid --type flag",
+			Args:      args("condition create --service-id 123 --version 1 --type FOO"),
+			WantError: "error parsing arguments: enum value must be one of REQUEST,CACHE,RESPONSE,PREFETCH, got 'FOO'",
+		},
+		{
+			Name: "validate CreateCondition API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateConditionFn: func(i *fastly.CreateConditionInput) (*fastly.Condition, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("condition create --service-id 123 --version 1 --name always_false --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate CreateCondition API success",
+			API: mock.API{
+				ListVersionsFn:    testutil.ListVersions,
+				CloneVersionFn:    testutil.CloneVersionResult(4),
+				CreateConditionFn: createConditionOK,
+			},
+			Args:       args("condition create --service-id 123 --version 1 --name always_false --statement false --type REQUEST --priority 10 --autoclone"),
+			WantOutput: "Created condition always_false (service 123 version 4)",
+		},
+		{
+			Name: "validate CreateCondition API success with --comment",
+			API: mock.API{
+				ListVersionsFn:    testutil.ListVersions,
+				CloneVersionFn:    testutil.CloneVersionResult(4),
+				CreateConditionFn: createConditionOK,
+				UpdateConditionFn: func(i *fastly.UpdateConditionInput) (*fastly.Condition, error) {
+					if i.Name != "always_false" || i.ServiceVersion != 4 || i.Comment == nil || *i.Comment != "never_matches" {
+						return nil, testutil.Err
+					}
+					return &fastly.Condition{
+						Comment:        *i.Comment,
+						Name:           i.Name,
+						ServiceID:      i.ServiceID,
+						ServiceVersion: i.ServiceVersion,
+					}, nil
+				},
+			},
+			Args:       args("condition create --service-id 123 --version 1 --name always_false --statement false --type REQUEST --priority 10 --comment never_matches --autoclone"),
+			WantOutput: "Created condition always_false (service 123 version 4)",
+		},
+		{
+			Name: "validate UpdateCondition API error with --comment",
+			API: mock.API{
+				ListVersionsFn:    testutil.ListVersions,
+				CloneVersionFn:    testutil.CloneVersionResult(4),
+				CreateConditionFn: createConditionOK,
+				UpdateConditionFn: func(i *fastly.UpdateConditionInput) (*fastly.Condition, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("condition create --service-id 123 --version 1 --name always_false --statement false --type REQUEST --priority 10 --comment note --autoclone"),
+			WantError: "error setting the comment of the condition: test error",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestConditionList(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate ListConditions API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListConditionsFn: func(i *fastly.ListConditionsInput) ([]*fastly.Condition, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("condition list --service-id 123 --version 1"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate ListConditions API success",
+			API: mock.API{
+				ListVersionsFn:   testutil.ListVersions,
+				ListConditionsFn: listConditionsOK,
+			},
+			Args:       args("condition list --service-id 123 --version 1"),
+			WantOutput: listConditionsShortOutput,
+		},
+		{
+			Name: "validate --verbose flag",
+			API: mock.API{
+				ListVersionsFn:   testutil.ListVersions,
+				ListConditionsFn: listConditionsOK,
+			},
+			Args:       args("condition list --service-id 123 --version 1 --verbose"),
+			WantOutput: listConditionsVerboseOutput,
+		},
+		{
+			Name: "validate --json flag",
+			API: mock.API{
+				ListVersionsFn:   testutil.ListVersions,
+				ListConditionsFn: listConditionsOK,
+			},
+			Args:       args("condition list --service-id 123 --version 1 --json"),
+			WantOutput: `"Statement": "req.url ~ \"^/admin\"",`,
+		},
+		{
+			Name:      "validate --verbose and --json flags are mutually exclusive",
+			Args:      args("condition list --service-id 123 --version 1 --json --verbose"),
+			WantError: "invalid flag combination, --verbose and --json",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestConditionDescribe(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("condition describe --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate GetCondition API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetConditionFn: func(i *fastly.GetConditionInput) (*fastly.Condition, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("condition describe --service-id 123 --version 1 --name always_false"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate GetCondition API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetConditionFn: getConditionOK,
+			},
+			Args:       args("condition describe --service-id 123 --version 1 --name always_false"),
+			WantOutput: describeConditionOutput,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestConditionUpdate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("condition update --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate UpdateCondition API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				UpdateConditionFn: func(i *fastly.UpdateConditionInput) (*fastly.Condition, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("condition update --service-id 123 --version 1 --name always_false --statement true --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate UpdateCondition API success",
+			API: mock.API{
+				ListVersionsFn:    testutil.ListVersions,
+				CloneVersionFn:    testutil.CloneVersionResult(4),
+				UpdateConditionFn: updateConditionOK,
+			},
+			Args:       args("condition update --service-id 123 --version 1 --name always_false --statement true --autoclone"),
+			WantOutput: "Updated condition always_false (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestConditionDelete(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("condition delete --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate DeleteCondition API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				DeleteConditionFn: func(i *fastly.DeleteConditionInput) error {
+					return testutil.Err
+				},
+			},
+			Args:      args("condition delete --service-id 123 --version 1 --name always_false --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate DeleteCondition API success",
+			API: mock.API{
+				ListVersionsFn:    testutil.ListVersions,
+				CloneVersionFn:    testutil.CloneVersionResult(4),
+				DeleteConditionFn: func(i *fastly.DeleteConditionInput) error { return nil },
+			},
+			Args:       args("condition delete --service-id 123 --version 1 --name always_false --autoclone"),
+			WantOutput: "Deleted condition always_false (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func createConditionOK(i *fastly.CreateConditionInput) (*fastly.Condition, error) {
+	return &fastly.Condition{
+		Name:           *i.Name,
+		Priority:       *i.Priority,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Statement:      *i.Statement,
+		Type:           *i.Type,
+	}, nil
+}
+
+func listConditionsOK(i *fastly.ListConditionsInput) ([]*fastly.Condition, error) {
+	return []*fastly.Condition{
+		{
+			Name:           "always_false",
+			Priority:       10,
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+			Statement:      "false",
+			Type:           "REQUEST",
+		},
+		{
+			Comment:        "admin only",
+			Name:           "is_admin",
+			Priority:       100,
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+			Statement:      `req.url ~ "^/admin"`,
+			Type:           "CACHE",
+		},
+	}, nil
+}
+
+var listConditionsShortOutput = strings.TrimSpace(`
+SERVICE  VERSION  NAME          TYPE     PRIORITY  STATEMENT
+123      1        always_false  REQUEST  10        false
+123      1        is_admin      CACHE    100       req.url ~ "^/admin"
+`) + "\n"
+
+var listConditionsVerboseOutput = strings.TrimSpace(`
+Fastly API token not provided
+Fastly API endpoint: https://api.fastly.com
+
+Service ID (via --service-id): 123
+
+Version: 1
+	Condition 1/2
+		Name: always_false
+		Comment: 
+		Statement: false
+		Type: REQUEST
+		Priority: 10
+	Condition 2/2
+		Name: is_admin
+		Comment: admin only
+		Statement: req.url ~ "^/admin"
+		Type: CACHE
+		Priority: 100
+`) + "\n\n"
+
+func getConditionOK(i *fastly.GetConditionInput) (*fastly.Condition, error) {
+	return &fastly.Condition{
+		Name:           i.Name,
+		Priority:       10,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Statement:      "false",
+		Type:           "REQUEST",
+	}, nil
+}
+
+var describeConditionOutput = `
+Service ID: 123
+Version: 1
+Name: always_false
+Comment: 
+Statement: false
+Type: REQUEST
+Priority: 10
+`
+
+func updateConditionOK(i *fastly.UpdateConditionInput) (*fastly.Condition, error) {
+	return &fastly.Condition{
+		Name:           i.Name,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Statement:      *i.Statement,
+	}, nil
+}
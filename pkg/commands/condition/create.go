@@ -0,0 +1,144 @@
+package condition
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// Types is a list of supported condition types.
+var Types = []string{"REQUEST", "CACHE", "RESPONSE", "PREFETCH"}
+
+// CreateCommand calls the Fastly API to create conditions.
+type CreateCommand struct {
+	cmd.Base
+	manifest manifest.Data
+
+	// required
+	serviceVersion cmd.OptionalServiceVersion
+
+	// optional
+	autoClone     cmd.OptionalAutoClone
+	comment       cmd.OptionalString
+	conditionType cmd.OptionalString
+	name          cmd.OptionalString
+	priority      cmd.OptionalInt
+	serviceName   cmd.OptionalServiceNameID
+	statement     cmd.OptionalString
+}
+
+// NewCreateCommand returns a usable command registered under the parent.
+func NewCreateCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *CreateCommand {
+	c := CreateCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+	c.CmdClause = parent.Command("create", "Create a condition on a Fastly service version").Alias("add")
+
+	// required
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// optional
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("comment", "A descriptive note").Action(c.comment.Set).StringVar(&c.comment.Value)
+	c.CmdClause.Flag("name", "Condition name").Short('n').Action(c.name.Set).StringVar(&c.name.Value)
+	c.CmdClause.Flag("priority", "Priority determines execution order. Lower numbers execute first").Action(c.priority.Set).IntVar(&c.priority.Value)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("statement", "A conditional expression in VCL used to determine if the condition is met").Action(c.statement.Set).StringVar(&c.statement.Value)
+	c.CmdClause.Flag("type", "Type of the condition").Action(c.conditionType.Set).HintOptions(Types...).EnumVar(&c.conditionType.Value, Types...)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flags.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+	input := fastly.CreateConditionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	}
+
+	if c.name.WasSet {
+		input.Name = &c.name.Value
+	}
+	if c.statement.WasSet {
+		input.Statement = &c.statement.Value
+	}
+	if c.conditionType.WasSet {
+		input.Type = &c.conditionType.Value
+	}
+	if c.priority.WasSet {
+		input.Priority = &c.priority.Value
+	}
+
+	cond, err := c.Globals.APIClient.CreateCondition(&input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	// NOTE: The API client's CreateConditionInput has no Comment field, so the
+	// comment is set by updating the newly created condition.
+	if c.comment.WasSet {
+		cond, err = c.Globals.APIClient.UpdateCondition(&fastly.UpdateConditionInput{
+			Comment:        &c.comment.Value,
+			Name:           cond.Name,
+			ServiceID:      cond.ServiceID,
+			ServiceVersion: cond.ServiceVersion,
+		})
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"Service ID":      serviceID,
+				"Service Version": serviceVersion.Number,
+			})
+			return fmt.Errorf("error setting the comment of the condition: %w", err)
+		}
+	}
+
+	text.Success(out, "Created condition %s (service %s version %d)", cond.Name, cond.ServiceID, cond.ServiceVersion)
+	return nil
+}
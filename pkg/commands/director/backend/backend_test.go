@@ -0,0 +1,108 @@
+package backend_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+func TestDirectorBackendAdd(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --director flag",
+			Args:      args("director backend add --service-id 123 --version 1 --backend origin"),
+			WantError: "error parsing arguments: required flag --director not provided",
+		},
+		{
+			Name: "validate CreateDirectorBackend API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateDirectorBackendFn: func(i *fastly.CreateDirectorBackendInput) (*fastly.DirectorBackend, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("director backend add --service-id 123 --version 1 --director example --backend origin --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate CreateDirectorBackend API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateDirectorBackendFn: func(i *fastly.CreateDirectorBackendInput) (*fastly.DirectorBackend, error) {
+					return &fastly.DirectorBackend{
+						Backend:        i.Backend,
+						Director:       i.Director,
+						ServiceID:      i.ServiceID,
+						ServiceVersion: i.ServiceVersion,
+					}, nil
+				},
+			},
+			Args:       args("director backend add --service-id 123 --version 1 --director example --backend origin --autoclone"),
+			WantOutput: "Added backend origin to director example (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestDirectorBackendRemove(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --backend flag",
+			Args:      args("director backend remove --service-id 123 --version 1 --director example"),
+			WantError: "error parsing arguments: required flag --backend not provided",
+		},
+		{
+			Name: "validate DeleteDirectorBackend API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				DeleteDirectorBackendFn: func(i *fastly.DeleteDirectorBackendInput) error {
+					return testutil.Err
+				},
+			},
+			Args:      args("director backend remove --service-id 123 --version 1 --director example --backend origin --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate DeleteDirectorBackend API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				DeleteDirectorBackendFn: func(i *fastly.DeleteDirectorBackendInput) error {
+					return nil
+				},
+			},
+			Args:       args("director backend remove --service-id 123 --version 1 --director example --backend origin --autoclone"),
+			WantOutput: "Removed backend origin from director example (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
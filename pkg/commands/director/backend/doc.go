@@ -0,0 +1,2 @@
+// Package backend contains commands to manipulate the backends of Fastly service directors.
+package backend
@@ -0,0 +1,140 @@
+package director
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// Types is a list of supported director types.
+var Types = []string{"random", "hash", "client"}
+
+// directorTypes maps a director type name to its API value.
+var directorTypes = map[string]fastly.DirectorType{
+	"random": fastly.DirectorTypeRandom,
+	"hash":   fastly.DirectorTypeHash,
+	"client": fastly.DirectorTypeClient,
+}
+
+// CreateCommand calls the Fastly API to create directors.
+type CreateCommand struct {
+	cmd.Base
+	manifest manifest.Data
+
+	// required
+	serviceVersion cmd.OptionalServiceVersion
+
+	// optional
+	autoClone    cmd.OptionalAutoClone
+	comment      cmd.OptionalString
+	directorType cmd.OptionalString
+	name         cmd.OptionalString
+	quorum       cmd.OptionalInt
+	retries      cmd.OptionalInt
+	serviceName  cmd.OptionalServiceNameID
+	shield       cmd.OptionalString
+}
+
+// NewCreateCommand returns a usable command registered under the parent.
+func NewCreateCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *CreateCommand {
+	c := CreateCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+	c.CmdClause = parent.Command("create", "Create a director on a Fastly service version").Alias("add")
+
+	// required
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// optional
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("comment", "A descriptive note").Action(c.comment.Set).StringVar(&c.comment.Value)
+	c.CmdClause.Flag("name", "Director name").Short('n').Action(c.name.Set).StringVar(&c.name.Value)
+	c.CmdClause.Flag("quorum", "The percentage of capacity that needs to be up for the director to be considered up").Action(c.quorum.Set).IntVar(&c.quorum.Value)
+	c.CmdClause.Flag("retries", "How many backends to search if a request fails").Action(c.retries.Set).IntVar(&c.retries.Value)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("shield", "The shield POP designated to reduce inbound load on the backends of this director").Action(c.shield.Set).StringVar(&c.shield.Value)
+	c.CmdClause.Flag("type", "How the director selects a backend").Action(c.directorType.Set).HintOptions(Types...).EnumVar(&c.directorType.Value, Types...)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flags.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+	input := fastly.CreateDirectorInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	}
+
+	if c.name.WasSet {
+		input.Name = &c.name.Value
+	}
+	if c.comment.WasSet {
+		input.Comment = &c.comment.Value
+	}
+	if c.directorType.WasSet {
+		input.Type = fastly.DirectorTypePtr(directorTypes[c.directorType.Value])
+	}
+	if c.quorum.WasSet {
+		input.Quorum = &c.quorum.Value
+	}
+	if c.retries.WasSet {
+		input.Retries = &c.retries.Value
+	}
+	if c.shield.WasSet {
+		input.Shield = &c.shield.Value
+	}
+
+	d, err := c.Globals.APIClient.CreateDirector(&input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Created director %s (service %s version %d)", d.Name, d.ServiceID, d.ServiceVersion)
+	return nil
+}
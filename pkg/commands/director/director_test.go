@@ -0,0 +1,342 @@
+package director_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+func TestDirectorCreate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("director create --service-id 123 --name example"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name:      "validate invalid --type flag",
+			Args:      args("director create --service-id 123 --version 1 --type fallback"),
+			WantError: "error parsing arguments: enum value must be one of random,hash,client, got 'fallback'",
+		},
+		{
+			Name: "validate CreateDirector API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateDirectorFn: func(i *fastly.CreateDirectorInput) (*fastly.Director, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("director create --service-id 123 --version 1 --name example --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate CreateDirector API success",
+			API: mock.API{
+				ListVersionsFn:   testutil.ListVersions,
+				CloneVersionFn:   testutil.CloneVersionResult(4),
+				CreateDirectorFn: createDirectorOK,
+			},
+			Args:       args("director create --service-id 123 --version 1 --name example --type hash --quorum 50 --autoclone"),
+			WantOutput: "Created director example (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestDirectorList(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate ListDirectors API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListDirectorsFn: func(i *fastly.ListDirectorsInput) ([]*fastly.Director, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("director list --service-id 123 --version 1"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate ListDirectors API success",
+			API: mock.API{
+				ListVersionsFn:  testutil.ListVersions,
+				ListDirectorsFn: listDirectorsOK,
+			},
+			Args:       args("director list --service-id 123 --version 1"),
+			WantOutput: listDirectorsShortOutput,
+		},
+		{
+			Name: "validate --verbose flag",
+			API: mock.API{
+				ListVersionsFn:  testutil.ListVersions,
+				ListDirectorsFn: listDirectorsOK,
+			},
+			Args:       args("director list --service-id 123 --version 1 --verbose"),
+			WantOutput: listDirectorsVerboseOutput,
+		},
+		{
+			Name: "validate --json flag",
+			API: mock.API{
+				ListVersionsFn:  testutil.ListVersions,
+				ListDirectorsFn: listDirectorsOK,
+			},
+			Args:       args("director list --service-id 123 --version 1 --json"),
+			WantOutput: `"Backends": [`,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestDirectorDescribe(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("director describe --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate GetDirector API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetDirectorFn: func(i *fastly.GetDirectorInput) (*fastly.Director, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("director describe --service-id 123 --version 1 --name example"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate GetDirector API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetDirectorFn:  getDirectorOK,
+			},
+			Args:       args("director describe --service-id 123 --version 1 --name example"),
+			WantOutput: describeDirectorOutput,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestDirectorUpdate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("director update --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate UpdateDirector API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				UpdateDirectorFn: func(i *fastly.UpdateDirectorInput) (*fastly.Director, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("director update --service-id 123 --version 1 --name example --new-name renamed --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate UpdateDirector API success",
+			API: mock.API{
+				ListVersionsFn:   testutil.ListVersions,
+				CloneVersionFn:   testutil.CloneVersionResult(4),
+				UpdateDirectorFn: updateDirectorOK,
+			},
+			Args:       args("director update --service-id 123 --version 1 --name example --new-name renamed --type client --autoclone"),
+			WantOutput: "Updated director renamed (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestDirectorDelete(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("director delete --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate DeleteDirector API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				DeleteDirectorFn: func(i *fastly.DeleteDirectorInput) error {
+					return testutil.Err
+				},
+			},
+			Args:      args("director delete --service-id 123 --version 1 --name example --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate DeleteDirector API success",
+			API: mock.API{
+				ListVersionsFn:   testutil.ListVersions,
+				CloneVersionFn:   testutil.CloneVersionResult(4),
+				DeleteDirectorFn: func(i *fastly.DeleteDirectorInput) error { return nil },
+			},
+			Args:       args("director delete --service-id 123 --version 1 --name example --autoclone"),
+			WantOutput: "Deleted director example (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func createDirectorOK(i *fastly.CreateDirectorInput) (*fastly.Director, error) {
+	if i.Type == nil || *i.Type != fastly.DirectorTypeHash {
+		return nil, testutil.Err
+	}
+	return &fastly.Director{
+		Name:           *i.Name,
+		Quorum:         *i.Quorum,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Type:           *i.Type,
+	}, nil
+}
+
+func listDirectorsOK(i *fastly.ListDirectorsInput) ([]*fastly.Director, error) {
+	return []*fastly.Director{
+		{
+			Backends:       []string{"origin-a", "origin-b"},
+			Name:           "example",
+			Quorum:         75,
+			Retries:        5,
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+			Type:           fastly.DirectorTypeRandom,
+		},
+		{
+			Name:           "sticky",
+			Quorum:         50,
+			Retries:        3,
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+			Type:           fastly.DirectorTypeClient,
+		},
+	}, nil
+}
+
+var listDirectorsShortOutput = strings.Join([]string{
+	"SERVICE  VERSION  NAME     TYPE    QUORUM  RETRIES  BACKENDS",
+	"123      1        example  random  75      5        origin-a, origin-b",
+	"123      1        sticky   client  50      3",
+}, "\n")
+
+var listDirectorsVerboseOutput = strings.Join([]string{
+	"Version: 1",
+	"\tDirector 1/2",
+	"\t\tName: example",
+	"\t\tComment: ",
+	"\t\tType: random",
+	"\t\tQuorum: 75",
+	"\t\tRetries: 5",
+	"\t\tCapacity: 0",
+	"\t\tShield: ",
+	"\t\tBackends:",
+	"\t\t\torigin-a",
+	"\t\t\torigin-b",
+	"\tDirector 2/2",
+	"\t\tName: sticky",
+}, "\n")
+
+func getDirectorOK(i *fastly.GetDirectorInput) (*fastly.Director, error) {
+	return &fastly.Director{
+		Backends:       []string{"origin-a"},
+		Name:           i.Name,
+		Quorum:         75,
+		Retries:        5,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Type:           fastly.DirectorTypeHash,
+	}, nil
+}
+
+var describeDirectorOutput = `
+Service ID: 123
+Version: 1
+Name: example
+Comment: 
+Type: hash
+Quorum: 75
+Retries: 5
+Capacity: 0
+Shield: 
+Backends:
+	origin-a
+`
+
+func updateDirectorOK(i *fastly.UpdateDirectorInput) (*fastly.Director, error) {
+	if i.Type != fastly.DirectorTypeClient {
+		return nil, testutil.Err
+	}
+	return &fastly.Director{
+		Name:           *i.NewName,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Type:           i.Type,
+	}, nil
+}
@@ -0,0 +1,122 @@
+package gzip
+
+import (
+	"io"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// CreateCommand calls the Fastly API to create gzip configurations.
+type CreateCommand struct {
+	cmd.Base
+	manifest manifest.Data
+
+	// required
+	serviceVersion cmd.OptionalServiceVersion
+
+	// optional
+	autoClone      cmd.OptionalAutoClone
+	cacheCondition cmd.OptionalString
+	contentTypes   cmd.OptionalStringSlice
+	extensions     cmd.OptionalStringSlice
+	name           cmd.OptionalString
+	serviceName    cmd.OptionalServiceNameID
+}
+
+// NewCreateCommand returns a usable command registered under the parent.
+func NewCreateCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *CreateCommand {
+	c := CreateCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+	c.CmdClause = parent.Command("create", "Create a gzip configuration on a Fastly service version").Alias("add")
+
+	// required
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// optional
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("cache-condition", "Name of the cache condition controlling when this gzip configuration applies").Action(c.cacheCondition.Set).StringVar(&c.cacheCondition.Value)
+	c.CmdClause.Flag("content-type", "Content type to compress (set flag once per content type)").Action(c.contentTypes.Set).StringsVar(&c.contentTypes.Value)
+	c.CmdClause.Flag("extension", "File extension to compress (set flag once per extension)").Action(c.extensions.Set).StringsVar(&c.extensions.Value)
+	c.CmdClause.Flag("name", "Gzip configuration name").Short('n').Action(c.name.Set).StringVar(&c.name.Value)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flags.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+	input := fastly.CreateGzipInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	}
+
+	if c.name.WasSet {
+		input.Name = &c.name.Value
+	}
+	// NOTE: The API expects content types and extensions as space-separated lists.
+	if c.contentTypes.WasSet {
+		input.ContentTypes = fastly.String(strings.Join(c.contentTypes.Value, " "))
+	}
+	if c.extensions.WasSet {
+		input.Extensions = fastly.String(strings.Join(c.extensions.Value, " "))
+	}
+	if c.cacheCondition.WasSet {
+		input.CacheCondition = &c.cacheCondition.Value
+	}
+
+	g, err := c.Globals.APIClient.CreateGzip(&input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Created gzip configuration %s (service %s version %d)", g.Name, g.ServiceID, g.ServiceVersion)
+	return nil
+}
@@ -0,0 +1,315 @@
+package gzip_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+func TestGzipCreate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("gzip create --service-id 123 --name example"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name: "validate CreateGzip API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateGzipFn: func(i *fastly.CreateGzipInput) (*fastly.Gzip, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("gzip create --service-id 123 --version 1 --name example --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate CreateGzip API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateGzipFn:   createGzipOK,
+			},
+			Args:       args("gzip create --service-id 123 --version 1 --name example --content-type text/html --content-type application/json --extension html --extension json --autoclone"),
+			WantOutput: "Created gzip configuration example (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestGzipList(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate ListGzips API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListGzipsFn: func(i *fastly.ListGzipsInput) ([]*fastly.Gzip, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("gzip list --service-id 123 --version 1"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate ListGzips API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListGzipsFn:    listGzipsOK,
+			},
+			Args:       args("gzip list --service-id 123 --version 1"),
+			WantOutput: listGzipsShortOutput,
+		},
+		{
+			Name: "validate --verbose flag",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListGzipsFn:    listGzipsOK,
+			},
+			Args:       args("gzip list --service-id 123 --version 1 --verbose"),
+			WantOutput: listGzipsVerboseOutput,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestGzipDescribe(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("gzip describe --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate GetGzip API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetGzipFn: func(i *fastly.GetGzipInput) (*fastly.Gzip, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("gzip describe --service-id 123 --version 1 --name example"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate GetGzip API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetGzipFn:      getGzipOK,
+			},
+			Args:       args("gzip describe --service-id 123 --version 1 --name example"),
+			WantOutput: describeGzipOutput,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestGzipUpdate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("gzip update --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate UpdateGzip API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				UpdateGzipFn: func(i *fastly.UpdateGzipInput) (*fastly.Gzip, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("gzip update --service-id 123 --version 1 --name example --new-name renamed --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate UpdateGzip API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				UpdateGzipFn:   updateGzipOK,
+			},
+			Args:       args("gzip update --service-id 123 --version 1 --name example --new-name renamed --extension css --autoclone"),
+			WantOutput: "Updated gzip configuration renamed (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestGzipDelete(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("gzip delete --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate DeleteGzip API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				DeleteGzipFn: func(i *fastly.DeleteGzipInput) error {
+					return testutil.Err
+				},
+			},
+			Args:      args("gzip delete --service-id 123 --version 1 --name example --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate DeleteGzip API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				DeleteGzipFn:   func(i *fastly.DeleteGzipInput) error { return nil },
+			},
+			Args:       args("gzip delete --service-id 123 --version 1 --name example --autoclone"),
+			WantOutput: "Deleted gzip configuration example (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func createGzipOK(i *fastly.CreateGzipInput) (*fastly.Gzip, error) {
+	if *i.ContentTypes != "text/html application/json" || *i.Extensions != "html json" {
+		return nil, testutil.Err
+	}
+	return &fastly.Gzip{
+		ContentTypes:   *i.ContentTypes,
+		Extensions:     *i.Extensions,
+		Name:           *i.Name,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}, nil
+}
+
+func listGzipsOK(i *fastly.ListGzipsInput) ([]*fastly.Gzip, error) {
+	return []*fastly.Gzip{
+		{
+			ContentTypes:   "text/html text/css",
+			Extensions:     "html css",
+			Name:           "text",
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+		},
+		{
+			CacheCondition: "is-json",
+			ContentTypes:   "application/json",
+			Name:           "json",
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+		},
+	}, nil
+}
+
+var listGzipsShortOutput = strings.Join([]string{
+	"SERVICE  VERSION  NAME  CONTENT TYPES       EXTENSIONS",
+	"123      1        text  text/html text/css  html css",
+	"123      1        json  application/json",
+}, "\n")
+
+var listGzipsVerboseOutput = strings.Join([]string{
+	"Version: 1",
+	"\tGzip configuration 1/2",
+	"\t\tName: text",
+	"\t\tContent types: text/html text/css",
+	"\t\tExtensions: html css",
+	"\t\tCache condition: ",
+	"\tGzip configuration 2/2",
+	"\t\tName: json",
+	"\t\tContent types: application/json",
+	"\t\tExtensions: ",
+	"\t\tCache condition: is-json",
+}, "\n")
+
+func getGzipOK(i *fastly.GetGzipInput) (*fastly.Gzip, error) {
+	return &fastly.Gzip{
+		ContentTypes:   "text/html",
+		Extensions:     "html",
+		Name:           i.Name,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}, nil
+}
+
+var describeGzipOutput = `
+Service ID: 123
+Version: 1
+Name: example
+Content types: text/html
+Extensions: html
+Cache condition: 
+`
+
+func updateGzipOK(i *fastly.UpdateGzipInput) (*fastly.Gzip, error) {
+	if i.ContentTypes != nil || i.Extensions == nil || *i.Extensions != "css" {
+		return nil, testutil.Err
+	}
+	return &fastly.Gzip{
+		Extensions:     *i.Extensions,
+		Name:           *i.NewName,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}, nil
+}
@@ -0,0 +1,177 @@
+package header
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// Actions is a list of supported header actions.
+var Actions = []string{
+	string(fastly.HeaderActionSet),
+	string(fastly.HeaderActionAppend),
+	string(fastly.HeaderActionDelete),
+	string(fastly.HeaderActionRegex),
+	string(fastly.HeaderActionRegexRepeat),
+}
+
+// Types is a list of supported header types.
+var Types = []string{
+	string(fastly.HeaderTypeRequest),
+	string(fastly.HeaderTypeFetch),
+	string(fastly.HeaderTypeCache),
+	string(fastly.HeaderTypeResponse),
+}
+
+// CreateCommand calls the Fastly API to create headers.
+type CreateCommand struct {
+	cmd.Base
+	manifest manifest.Data
+
+	// required
+	serviceVersion cmd.OptionalServiceVersion
+
+	// optional
+	action            cmd.OptionalString
+	autoClone         cmd.OptionalAutoClone
+	cacheCondition    cmd.OptionalString
+	destination       cmd.OptionalString
+	headerType        cmd.OptionalString
+	ignoreIfSet       cmd.OptionalBool
+	name              cmd.OptionalString
+	priority          cmd.OptionalInt
+	regex             cmd.OptionalString
+	requestCondition  cmd.OptionalString
+	responseCondition cmd.OptionalString
+	serviceName       cmd.OptionalServiceNameID
+	source            cmd.OptionalString
+	substitution      cmd.OptionalString
+}
+
+// NewCreateCommand returns a usable command registered under the parent.
+func NewCreateCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *CreateCommand {
+	c := CreateCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+	c.CmdClause = parent.Command("create", "Create a header on a Fastly service version").Alias("add")
+
+	// required
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// optional
+	c.CmdClause.Flag("action", "The action to perform on the header").Action(c.action.Set).HintOptions(Actions...).EnumVar(&c.action.Value, Actions...)
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("cache-condition", "Name of the cache condition controlling when this header applies").Action(c.cacheCondition.Set).StringVar(&c.cacheCondition.Value)
+	c.CmdClause.Flag("dst", "The header to set").Action(c.destination.Set).StringVar(&c.destination.Value)
+	c.CmdClause.Flag("ignore-if-set", "Don't add the header if it is already set (only applies to the 'set' action)").Action(c.ignoreIfSet.Set).BoolVar(&c.ignoreIfSet.Value)
+	c.CmdClause.Flag("name", "Header name").Short('n').Action(c.name.Set).StringVar(&c.name.Value)
+	c.CmdClause.Flag("priority", "Priority determines execution order. Lower numbers execute first").Action(c.priority.Set).IntVar(&c.priority.Value)
+	c.CmdClause.Flag("regex", "Regular expression to use (only applies to the 'regex' and 'regex_repeat' actions)").Action(c.regex.Set).StringVar(&c.regex.Value)
+	c.CmdClause.Flag("request-condition", "Condition, which if met, will select this header during a request").Action(c.requestCondition.Set).StringVar(&c.requestCondition.Value)
+	c.CmdClause.Flag("response-condition", "Name of a response condition controlling when this header applies").Action(c.responseCondition.Set).StringVar(&c.responseCondition.Value)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("src", "Variable to be used as a source for the header content (does not apply to the 'delete' action)").Action(c.source.Set).StringVar(&c.source.Value)
+	c.CmdClause.Flag("substitution", "Value to substitute in place of the regular expression (only applies to the 'regex' and 'regex_repeat' actions)").Action(c.substitution.Set).StringVar(&c.substitution.Value)
+	c.CmdClause.Flag("type", "The point in the request lifecycle at which the header is applied").Action(c.headerType.Set).HintOptions(Types...).EnumVar(&c.headerType.Value, Types...)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flags.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+	input := fastly.CreateHeaderInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	}
+
+	if c.name.WasSet {
+		input.Name = &c.name.Value
+	}
+	if c.action.WasSet {
+		input.Action = fastly.HeaderActionPtr(fastly.HeaderAction(c.action.Value))
+	}
+	if c.headerType.WasSet {
+		input.Type = fastly.HeaderTypePtr(fastly.HeaderType(c.headerType.Value))
+	}
+	if c.destination.WasSet {
+		input.Destination = &c.destination.Value
+	}
+	if c.source.WasSet {
+		input.Source = &c.source.Value
+	}
+	if c.regex.WasSet {
+		input.Regex = &c.regex.Value
+	}
+	if c.substitution.WasSet {
+		input.Substitution = &c.substitution.Value
+	}
+	if c.ignoreIfSet.WasSet {
+		input.IgnoreIfSet = fastly.CBool(c.ignoreIfSet.Value)
+	}
+	if c.priority.WasSet {
+		input.Priority = &c.priority.Value
+	}
+	if c.requestCondition.WasSet {
+		input.RequestCondition = &c.requestCondition.Value
+	}
+	if c.cacheCondition.WasSet {
+		input.CacheCondition = &c.cacheCondition.Value
+	}
+	if c.responseCondition.WasSet {
+		input.ResponseCondition = &c.responseCondition.Value
+	}
+
+	h, err := c.Globals.APIClient.CreateHeader(&input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Created header %s (service %s version %d)", h.Name, h.ServiceID, h.ServiceVersion)
+	return nil
+}
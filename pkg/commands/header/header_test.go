@@ -0,0 +1,351 @@
+package header_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+func TestHeaderCreate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("header create --service-id 123 --name example"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name:      "validate invalid --action flag",
+			Args:      args("header create --service-id 123 --version 1 --action replace"),
+			WantError: "error parsing arguments: enum value must be one of set,append,delete,regex,regex_repeat, got 'replace'",
+		},
+		{
+			Name: "validate CreateHeader API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateHeaderFn: func(i *fastly.CreateHeaderInput) (*fastly.Header, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("header create --service-id 123 --version 1 --name example --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate CreateHeader API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateHeaderFn: createHeaderOK,
+			},
+			Args:       args("header create --service-id 123 --version 1 --name example --action set --type response --dst http.X-Example --src req.http.Host --ignore-if-set --autoclone"),
+			WantOutput: "Created header example (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestHeaderList(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate ListHeaders API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListHeadersFn: func(i *fastly.ListHeadersInput) ([]*fastly.Header, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("header list --service-id 123 --version 1"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate ListHeaders API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListHeadersFn:  listHeadersOK,
+			},
+			Args:       args("header list --service-id 123 --version 1"),
+			WantOutput: listHeadersShortOutput,
+		},
+		{
+			Name: "validate --verbose flag",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListHeadersFn:  listHeadersOK,
+			},
+			Args:       args("header list --service-id 123 --version 1 --verbose"),
+			WantOutput: listHeadersVerboseOutput,
+		},
+		{
+			Name: "validate --json flag",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListHeadersFn:  listHeadersOK,
+			},
+			Args:       args("header list --service-id 123 --version 1 --json"),
+			WantOutput: `"Destination": "http.X-Example",`,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestHeaderDescribe(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("header describe --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate GetHeader API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetHeaderFn: func(i *fastly.GetHeaderInput) (*fastly.Header, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("header describe --service-id 123 --version 1 --name example"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate GetHeader API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetHeaderFn:    getHeaderOK,
+			},
+			Args:       args("header describe --service-id 123 --version 1 --name example"),
+			WantOutput: describeHeaderOutput,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestHeaderUpdate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("header update --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate UpdateHeader API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				UpdateHeaderFn: func(i *fastly.UpdateHeaderInput) (*fastly.Header, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("header update --service-id 123 --version 1 --name example --new-name renamed --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate UpdateHeader API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				UpdateHeaderFn: updateHeaderOK,
+			},
+			Args:       args("header update --service-id 123 --version 1 --name example --new-name renamed --autoclone"),
+			WantOutput: "Updated header renamed (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestHeaderDelete(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("header delete --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate DeleteHeader API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				DeleteHeaderFn: func(i *fastly.DeleteHeaderInput) error {
+					return testutil.Err
+				},
+			},
+			Args:      args("header delete --service-id 123 --version 1 --name example --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate DeleteHeader API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				DeleteHeaderFn: func(i *fastly.DeleteHeaderInput) error { return nil },
+			},
+			Args:       args("header delete --service-id 123 --version 1 --name example --autoclone"),
+			WantOutput: "Deleted header example (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func createHeaderOK(i *fastly.CreateHeaderInput) (*fastly.Header, error) {
+	if i.IgnoreIfSet == nil || !bool(*i.IgnoreIfSet) {
+		return nil, testutil.Err
+	}
+	return &fastly.Header{
+		Action:         *i.Action,
+		Destination:    *i.Destination,
+		Name:           *i.Name,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Source:         *i.Source,
+		Type:           *i.Type,
+	}, nil
+}
+
+func listHeadersOK(i *fastly.ListHeadersInput) ([]*fastly.Header, error) {
+	return []*fastly.Header{
+		{
+			Action:         fastly.HeaderActionSet,
+			Destination:    "http.X-Example",
+			Name:           "example",
+			Priority:       10,
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+			Source:         "req.http.Host",
+			Type:           fastly.HeaderTypeResponse,
+		},
+		{
+			Action:         fastly.HeaderActionDelete,
+			Destination:    "http.Cookie",
+			Name:           "strip-cookies",
+			Priority:       100,
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+			Type:           fastly.HeaderTypeRequest,
+		},
+	}, nil
+}
+
+var listHeadersShortOutput = strings.TrimSpace(`
+SERVICE  VERSION  NAME           TYPE      ACTION  DESTINATION     PRIORITY
+123      1        example        response  set     http.X-Example  10
+123      1        strip-cookies  request   delete  http.Cookie     100
+`) + "\n"
+
+var listHeadersVerboseOutput = strings.Join([]string{
+	"Version: 1",
+	"\tHeader 1/2",
+	"\t\tName: example",
+	"\t\tAction: set",
+	"\t\tType: response",
+	"\t\tDestination: http.X-Example",
+	"\t\tSource: req.http.Host",
+	"\t\tRegex: ",
+	"\t\tSubstitution: ",
+	"\t\tIgnore if set: false",
+	"\t\tPriority: 10",
+	"\t\tRequest condition: ",
+	"\t\tCache condition: ",
+	"\t\tResponse condition: ",
+	"\tHeader 2/2",
+	"\t\tName: strip-cookies",
+	"\t\tAction: delete",
+	"\t\tType: request",
+	"\t\tDestination: http.Cookie",
+}, "\n")
+
+func getHeaderOK(i *fastly.GetHeaderInput) (*fastly.Header, error) {
+	return &fastly.Header{
+		Action:         fastly.HeaderActionSet,
+		Destination:    "http.X-Example",
+		Name:           i.Name,
+		Priority:       10,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Source:         "req.http.Host",
+		Type:           fastly.HeaderTypeResponse,
+	}, nil
+}
+
+var describeHeaderOutput = `
+Service ID: 123
+Version: 1
+Name: example
+Action: set
+Type: response
+Destination: http.X-Example
+Source: req.http.Host
+Regex: 
+Substitution: 
+Ignore if set: false
+Priority: 10
+Request condition: 
+Cache condition: 
+Response condition: 
+`
+
+func updateHeaderOK(i *fastly.UpdateHeaderInput) (*fastly.Header, error) {
+	return &fastly.Header{
+		Name:           *i.NewName,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}, nil
+}
@@ -0,0 +1,170 @@
+package header
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// UpdateCommand calls the Fastly API to update headers.
+type UpdateCommand struct {
+	cmd.Base
+	manifest       manifest.Data
+	input          fastly.UpdateHeaderInput
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	autoClone      cmd.OptionalAutoClone
+
+	NewName           cmd.OptionalString
+	Action            cmd.OptionalString
+	Type              cmd.OptionalString
+	Destination       cmd.OptionalString
+	Source            cmd.OptionalString
+	Regex             cmd.OptionalString
+	Substitution      cmd.OptionalString
+	IgnoreIfSet       cmd.OptionalBool
+	Priority          cmd.OptionalInt
+	RequestCondition  cmd.OptionalString
+	CacheCondition    cmd.OptionalString
+	ResponseCondition cmd.OptionalString
+}
+
+// NewUpdateCommand returns a usable command registered under the parent.
+func NewUpdateCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *UpdateCommand {
+	c := UpdateCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+	c.CmdClause = parent.Command("update", "Update a header on a Fastly service version")
+
+	// required
+	c.CmdClause.Flag("name", "Header name").Short('n').Required().StringVar(&c.input.Name)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// optional
+	// NOTE: Actions and Types are defined in the same header package inside create.go
+	c.CmdClause.Flag("action", "The action to perform on the header").Action(c.Action.Set).HintOptions(Actions...).EnumVar(&c.Action.Value, Actions...)
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("cache-condition", "Name of the cache condition controlling when this header applies").Action(c.CacheCondition.Set).StringVar(&c.CacheCondition.Value)
+	c.CmdClause.Flag("dst", "The header to set").Action(c.Destination.Set).StringVar(&c.Destination.Value)
+	c.CmdClause.Flag("ignore-if-set", "Don't add the header if it is already set (only applies to the 'set' action)").Action(c.IgnoreIfSet.Set).BoolVar(&c.IgnoreIfSet.Value)
+	c.CmdClause.Flag("new-name", "New header name").Action(c.NewName.Set).StringVar(&c.NewName.Value)
+	c.CmdClause.Flag("priority", "Priority determines execution order. Lower numbers execute first").Action(c.Priority.Set).IntVar(&c.Priority.Value)
+	c.CmdClause.Flag("regex", "Regular expression to use (only applies to the 'regex' and 'regex_repeat' actions)").Action(c.Regex.Set).StringVar(&c.Regex.Value)
+	c.CmdClause.Flag("request-condition", "Condition, which if met, will select this header during a request").Action(c.RequestCondition.Set).StringVar(&c.RequestCondition.Value)
+	c.CmdClause.Flag("response-condition", "Name of a response condition controlling when this header applies").Action(c.ResponseCondition.Set).StringVar(&c.ResponseCondition.Value)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("src", "Variable to be used as a source for the header content (does not apply to the 'delete' action)").Action(c.Source.Set).StringVar(&c.Source.Value)
+	c.CmdClause.Flag("substitution", "Value to substitute in place of the regular expression (only applies to the 'regex' and 'regex_repeat' actions)").Action(c.Substitution.Set).StringVar(&c.Substitution.Value)
+	c.CmdClause.Flag("type", "The point in the request lifecycle at which the header is applied").Action(c.Type.Set).HintOptions(Types...).EnumVar(&c.Type.Value, Types...)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *UpdateCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flags.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	c.input.ServiceID = serviceID
+	c.input.ServiceVersion = serviceVersion.Number
+
+	if c.NewName.WasSet {
+		c.input.NewName = &c.NewName.Value
+	}
+
+	if c.Action.WasSet {
+		c.input.Action = fastly.HeaderActionPtr(fastly.HeaderAction(c.Action.Value))
+	}
+
+	if c.Type.WasSet {
+		c.input.Type = fastly.HeaderTypePtr(fastly.HeaderType(c.Type.Value))
+	}
+
+	if c.Destination.WasSet {
+		c.input.Destination = &c.Destination.Value
+	}
+
+	if c.Source.WasSet {
+		c.input.Source = &c.Source.Value
+	}
+
+	if c.Regex.WasSet {
+		c.input.Regex = &c.Regex.Value
+	}
+
+	if c.Substitution.WasSet {
+		c.input.Substitution = &c.Substitution.Value
+	}
+
+	if c.IgnoreIfSet.WasSet {
+		c.input.IgnoreIfSet = fastly.CBool(c.IgnoreIfSet.Value)
+	}
+
+	if c.Priority.WasSet {
+		c.input.Priority = &c.Priority.Value
+	}
+
+	if c.RequestCondition.WasSet {
+		c.input.RequestCondition = &c.RequestCondition.Value
+	}
+
+	if c.CacheCondition.WasSet {
+		c.input.CacheCondition = &c.CacheCondition.Value
+	}
+
+	if c.ResponseCondition.WasSet {
+		c.input.ResponseCondition = &c.ResponseCondition.Value
+	}
+
+	h, err := c.Globals.APIClient.UpdateHeader(&c.input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Updated header %s (service %s version %d)", h.Name, h.ServiceID, h.ServiceVersion)
+	return nil
+}
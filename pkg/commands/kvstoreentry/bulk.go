@@ -0,0 +1,215 @@
+package kvstoreentry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// defaultConcurrency is the default number of keys imported or exported in
+// parallel.
+const defaultConcurrency = 10
+
+// encodingBase64 is the encoding of a value that isn't valid UTF-8 text.
+const encodingBase64 = "base64"
+
+// Entry is a key-value pair as represented by a line of the JSON Lines format
+// used by the import and export commands.
+//
+// NOTE: A value that isn't valid UTF-8 text (e.g. an image) is base64 encoded
+// and the encoding is set to "base64".
+type Entry struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+var errMultipleSources = fsterr.RemediationError{
+	Inner:       fmt.Errorf("invalid flag combination, only one of --file, --dir and --stdin can be set"),
+	Remediation: "Use one of the --file, --dir or --stdin flags",
+}
+
+var errNoSTDINData = fsterr.RemediationError{
+	Inner:       fmt.Errorf("unable to read from STDIN"),
+	Remediation: "Provide data to STDIN, or use --file or --dir to read from the filesystem",
+}
+
+var errInvalidConcurrency = fsterr.RemediationError{
+	Inner:       fmt.Errorf("invalid --concurrency, it must be greater than zero"),
+	Remediation: fmt.Sprintf("Omit --concurrency to use the default (%d)", defaultConcurrency),
+}
+
+// concurrencyFlag returns the flag setting the number of parallel requests.
+func concurrencyFlag(dst *int) cmd.IntFlagOpts {
+	return cmd.IntFlagOpts{
+		Name:        "concurrency",
+		Description: fmt.Sprintf("Number of keys to process in parallel (default %d)", defaultConcurrency),
+		Dst:         dst,
+		Default:     defaultConcurrency,
+	}
+}
+
+// keyResult is the outcome of inserting or deleting a single key.
+type keyResult struct {
+	key string
+	err error
+}
+
+// newEntry returns the entry for a key-value pair, base64 encoding the value
+// when necessary.
+func newEntry(key, value string) Entry {
+	if utf8.ValidString(value) {
+		return Entry{Key: key, Value: value}
+	}
+	return Entry{
+		Key:      key,
+		Value:    base64.StdEncoding.EncodeToString([]byte(value)),
+		Encoding: encodingBase64,
+	}
+}
+
+// decodedValue returns the raw value of the entry.
+func (e Entry) decodedValue() (string, error) {
+	switch e.Encoding {
+	case "":
+		return e.Value, nil
+	case encodingBase64:
+		v, err := base64.StdEncoding.DecodeString(e.Value)
+		if err != nil {
+			return "", fmt.Errorf("invalid base64 value for key '%s': %w", e.Key, err)
+		}
+		return string(v), nil
+	}
+	return "", fmt.Errorf("unsupported encoding '%s' for key '%s'", e.Encoding, e.Key)
+}
+
+// readEntries decodes the JSON Lines from r, sending each entry to entries.
+func readEntries(r io.Reader, entries chan<- Entry) error {
+	dec := json.NewDecoder(r)
+	for n := 1; ; n++ {
+		var e Entry
+		err := dec.Decode(&e)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("error decoding entry %d: %w", n, err)
+		}
+		if e.Key == "" {
+			return fmt.Errorf("error decoding entry %d: missing key", n)
+		}
+		entries <- e
+	}
+}
+
+// readDirEntries sends an entry for each file within dir, where the key is
+// the path of the file relative to dir (using forward slashes).
+func readDirEntries(dir string, entries chan<- Entry) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		key, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		// gosec flagged this:
+		// G304 (CWE-22): Potential file inclusion via variable
+		// Disabling as we trust the source of the filepath variable.
+		/* #nosec */
+		value, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		entries <- Entry{Key: filepath.ToSlash(key), Value: string(value)}
+		return nil
+	})
+}
+
+// keyPath returns the path of the file for a key within dir.
+//
+// NOTE: A key that would be written outside of dir (e.g. ../secret) is an
+// error, as are keys that can't be represented as a file.
+func keyPath(dir, key string) (string, error) {
+	name := filepath.FromSlash(key)
+	if !filepath.IsLocal(name) || filepath.ToSlash(filepath.Clean(name)) != key {
+		return "", fmt.Errorf("key '%s' can't be exported to a directory", key)
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// listKeys pages through the keys of the kv store, calling fn with the keys of
+// each page.
+func listKeys(client api.Interface, storeID string, fn func(keys []string) error) error {
+	var cursor string
+	for {
+		o, err := client.ListKVStoreKeys(&fastly.ListKVStoreKeysInput{
+			Cursor: cursor,
+			ID:     storeID,
+		})
+		if err != nil {
+			return fmt.Errorf("error listing keys: %w", err)
+		}
+		if err := fn(o.Data); err != nil {
+			return err
+		}
+		if cursor = o.Meta["next_cursor"]; cursor == "" {
+			return nil
+		}
+	}
+}
+
+// fetchValues returns the values of the keys (in the same order), fetching up
+// to concurrency values in parallel.
+func fetchValues(client api.Interface, storeID string, keys []string, concurrency int) ([]string, error) {
+	var (
+		values  = make([]string, len(keys))
+		errs    = make([]error, len(keys))
+		indexes = make(chan int)
+		wg      sync.WaitGroup
+	)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				value, err := client.GetKVStoreKey(&fastly.GetKVStoreKeyInput{
+					ID:  storeID,
+					Key: keys[i],
+				})
+				if err != nil {
+					errs[i] = fmt.Errorf("error fetching key '%s': %w", keys[i], err)
+					continue
+				}
+				values[i] = value
+			}
+		}()
+	}
+	for i := range keys {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,173 @@
+package kvstoreentry
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/filesystem"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// ExportCommand calls the Fastly API to fetch every key and value of an kv
+// store.
+type ExportCommand struct {
+	cmd.Base
+	manifest manifest.Data
+
+	concurrency int
+	dir         string
+	file        string
+	storeID     string
+}
+
+// NewExportCommand returns a usable command registered under the parent.
+func NewExportCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *ExportCommand {
+	c := ExportCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+	c.CmdClause = parent.Command("export", "Write every key-value pair as JSON Lines to a file or STDOUT, or as files to a directory")
+	c.CmdClause.Flag("store-id", "Store ID").Short('s').Required().StringVar(&c.storeID)
+
+	// optional
+	c.RegisterFlagInt(concurrencyFlag(&c.concurrency))
+	c.CmdClause.Flag("dir", "Write each value to a file within the directory, with the key as the file path (relative to the directory)").StringVar(&c.dir)
+	c.CmdClause.Flag("file", "Write JSON Lines to the file instead of STDOUT").Short('f').StringVar(&c.file)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ExportCommand) Exec(_ io.Reader, out io.Writer) (err error) {
+	if c.dir != "" && c.file != "" {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid flag combination, --file and --dir"),
+			Remediation: "Use one of the --file or --dir flags",
+		}
+	}
+	if c.concurrency < 1 {
+		return errInvalidConcurrency
+	}
+
+	var write func(Entry) error
+	switch {
+	case c.dir != "":
+		if err := filesystem.MakeDirectoryIfNotExists(c.dir); err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error creating export directory: %w", err)
+		}
+		write = func(e Entry) error {
+			return writeKeyFile(c.dir, e)
+		}
+	case c.file != "":
+		// gosec flagged this:
+		// G304 (CWE-22): Potential file inclusion via variable
+		// Disabling as we trust the source of the filepath variable.
+		/* #nosec */
+		f, err := os.Create(c.file)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		defer func() {
+			if cerr := f.Close(); err == nil && cerr != nil {
+				err = cerr
+			}
+		}()
+		enc := json.NewEncoder(f)
+		write = func(e Entry) error {
+			return enc.Encode(e)
+		}
+	default:
+		// NOTE: No progress is displayed when writing the entries to STDOUT.
+		enc := json.NewEncoder(out)
+		err := c.export(func(e Entry) error {
+			return enc.Encode(e)
+		}, nil)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+		}
+		return err
+	}
+
+	spinner, err := text.NewSpinner(out)
+	if err != nil {
+		return err
+	}
+	err = spinner.Start()
+	if err != nil {
+		return err
+	}
+	msg := "Exporting keys"
+	spinner.Message(msg + "...")
+
+	var exported int
+	err = c.export(write, func(n int) {
+		exported = n
+		spinner.Message(fmt.Sprintf("%s (%d exported)...", msg, n))
+	})
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		spinner.StopFailMessage(msg)
+		spinErr := spinner.StopFail()
+		if spinErr != nil {
+			return spinErr
+		}
+		return err
+	}
+
+	spinner.StopMessage(msg)
+	err = spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	text.Success(out, "Exported %d keys from kv store %s", exported, c.storeID)
+	return nil
+}
+
+// export pages through the keys of the kv store, fetching the values of each
+// page in parallel, and writes the entries in the order the keys are listed.
+func (c *ExportCommand) export(write func(Entry) error, progress func(int)) error {
+	var exported int
+	return listKeys(c.Globals.APIClient, c.storeID, func(keys []string) error {
+		values, err := fetchValues(c.Globals.APIClient, c.storeID, keys, c.concurrency)
+		if err != nil {
+			return err
+		}
+		for i, key := range keys {
+			if err := write(newEntry(key, values[i])); err != nil {
+				return fmt.Errorf("error writing key '%s': %w", key, err)
+			}
+			exported++
+			if progress != nil {
+				progress(exported)
+			}
+		}
+		return nil
+	})
+}
+
+// writeKeyFile writes the raw value of the entry to the file for its key.
+func writeKeyFile(dir string, e Entry) error {
+	path, err := keyPath(dir, e.Key)
+	if err != nil {
+		return err
+	}
+	value, err := e.decodedValue()
+	if err != nil {
+		return err
+	}
+	if err := filesystem.MakeDirectoryIfNotExists(filepath.Dir(path)); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(value), 0o600)
+}
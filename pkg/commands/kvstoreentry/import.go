@@ -0,0 +1,186 @@
+package kvstoreentry
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// ImportCommand calls the Fastly API to insert many keys into an kv store.
+type ImportCommand struct {
+	cmd.Base
+	manifest manifest.Data
+
+	concurrency int
+	dir         string
+	file        string
+	stdin       bool
+	storeID     string
+}
+
+// NewImportCommand returns a usable command registered under the parent.
+func NewImportCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *ImportCommand {
+	c := ImportCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+	c.CmdClause = parent.Command("import", "Insert key-value pairs from a JSON Lines file, a directory or STDIN")
+	c.CmdClause.Flag("store-id", "Store ID").Short('s').Required().StringVar(&c.storeID)
+
+	// optional
+	c.RegisterFlagInt(concurrencyFlag(&c.concurrency))
+	c.CmdClause.Flag("dir", "Read each file within the directory as a value, with the file path (relative to the directory) as its key").StringVar(&c.dir)
+	c.CmdClause.Flag("file", `Read JSON Lines from the file, one {"key": "...", "value": "..."} object per line`).Short('f').StringVar(&c.file)
+	c.CmdClause.Flag("stdin", "Read JSON Lines from STDIN").BoolVar(&c.stdin)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ImportCommand) Exec(in io.Reader, out io.Writer) error {
+	var sources int
+	for _, set := range []bool{c.dir != "", c.file != "", c.stdin} {
+		if set {
+			sources++
+		}
+	}
+	if sources > 1 {
+		return errMultipleSources
+	}
+	if c.concurrency < 1 {
+		return errInvalidConcurrency
+	}
+
+	var read func(chan<- Entry) error
+	switch {
+	case c.dir != "":
+		read = func(entries chan<- Entry) error {
+			return readDirEntries(c.dir, entries)
+		}
+	case c.file != "":
+		// gosec flagged this:
+		// G304 (CWE-22): Potential file inclusion via variable
+		// Disabling as we trust the source of the filepath variable.
+		/* #nosec */
+		f, err := os.Open(c.file)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return err
+		}
+		defer f.Close() // #nosec G307
+		read = func(entries chan<- Entry) error {
+			return readEntries(f, entries)
+		}
+	default:
+		// NOTE: STDIN is read when neither --file nor --dir is set.
+		// Determine if 'in' has data available.
+		if in == nil || text.IsTTY(in) {
+			return errNoSTDINData
+		}
+		read = func(entries chan<- Entry) error {
+			return readEntries(in, entries)
+		}
+	}
+
+	spinner, err := text.NewSpinner(out)
+	if err != nil {
+		return err
+	}
+	err = spinner.Start()
+	if err != nil {
+		return err
+	}
+	msg := "Importing keys"
+	spinner.Message(msg + "...")
+
+	var (
+		entries = make(chan Entry)
+		readErr = make(chan error, 1)
+		results = make(chan keyResult)
+		wg      sync.WaitGroup
+	)
+
+	go func() {
+		defer close(entries)
+		readErr <- read(entries)
+	}()
+
+	for i := 0; i < c.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for e := range entries {
+				results <- keyResult{key: e.Key, err: c.insert(e)}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var (
+		imported int
+		failed   []keyResult
+	)
+	for r := range results {
+		if r.err != nil {
+			failed = append(failed, r)
+			continue
+		}
+		imported++
+		spinner.Message(fmt.Sprintf("%s (%d imported)...", msg, imported))
+	}
+
+	err = <-readErr
+	if err == nil && len(failed) > 0 {
+		err = fmt.Errorf("failed to import %d keys (%d imported)", len(failed), imported)
+	}
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		spinner.StopFailMessage(msg)
+		spinErr := spinner.StopFail()
+		if spinErr != nil {
+			return spinErr
+		}
+		for _, r := range failed {
+			c.Globals.ErrLog.AddWithContext(r.err, map[string]any{
+				"Store ID": c.storeID,
+				"Key":      r.key,
+			})
+			text.Error(out, "error importing key '%s': %s", r.key, r.err)
+		}
+		return err
+	}
+
+	spinner.StopMessage(msg)
+	err = spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	text.Success(out, "Imported %d keys into kv store %s", imported, c.storeID)
+	return nil
+}
+
+// insert inserts the entry into the kv store.
+func (c *ImportCommand) insert(e Entry) error {
+	value, err := e.decodedValue()
+	if err != nil {
+		return err
+	}
+	return c.Globals.APIClient.InsertKVStoreKey(&fastly.InsertKVStoreKeyInput{
+		ID:    c.storeID,
+		Key:   e.Key,
+		Value: value,
+	})
+}
@@ -0,0 +1,349 @@
+package kvstoreentry_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+const storeID = "store123"
+
+func TestImportCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	jsonlFile := filepath.Join(tmpDir, "data.jsonl")
+	jsonl := `{"key":"foo","value":"bar"}
+{"key":"img","value":"/w==","encoding":"base64"}
+`
+	if err := os.WriteFile(jsonlFile, []byte(jsonl), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	invalidFile := filepath.Join(tmpDir, "invalid.jsonl")
+	if err := os.WriteFile(invalidFile, []byte(`{"value":"bar"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	dataDir := filepath.Join(tmpDir, "data")
+	if err := os.MkdirAll(filepath.Join(dataDir, "nested"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "foo"), []byte("bar"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "nested", "baz"), []byte("qux"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		args       string
+		stdin      string
+		insertErr  error
+		wantError  string
+		wantOutput []string
+		wantKeys   map[string]string
+	}{
+		{
+			args:      "import --file " + jsonlFile,
+			wantError: "error parsing arguments: required flag --store-id not provided",
+		},
+		{
+			args:      fmt.Sprintf("import --store-id %s --file %s --dir %s", storeID, jsonlFile, dataDir),
+			wantError: "invalid flag combination",
+		},
+		{
+			args:      fmt.Sprintf("import --store-id %s --file %s --concurrency 0", storeID, jsonlFile),
+			wantError: "invalid --concurrency",
+		},
+		{
+			args:      fmt.Sprintf("import --store-id %s", storeID),
+			wantError: "unable to read from STDIN",
+		},
+		{
+			args:      fmt.Sprintf("import --store-id %s --file %s", storeID, invalidFile),
+			wantError: "error decoding entry 1: missing key",
+		},
+		{
+			args:       fmt.Sprintf("import --store-id %s --file %s", storeID, jsonlFile),
+			wantOutput: []string{"Imported 2 keys into kv store " + storeID},
+			wantKeys:   map[string]string{"foo": "bar", "img": "\xff"},
+		},
+		{
+			args:       fmt.Sprintf("import --store-id %s --dir %s", storeID, dataDir),
+			wantOutput: []string{"Imported 2 keys into kv store " + storeID},
+			wantKeys:   map[string]string{"foo": "bar", "nested/baz": "qux"},
+		},
+		{
+			args:       fmt.Sprintf("import --store-id %s --stdin", storeID),
+			stdin:      `{"key":"foo","value":"bar"}`,
+			wantOutput: []string{"Imported 1 keys into kv store " + storeID},
+			wantKeys:   map[string]string{"foo": "bar"},
+		},
+		{
+			args:       fmt.Sprintf("import --store-id %s --file %s", storeID, jsonlFile),
+			insertErr:  errors.New("whoops"),
+			wantError:  "failed to import 2 keys (0 imported)",
+			wantOutput: []string{"error importing key 'foo': whoops", "error importing key 'img': whoops"},
+			wantKeys:   map[string]string{},
+		},
+	}
+
+	for _, testcase := range scenarios {
+		testcase := testcase
+		t.Run(testcase.args, func(t *testing.T) {
+			var (
+				mu   sync.Mutex
+				keys = make(map[string]string)
+			)
+			api := mock.API{
+				InsertKVStoreKeyFn: func(i *fastly.InsertKVStoreKeyInput) error {
+					if i.ID != storeID {
+						return fmt.Errorf("unexpected store ID: %s", i.ID)
+					}
+					if testcase.insertErr != nil {
+						return testcase.insertErr
+					}
+					mu.Lock()
+					defer mu.Unlock()
+					keys[i.Key] = i.Value
+					return nil
+				},
+			}
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testutil.Args("kv-store-entry "+testcase.args), &stdout)
+			if testcase.stdin != "" {
+				var stdin bytes.Buffer
+				stdin.WriteString(testcase.stdin)
+				opts.Stdin = &stdin
+			}
+			opts.APIClient = mock.APIClient(api)
+
+			err := app.Run(opts)
+
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+			if testcase.wantKeys != nil {
+				testutil.AssertEqual(t, testcase.wantKeys, keys)
+			}
+		})
+	}
+}
+
+func TestExportCommand(t *testing.T) {
+	// The keys are listed over two pages.
+	pages := map[string]*fastly.ListKVStoreKeysResponse{
+		"": {
+			Data: []string{"foo", "img"},
+			Meta: map[string]string{"next_cursor": "page2"},
+		},
+		"page2": {
+			Data: []string{"nested/baz"},
+		},
+	}
+	values := map[string]string{
+		"foo":        "bar",
+		"img":        "\xff",
+		"nested/baz": "qux",
+	}
+
+	api := mock.API{
+		ListKVStoreKeysFn: func(i *fastly.ListKVStoreKeysInput) (*fastly.ListKVStoreKeysResponse, error) {
+			if i.ID != storeID {
+				return nil, fmt.Errorf("unexpected store ID: %s", i.ID)
+			}
+			return pages[i.Cursor], nil
+		},
+		GetKVStoreKeyFn: func(i *fastly.GetKVStoreKeyInput) (string, error) {
+			return values[i.Key], nil
+		},
+	}
+
+	wantJSONL := `{"key":"foo","value":"bar"}
+{"key":"img","value":"/w==","encoding":"base64"}
+{"key":"nested/baz","value":"qux"}
+`
+
+	t.Run("stdout", func(t *testing.T) {
+		var stdout bytes.Buffer
+		opts := testutil.NewRunOpts(testutil.Args("kv-store-entry export --store-id "+storeID), &stdout)
+		opts.APIClient = mock.APIClient(api)
+		err := app.Run(opts)
+		testutil.AssertNoError(t, err)
+		testutil.AssertString(t, wantJSONL, stdout.String())
+	})
+
+	t.Run("file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "data.jsonl")
+		var stdout bytes.Buffer
+		opts := testutil.NewRunOpts(testutil.Args(fmt.Sprintf("kv-store-entry export --store-id %s --file %s", storeID, file)), &stdout)
+		opts.APIClient = mock.APIClient(api)
+		err := app.Run(opts)
+		testutil.AssertNoError(t, err)
+		testutil.AssertStringContains(t, stdout.String(), "Exported 3 keys from kv store "+storeID)
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testutil.AssertString(t, wantJSONL, string(data))
+	})
+
+	t.Run("dir", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "data")
+		var stdout bytes.Buffer
+		opts := testutil.NewRunOpts(testutil.Args(fmt.Sprintf("kv-store-entry export --store-id %s --dir %s", storeID, dir)), &stdout)
+		opts.APIClient = mock.APIClient(api)
+		err := app.Run(opts)
+		testutil.AssertNoError(t, err)
+		testutil.AssertStringContains(t, stdout.String(), "Exported 3 keys from kv store "+storeID)
+
+		for key, want := range values {
+			data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(key)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			testutil.AssertString(t, want, string(data))
+		}
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		api := api
+		api.ListKVStoreKeysFn = func(i *fastly.ListKVStoreKeysInput) (*fastly.ListKVStoreKeysResponse, error) {
+			return &fastly.ListKVStoreKeysResponse{Data: []string{"../foo"}}, nil
+		}
+		var stdout bytes.Buffer
+		opts := testutil.NewRunOpts(testutil.Args(fmt.Sprintf("kv-store-entry export --store-id %s --dir %s", storeID, t.TempDir())), &stdout)
+		opts.APIClient = mock.APIClient(api)
+		err := app.Run(opts)
+		testutil.AssertErrorContains(t, err, "key '../foo' can't be exported to a directory")
+	})
+}
+
+func TestSyncCommand(t *testing.T) {
+	dataDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dataDir, "same"), []byte("value"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "changed"), []byte("new"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "added"), []byte("value"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	scenarios := []struct {
+		args        string
+		insertErr   error
+		wantError   string
+		wantOutput  []string
+		wantInserts []string
+		wantDeletes []string
+	}{
+		{
+			args:      "sync --store-id " + storeID,
+			wantError: "one of --dir and --local-store must be set",
+		},
+		{
+			args:      fmt.Sprintf("sync --store-id %s --local-store example", storeID),
+			wantError: "no [local_server.kv_stores.example] configuration found",
+		},
+		{
+			args: fmt.Sprintf("sync --store-id %s --dir %s --dry-run", storeID, dataDir),
+			wantOutput: []string{
+				"Dry run: no changes will be made to the kv store",
+				"Upload added",
+				"Upload changed",
+				"1 keys in the kv store don't exist locally, use --delete to delete them",
+			},
+		},
+		{
+			args: fmt.Sprintf("sync --store-id %s --dir %s --dry-run --delete", storeID, dataDir),
+			wantOutput: []string{
+				"Delete removed",
+			},
+		},
+		{
+			args:        fmt.Sprintf("sync --store-id %s --dir %s", storeID, dataDir),
+			wantOutput:  []string{"Synced kv store store123 (1 new, 1 changed, 0 deleted, 1 unchanged)"},
+			wantInserts: []string{"added", "changed"},
+		},
+		{
+			args:        fmt.Sprintf("sync --store-id %s --dir %s --delete", storeID, dataDir),
+			wantOutput:  []string{"Synced kv store store123 (1 new, 1 changed, 1 deleted, 1 unchanged)"},
+			wantInserts: []string{"added", "changed"},
+			wantDeletes: []string{"removed"},
+		},
+		{
+			args:       fmt.Sprintf("sync --store-id %s --dir %s", storeID, dataDir),
+			insertErr:  errors.New("whoops"),
+			wantError:  "failed to sync 2 keys (0 synced)",
+			wantOutput: []string{"error syncing key 'added': whoops"},
+		},
+	}
+
+	for _, testcase := range scenarios {
+		testcase := testcase
+		t.Run(testcase.args, func(t *testing.T) {
+			var (
+				mu      sync.Mutex
+				inserts []string
+				deletes []string
+			)
+			api := mock.API{
+				ListKVStoreKeysFn: func(i *fastly.ListKVStoreKeysInput) (*fastly.ListKVStoreKeysResponse, error) {
+					return &fastly.ListKVStoreKeysResponse{Data: []string{"changed", "removed", "same"}}, nil
+				},
+				GetKVStoreKeyFn: func(i *fastly.GetKVStoreKeyInput) (string, error) {
+					if i.Key == "changed" {
+						return "old", nil
+					}
+					return "value", nil
+				},
+				InsertKVStoreKeyFn: func(i *fastly.InsertKVStoreKeyInput) error {
+					if testcase.insertErr != nil {
+						return testcase.insertErr
+					}
+					mu.Lock()
+					defer mu.Unlock()
+					inserts = append(inserts, i.Key)
+					return nil
+				},
+				DeleteKVStoreKeyFn: func(i *fastly.DeleteKVStoreKeyInput) error {
+					mu.Lock()
+					defer mu.Unlock()
+					deletes = append(deletes, i.Key)
+					return nil
+				},
+			}
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testutil.Args("kv-store-entry "+testcase.args), &stdout)
+			opts.APIClient = mock.APIClient(api)
+
+			err := app.Run(opts)
+
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+			sort.Strings(inserts)
+			sort.Strings(deletes)
+			testutil.AssertString(t, strings.Join(testcase.wantInserts, ","), strings.Join(inserts, ","))
+			testutil.AssertString(t, strings.Join(testcase.wantDeletes, ","), strings.Join(deletes, ","))
+		})
+	}
+}
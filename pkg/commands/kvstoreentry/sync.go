@@ -0,0 +1,321 @@
+package kvstoreentry
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// SyncCommand calls the Fastly API to make the keys of an kv store match a
+// local directory or [local_server.kv_stores] configuration.
+type SyncCommand struct {
+	cmd.Base
+	manifest manifest.Data
+
+	concurrency int
+	delete      bool
+	dir         string
+	dryRun      bool
+	localStore  string
+	storeID     string
+}
+
+// NewSyncCommand returns a usable command registered under the parent.
+func NewSyncCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *SyncCommand {
+	c := SyncCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+	c.CmdClause = parent.Command("sync", "Upload new and changed keys from a directory or the fastly.toml [local_server.kv_stores] configuration")
+	c.CmdClause.Flag("store-id", "Store ID").Short('s').Required().StringVar(&c.storeID)
+
+	// optional
+	c.RegisterFlagInt(concurrencyFlag(&c.concurrency))
+	c.CmdClause.Flag("delete", "Delete keys from the kv store that don't exist locally").BoolVar(&c.delete)
+	c.CmdClause.Flag("dir", "Read each file within the directory as a value, with the file path (relative to the directory) as its key").StringVar(&c.dir)
+	c.CmdClause.Flag("dry-run", "Display the changes that would be made without making them").BoolVar(&c.dryRun)
+	c.CmdClause.Flag("local-store", "Read the keys from the named [local_server.kv_stores] configuration in the fastly.toml manifest").StringVar(&c.localStore)
+	return &c
+}
+
+// syncPlan is the difference between the local keys and the kv store.
+type syncPlan struct {
+	// added are the local keys missing from the kv store.
+	added []string
+	// changed are the keys whose local value differs from the kv store.
+	changed []string
+	// deleted are the keys missing locally that will be deleted.
+	deleted []string
+	// remoteOnly are the keys missing locally that will be left unchanged.
+	remoteOnly []string
+	// unchanged is the number of keys with identical values.
+	unchanged int
+}
+
+// Exec invokes the application logic for the command.
+func (c *SyncCommand) Exec(_ io.Reader, out io.Writer) error {
+	if (c.dir == "") == (c.localStore == "") {
+		return fsterr.RemediationError{
+			Inner:       fmt.Errorf("invalid flag combination, one of --dir and --local-store must be set"),
+			Remediation: "Use one of the --dir or --local-store flags",
+		}
+	}
+	if c.concurrency < 1 {
+		return errInvalidConcurrency
+	}
+
+	local, err := c.localEntries()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	plan, err := c.plan(local)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Store ID": c.storeID,
+		})
+		return err
+	}
+
+	if c.dryRun {
+		text.Info(out, "Dry run: no changes will be made to the kv store")
+		text.Break(out)
+		text.Description(out, "New keys", planKeys(plan.added, "Upload"))
+		text.Description(out, "Changed keys", planKeys(plan.changed, "Upload"))
+		text.Description(out, "Deleted keys", planKeys(plan.deleted, "Delete"))
+		if len(plan.remoteOnly) > 0 {
+			text.Info(out, "%d keys in the kv store don't exist locally, use --delete to delete them", len(plan.remoteOnly))
+		}
+		return nil
+	}
+
+	total := len(plan.added) + len(plan.changed) + len(plan.deleted)
+	if total == 0 {
+		text.Success(out, "The kv store %s is up to date (%d keys unchanged)", c.storeID, plan.unchanged)
+		return nil
+	}
+
+	spinner, err := text.NewSpinner(out)
+	if err != nil {
+		return err
+	}
+	err = spinner.Start()
+	if err != nil {
+		return err
+	}
+	msg := "Syncing keys"
+	spinner.Message(msg + "...")
+
+	var (
+		keys    = make(chan string)
+		results = make(chan keyResult)
+		wg      sync.WaitGroup
+	)
+
+	go func() {
+		defer close(keys)
+		for _, key := range plan.added {
+			keys <- key
+		}
+		for _, key := range plan.changed {
+			keys <- key
+		}
+		for _, key := range plan.deleted {
+			keys <- key
+		}
+	}()
+
+	for i := 0; i < c.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range keys {
+				results <- keyResult{key: key, err: c.apply(key, local)}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var (
+		synced int
+		failed []keyResult
+	)
+	for r := range results {
+		if r.err != nil {
+			failed = append(failed, r)
+			continue
+		}
+		synced++
+		spinner.Message(fmt.Sprintf("%s (%d of %d)...", msg, synced, total))
+	}
+
+	if len(failed) > 0 {
+		err := fmt.Errorf("failed to sync %d keys (%d synced)", len(failed), synced)
+		c.Globals.ErrLog.Add(err)
+		spinner.StopFailMessage(msg)
+		spinErr := spinner.StopFail()
+		if spinErr != nil {
+			return spinErr
+		}
+		for _, r := range failed {
+			c.Globals.ErrLog.AddWithContext(r.err, map[string]any{
+				"Store ID": c.storeID,
+				"Key":      r.key,
+			})
+			text.Error(out, "error syncing key '%s': %s", r.key, r.err)
+		}
+		return err
+	}
+
+	spinner.StopMessage(msg)
+	err = spinner.Stop()
+	if err != nil {
+		return err
+	}
+
+	text.Success(out, "Synced kv store %s (%d new, %d changed, %d deleted, %d unchanged)", c.storeID, len(plan.added), len(plan.changed), len(plan.deleted), plan.unchanged)
+	if len(plan.remoteOnly) > 0 {
+		text.Info(out, "%d keys in the kv store don't exist locally, use --delete to delete them", len(plan.remoteOnly))
+	}
+	return nil
+}
+
+// localEntries returns the local value of each key.
+func (c *SyncCommand) localEntries() (map[string]string, error) {
+	local := make(map[string]string)
+
+	if c.dir != "" {
+		entries := make(chan Entry)
+		readErr := make(chan error, 1)
+		go func() {
+			defer close(entries)
+			readErr <- readDirEntries(c.dir, entries)
+		}()
+		for e := range entries {
+			local[e.Key] = e.Value
+		}
+		if err := <-readErr; err != nil {
+			return nil, fmt.Errorf("error reading directory '%s': %w", c.dir, err)
+		}
+		return local, nil
+	}
+
+	items, ok := c.manifest.File.LocalServer.KVStores[c.localStore]
+	if !ok {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("no [local_server.kv_stores.%s] configuration found", c.localStore),
+			Remediation: fmt.Sprintf("Define the kv store keys in the %s [local_server.kv_stores] configuration.", manifest.Filename),
+		}
+	}
+	for _, item := range items {
+		if item.File == "" {
+			local[item.Key] = item.Data
+			continue
+		}
+		// gosec flagged this:
+		// G304 (CWE-22): Potential file inclusion via variable
+		// Disabling as we trust the source of the filepath variable.
+		/* #nosec */
+		value, err := os.ReadFile(item.File)
+		if err != nil {
+			return nil, fmt.Errorf("error reading file for key '%s': %w", item.Key, err)
+		}
+		local[item.Key] = string(value)
+	}
+	return local, nil
+}
+
+// plan compares the local keys with the kv store.
+//
+// NOTE: The API doesn't expose a digest of each value, so the value of every
+// key that exists both locally and remotely is fetched for the comparison.
+func (c *SyncCommand) plan(local map[string]string) (syncPlan, error) {
+	var (
+		plan   syncPlan
+		remote = make(map[string]bool)
+	)
+
+	err := listKeys(c.Globals.APIClient, c.storeID, func(keys []string) error {
+		var shared []string
+		for _, key := range keys {
+			remote[key] = true
+			if _, ok := local[key]; ok {
+				shared = append(shared, key)
+				continue
+			}
+			if c.delete {
+				plan.deleted = append(plan.deleted, key)
+			} else {
+				plan.remoteOnly = append(plan.remoteOnly, key)
+			}
+		}
+
+		values, err := fetchValues(c.Globals.APIClient, c.storeID, shared, c.concurrency)
+		if err != nil {
+			return err
+		}
+		for i, key := range shared {
+			if values[i] == local[key] {
+				plan.unchanged++
+				continue
+			}
+			plan.changed = append(plan.changed, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return plan, err
+	}
+
+	for key := range local {
+		if !remote[key] {
+			plan.added = append(plan.added, key)
+		}
+	}
+	sort.Strings(plan.added)
+	sort.Strings(plan.changed)
+	sort.Strings(plan.deleted)
+	return plan, nil
+}
+
+// apply uploads the local value of the key, or deletes the key when it
+// doesn't exist locally.
+func (c *SyncCommand) apply(key string, local map[string]string) error {
+	value, ok := local[key]
+	if !ok {
+		return c.Globals.APIClient.DeleteKVStoreKey(&fastly.DeleteKVStoreKeyInput{
+			ID:  c.storeID,
+			Key: key,
+		})
+	}
+	return c.Globals.APIClient.InsertKVStoreKey(&fastly.InsertKVStoreKeyInput{
+		ID:    c.storeID,
+		Key:   key,
+		Value: value,
+	})
+}
+
+// planKeys describes the change that would be made to the keys.
+func planKeys(keys []string, action string) string {
+	if len(keys) == 0 {
+		return "None"
+	}
+	return action + " " + strings.Join(keys, ", ")
+}
@@ -0,0 +1,336 @@
+package requestsetting_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+func TestRequestSettingCreate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("request-setting create --service-id 123 --name example"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name:      "validate invalid --xff flag",
+			Args:      args("request-setting create --service-id 123 --version 1 --xff replace"),
+			WantError: "error parsing arguments: enum value must be one of clear,leave,append,append_all,overwrite, got 'replace'",
+		},
+		{
+			Name: "validate CreateRequestSetting API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateRequestSettingFn: func(i *fastly.CreateRequestSettingInput) (*fastly.RequestSetting, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("request-setting create --service-id 123 --version 1 --name example --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate CreateRequestSetting API success",
+			API: mock.API{
+				ListVersionsFn:         testutil.ListVersions,
+				CloneVersionFn:         testutil.CloneVersionResult(4),
+				CreateRequestSettingFn: createRequestSettingOK,
+			},
+			Args:       args("request-setting create --service-id 123 --version 1 --name example --action pass --xff append --force-ssl --autoclone"),
+			WantOutput: "Created request setting example (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestRequestSettingList(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate ListRequestSettings API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListRequestSettingsFn: func(i *fastly.ListRequestSettingsInput) ([]*fastly.RequestSetting, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("request-setting list --service-id 123 --version 1"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate ListRequestSettings API success",
+			API: mock.API{
+				ListVersionsFn:        testutil.ListVersions,
+				ListRequestSettingsFn: listRequestSettingsOK,
+			},
+			Args:       args("request-setting list --service-id 123 --version 1"),
+			WantOutput: listRequestSettingsShortOutput,
+		},
+		{
+			Name: "validate --verbose flag",
+			API: mock.API{
+				ListVersionsFn:        testutil.ListVersions,
+				ListRequestSettingsFn: listRequestSettingsOK,
+			},
+			Args:       args("request-setting list --service-id 123 --version 1 --verbose"),
+			WantOutput: listRequestSettingsVerboseOutput,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestRequestSettingDescribe(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("request-setting describe --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate GetRequestSetting API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetRequestSettingFn: func(i *fastly.GetRequestSettingInput) (*fastly.RequestSetting, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("request-setting describe --service-id 123 --version 1 --name example"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate GetRequestSetting API success",
+			API: mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				GetRequestSettingFn: getRequestSettingOK,
+			},
+			Args:       args("request-setting describe --service-id 123 --version 1 --name example"),
+			WantOutput: describeRequestSettingOutput,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestRequestSettingUpdate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("request-setting update --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate UpdateRequestSetting API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				UpdateRequestSettingFn: func(i *fastly.UpdateRequestSettingInput) (*fastly.RequestSetting, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("request-setting update --service-id 123 --version 1 --name example --new-name renamed --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate UpdateRequestSetting API success",
+			API: mock.API{
+				ListVersionsFn:         testutil.ListVersions,
+				CloneVersionFn:         testutil.CloneVersionResult(4),
+				UpdateRequestSettingFn: updateRequestSettingOK,
+			},
+			Args:       args("request-setting update --service-id 123 --version 1 --name example --new-name renamed --xff overwrite --autoclone"),
+			WantOutput: "Updated request setting renamed (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestRequestSettingDelete(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("request-setting delete --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate DeleteRequestSetting API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				DeleteRequestSettingFn: func(i *fastly.DeleteRequestSettingInput) error {
+					return testutil.Err
+				},
+			},
+			Args:      args("request-setting delete --service-id 123 --version 1 --name example --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate DeleteRequestSetting API success",
+			API: mock.API{
+				ListVersionsFn:         testutil.ListVersions,
+				CloneVersionFn:         testutil.CloneVersionResult(4),
+				DeleteRequestSettingFn: func(i *fastly.DeleteRequestSettingInput) error { return nil },
+			},
+			Args:       args("request-setting delete --service-id 123 --version 1 --name example --autoclone"),
+			WantOutput: "Deleted request setting example (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func createRequestSettingOK(i *fastly.CreateRequestSettingInput) (*fastly.RequestSetting, error) {
+	if i.XForwardedFor == nil || *i.XForwardedFor != fastly.RequestSettingXFFAppend || i.ForceSSL == nil || !bool(*i.ForceSSL) {
+		return nil, testutil.Err
+	}
+	return &fastly.RequestSetting{
+		Action:         *i.Action,
+		ForceSSL:       true,
+		Name:           *i.Name,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		XForwardedFor:  *i.XForwardedFor,
+	}, nil
+}
+
+func listRequestSettingsOK(i *fastly.ListRequestSettingsInput) ([]*fastly.RequestSetting, error) {
+	return []*fastly.RequestSetting{
+		{
+			Action:           fastly.RequestSettingActionPass,
+			Name:             "bypass",
+			RequestCondition: "is-admin",
+			ServiceID:        i.ServiceID,
+			ServiceVersion:   i.ServiceVersion,
+			XForwardedFor:    fastly.RequestSettingXFFAppend,
+		},
+		{
+			ForceSSL:       true,
+			Name:           "https",
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+			XForwardedFor:  fastly.RequestSettingXFFLeave,
+		},
+	}, nil
+}
+
+var listRequestSettingsShortOutput = strings.Join([]string{
+	"SERVICE  VERSION  NAME    ACTION  XFF     FORCE SSL  REQUEST CONDITION",
+	"123      1        bypass  pass    append  false      is-admin",
+	"123      1        https           leave   true",
+}, "\n")
+
+var listRequestSettingsVerboseOutput = strings.Join([]string{
+	"Version: 1",
+	"\tRequest setting 1/2",
+	"\t\tName: bypass",
+	"\t\tAction: pass",
+	"\t\tX-Forwarded-For: append",
+	"\t\tDefault host: ",
+	"\t\tHash keys: ",
+	"\t\tMax stale age: 0",
+	"\t\tBypass busy wait: false",
+	"\t\tForce miss: false",
+	"\t\tForce SSL: false",
+	"\t\tGeo headers: false",
+	"\t\tTimer support: false",
+	"\t\tRequest condition: is-admin",
+	"\tRequest setting 2/2",
+	"\t\tName: https",
+}, "\n")
+
+func getRequestSettingOK(i *fastly.GetRequestSettingInput) (*fastly.RequestSetting, error) {
+	return &fastly.RequestSetting{
+		DefaultHost:    "www.example.com",
+		ForceSSL:       true,
+		Name:           i.Name,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		XForwardedFor:  fastly.RequestSettingXFFClear,
+	}, nil
+}
+
+var describeRequestSettingOutput = `
+Service ID: 123
+Version: 1
+Name: example
+Action: 
+X-Forwarded-For: clear
+Default host: www.example.com
+Hash keys: 
+Max stale age: 0
+Bypass busy wait: false
+Force miss: false
+Force SSL: true
+Geo headers: false
+Timer support: false
+Request condition: 
+`
+
+func updateRequestSettingOK(i *fastly.UpdateRequestSettingInput) (*fastly.RequestSetting, error) {
+	if i.XForwardedFor != fastly.RequestSettingXFFOverwrite {
+		return nil, testutil.Err
+	}
+	return &fastly.RequestSetting{
+		Name:           *i.NewName,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		XForwardedFor:  i.XForwardedFor,
+	}, nil
+}
@@ -0,0 +1,170 @@
+package requestsetting
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// UpdateCommand calls the Fastly API to update request settings.
+type UpdateCommand struct {
+	cmd.Base
+	manifest       manifest.Data
+	input          fastly.UpdateRequestSettingInput
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	autoClone      cmd.OptionalAutoClone
+
+	NewName          cmd.OptionalString
+	Action           cmd.OptionalString
+	XForwardedFor    cmd.OptionalString
+	DefaultHost      cmd.OptionalString
+	HashKeys         cmd.OptionalString
+	MaxStaleAge      cmd.OptionalInt
+	BypassBusyWait   cmd.OptionalBool
+	ForceMiss        cmd.OptionalBool
+	ForceSSL         cmd.OptionalBool
+	GeoHeaders       cmd.OptionalBool
+	TimerSupport     cmd.OptionalBool
+	RequestCondition cmd.OptionalString
+}
+
+// NewUpdateCommand returns a usable command registered under the parent.
+func NewUpdateCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *UpdateCommand {
+	c := UpdateCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+	c.CmdClause = parent.Command("update", "Update a request setting on a Fastly service version")
+
+	// required
+	c.CmdClause.Flag("name", "Request setting name").Short('n').Required().StringVar(&c.input.Name)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// optional
+	// NOTE: Actions and XFFs are defined in the same requestsetting package inside create.go
+	c.CmdClause.Flag("action", "Terminate request handling and immediately perform an action").Action(c.Action.Set).HintOptions(Actions...).EnumVar(&c.Action.Value, Actions...)
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("bypass-busy-wait", "Disable collapsed forwarding, so you don't wait for other objects to origin").Action(c.BypassBusyWait.Set).BoolVar(&c.BypassBusyWait.Value)
+	c.CmdClause.Flag("default-host", "Sets the host header").Action(c.DefaultHost.Set).StringVar(&c.DefaultHost.Value)
+	c.CmdClause.Flag("force-miss", "Force a cache miss for the request").Action(c.ForceMiss.Set).BoolVar(&c.ForceMiss.Value)
+	c.CmdClause.Flag("force-ssl", "Forces the request use SSL (redirects a non-SSL to SSL)").Action(c.ForceSSL.Set).BoolVar(&c.ForceSSL.Value)
+	c.CmdClause.Flag("geo-headers", "Injects Fastly-Geo-Country, Fastly-Geo-City, and Fastly-Geo-Region into the request headers").Action(c.GeoHeaders.Set).BoolVar(&c.GeoHeaders.Value)
+	c.CmdClause.Flag("hash-keys", "Comma separated list of varnish request object fields that should be in the hash key").Action(c.HashKeys.Set).StringVar(&c.HashKeys.Value)
+	c.CmdClause.Flag("max-stale-age", "How old an object is allowed to be to serve stale-if-error or stale-while-revalidate").Action(c.MaxStaleAge.Set).IntVar(&c.MaxStaleAge.Value)
+	c.CmdClause.Flag("new-name", "New request setting name").Action(c.NewName.Set).StringVar(&c.NewName.Value)
+	c.CmdClause.Flag("request-condition", "Condition, which if met, will select this request setting during a request").Action(c.RequestCondition.Set).StringVar(&c.RequestCondition.Value)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("timer-support", "Injects the X-Timer info into the request for viewing origin fetch durations").Action(c.TimerSupport.Set).BoolVar(&c.TimerSupport.Value)
+	c.CmdClause.Flag("xff", "X-Forwarded-For header behaviour").Action(c.XForwardedFor.Set).HintOptions(XFFs...).EnumVar(&c.XForwardedFor.Value, XFFs...)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *UpdateCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flags.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	c.input.ServiceID = serviceID
+	c.input.ServiceVersion = serviceVersion.Number
+
+	if c.NewName.WasSet {
+		c.input.NewName = &c.NewName.Value
+	}
+
+	if c.Action.WasSet {
+		c.input.Action = fastly.RequestSettingAction(c.Action.Value)
+	}
+
+	if c.XForwardedFor.WasSet {
+		c.input.XForwardedFor = fastly.RequestSettingXFF(c.XForwardedFor.Value)
+	}
+
+	if c.DefaultHost.WasSet {
+		c.input.DefaultHost = &c.DefaultHost.Value
+	}
+
+	if c.HashKeys.WasSet {
+		c.input.HashKeys = &c.HashKeys.Value
+	}
+
+	if c.MaxStaleAge.WasSet {
+		c.input.MaxStaleAge = &c.MaxStaleAge.Value
+	}
+
+	if c.BypassBusyWait.WasSet {
+		c.input.BypassBusyWait = fastly.CBool(c.BypassBusyWait.Value)
+	}
+
+	if c.ForceMiss.WasSet {
+		c.input.ForceMiss = fastly.CBool(c.ForceMiss.Value)
+	}
+
+	if c.ForceSSL.WasSet {
+		c.input.ForceSSL = fastly.CBool(c.ForceSSL.Value)
+	}
+
+	if c.GeoHeaders.WasSet {
+		c.input.GeoHeaders = fastly.CBool(c.GeoHeaders.Value)
+	}
+
+	if c.TimerSupport.WasSet {
+		c.input.TimerSupport = fastly.CBool(c.TimerSupport.Value)
+	}
+
+	if c.RequestCondition.WasSet {
+		c.input.RequestCondition = &c.RequestCondition.Value
+	}
+
+	s, err := c.Globals.APIClient.UpdateRequestSetting(&c.input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Updated request setting %s (service %s version %d)", s.Name, s.ServiceID, s.ServiceVersion)
+	return nil
+}
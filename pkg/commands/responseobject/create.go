@@ -0,0 +1,135 @@
+package responseobject
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// CreateCommand calls the Fastly API to create response objects.
+type CreateCommand struct {
+	cmd.Base
+	manifest manifest.Data
+
+	// required
+	serviceVersion cmd.OptionalServiceVersion
+
+	// optional
+	autoClone        cmd.OptionalAutoClone
+	cacheCondition   cmd.OptionalString
+	content          cmd.OptionalString
+	contentType      cmd.OptionalString
+	name             cmd.OptionalString
+	requestCondition cmd.OptionalString
+	response         cmd.OptionalString
+	serviceName      cmd.OptionalServiceNameID
+	status           cmd.OptionalInt
+}
+
+// NewCreateCommand returns a usable command registered under the parent.
+func NewCreateCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *CreateCommand {
+	c := CreateCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+	c.CmdClause = parent.Command("create", "Create a synthetic response object on a Fastly service version").Alias("add")
+
+	// required
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// optional
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("cache-condition", "Name of the cache condition controlling when this response object applies").Action(c.cacheCondition.Set).StringVar(&c.cacheCondition.Value)
+	c.CmdClause.Flag("content", "Content to deliver for the response object").Action(c.content.Set).StringVar(&c.content.Value)
+	c.CmdClause.Flag("content-type", "MIME type of the content").Action(c.contentType.Set).StringVar(&c.contentType.Value)
+	c.CmdClause.Flag("name", "Response object name").Short('n').Action(c.name.Set).StringVar(&c.name.Value)
+	c.CmdClause.Flag("request-condition", "Condition, which if met, will select this response object during a request").Action(c.requestCondition.Set).StringVar(&c.requestCondition.Value)
+	c.CmdClause.Flag("response", "HTTP response reason phrase (e.g. 'OK')").Action(c.response.Set).StringVar(&c.response.Value)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("status", "HTTP status code").Action(c.status.Set).IntVar(&c.status.Value)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *CreateCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flags.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+	input := fastly.CreateResponseObjectInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	}
+
+	if c.name.WasSet {
+		input.Name = &c.name.Value
+	}
+	if c.status.WasSet {
+		input.Status = &c.status.Value
+	}
+	if c.response.WasSet {
+		input.Response = &c.response.Value
+	}
+	if c.content.WasSet {
+		input.Content = &c.content.Value
+	}
+	if c.contentType.WasSet {
+		input.ContentType = &c.contentType.Value
+	}
+	if c.requestCondition.WasSet {
+		input.RequestCondition = &c.requestCondition.Value
+	}
+	if c.cacheCondition.WasSet {
+		input.CacheCondition = &c.cacheCondition.Value
+	}
+
+	r, err := c.Globals.APIClient.CreateResponseObject(&input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Created response object %s (service %s version %d)", r.Name, r.ServiceID, r.ServiceVersion)
+	return nil
+}
@@ -0,0 +1,2 @@
+// Package responseobject contains commands to inspect and manipulate Fastly service synthetic response objects.
+package responseobject
@@ -0,0 +1,325 @@
+package responseobject_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+func TestResponseObjectCreate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("response-object create --service-id 123 --name example"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name: "validate CreateResponseObject API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				CreateResponseObjectFn: func(i *fastly.CreateResponseObjectInput) (*fastly.ResponseObject, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("response-object create --service-id 123 --version 1 --name example --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate CreateResponseObject API success",
+			API: mock.API{
+				ListVersionsFn:         testutil.ListVersions,
+				CloneVersionFn:         testutil.CloneVersionResult(4),
+				CreateResponseObjectFn: createResponseObjectOK,
+			},
+			Args:       args("response-object create --service-id 123 --version 1 --name example --status 503 --response Unavailable --content-type text/plain --content maintenance --autoclone"),
+			WantOutput: "Created response object example (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestResponseObjectList(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate ListResponseObjects API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListResponseObjectsFn: func(i *fastly.ListResponseObjectsInput) ([]*fastly.ResponseObject, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("response-object list --service-id 123 --version 1"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate ListResponseObjects API success",
+			API: mock.API{
+				ListVersionsFn:        testutil.ListVersions,
+				ListResponseObjectsFn: listResponseObjectsOK,
+			},
+			Args:       args("response-object list --service-id 123 --version 1"),
+			WantOutput: listResponseObjectsShortOutput,
+		},
+		{
+			Name: "validate --verbose flag",
+			API: mock.API{
+				ListVersionsFn:        testutil.ListVersions,
+				ListResponseObjectsFn: listResponseObjectsOK,
+			},
+			Args:       args("response-object list --service-id 123 --version 1 --verbose"),
+			WantOutput: listResponseObjectsVerboseOutput,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestResponseObjectDescribe(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("response-object describe --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate GetResponseObject API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetResponseObjectFn: func(i *fastly.GetResponseObjectInput) (*fastly.ResponseObject, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("response-object describe --service-id 123 --version 1 --name example"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate GetResponseObject API success",
+			API: mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				GetResponseObjectFn: getResponseObjectOK,
+			},
+			Args:       args("response-object describe --service-id 123 --version 1 --name example"),
+			WantOutput: describeResponseObjectOutput,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestResponseObjectUpdate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("response-object update --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate UpdateResponseObject API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				UpdateResponseObjectFn: func(i *fastly.UpdateResponseObjectInput) (*fastly.ResponseObject, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("response-object update --service-id 123 --version 1 --name example --new-name renamed --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate UpdateResponseObject API success",
+			API: mock.API{
+				ListVersionsFn:         testutil.ListVersions,
+				CloneVersionFn:         testutil.CloneVersionResult(4),
+				UpdateResponseObjectFn: updateResponseObjectOK,
+			},
+			Args:       args("response-object update --service-id 123 --version 1 --name example --new-name renamed --status 404 --autoclone"),
+			WantOutput: "Updated response object renamed (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestResponseObjectDelete(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --name flag",
+			Args:      args("response-object delete --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --name not provided",
+		},
+		{
+			Name: "validate DeleteResponseObject API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				DeleteResponseObjectFn: func(i *fastly.DeleteResponseObjectInput) error {
+					return testutil.Err
+				},
+			},
+			Args:      args("response-object delete --service-id 123 --version 1 --name example --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate DeleteResponseObject API success",
+			API: mock.API{
+				ListVersionsFn:         testutil.ListVersions,
+				CloneVersionFn:         testutil.CloneVersionResult(4),
+				DeleteResponseObjectFn: func(i *fastly.DeleteResponseObjectInput) error { return nil },
+			},
+			Args:       args("response-object delete --service-id 123 --version 1 --name example --autoclone"),
+			WantOutput: "Deleted response object example (service 123 version 4)",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func createResponseObjectOK(i *fastly.CreateResponseObjectInput) (*fastly.ResponseObject, error) {
+	if i.Status == nil || *i.Status != 503 || *i.Content != "maintenance" {
+		return nil, testutil.Err
+	}
+	return &fastly.ResponseObject{
+		Content:        *i.Content,
+		ContentType:    *i.ContentType,
+		Name:           *i.Name,
+		Response:       *i.Response,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Status:         *i.Status,
+	}, nil
+}
+
+func listResponseObjectsOK(i *fastly.ListResponseObjectsInput) ([]*fastly.ResponseObject, error) {
+	return []*fastly.ResponseObject{
+		{
+			Content:          "maintenance",
+			ContentType:      "text/plain",
+			Name:             "maintenance",
+			RequestCondition: "is-maintenance",
+			Response:         "Service Unavailable",
+			ServiceID:        i.ServiceID,
+			ServiceVersion:   i.ServiceVersion,
+			Status:           503,
+		},
+		{
+			Name:           "robots",
+			Response:       "OK",
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+			Status:         200,
+		},
+	}, nil
+}
+
+var listResponseObjectsShortOutput = strings.Join([]string{
+	"SERVICE  VERSION  NAME         STATUS  RESPONSE             CONTENT TYPE",
+	"123      1        maintenance  503     Service Unavailable  text/plain",
+	"123      1        robots       200     OK",
+}, "\n")
+
+var listResponseObjectsVerboseOutput = strings.Join([]string{
+	"Version: 1",
+	"\tResponse object 1/2",
+	"\t\tName: maintenance",
+	"\t\tStatus: 503",
+	"\t\tResponse: Service Unavailable",
+	"\t\tContent type: text/plain",
+	"\t\tContent: maintenance",
+	"\t\tRequest condition: is-maintenance",
+	"\t\tCache condition: ",
+	"\tResponse object 2/2",
+	"\t\tName: robots",
+}, "\n")
+
+func getResponseObjectOK(i *fastly.GetResponseObjectInput) (*fastly.ResponseObject, error) {
+	return &fastly.ResponseObject{
+		Content:        "User-agent: *",
+		ContentType:    "text/plain",
+		Name:           i.Name,
+		Response:       "OK",
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Status:         200,
+	}, nil
+}
+
+var describeResponseObjectOutput = `
+Service ID: 123
+Version: 1
+Name: example
+Status: 200
+Response: OK
+Content type: text/plain
+Content: User-agent: *
+Request condition: 
+Cache condition: 
+`
+
+func updateResponseObjectOK(i *fastly.UpdateResponseObjectInput) (*fastly.ResponseObject, error) {
+	if i.Status == nil || *i.Status != 404 {
+		return nil, testutil.Err
+	}
+	return &fastly.ResponseObject{
+		Name:           *i.NewName,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+		Status:         *i.Status,
+	}, nil
+}
@@ -0,0 +1,139 @@
+package responseobject
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// UpdateCommand calls the Fastly API to update response objects.
+type UpdateCommand struct {
+	cmd.Base
+	manifest       manifest.Data
+	input          fastly.UpdateResponseObjectInput
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	autoClone      cmd.OptionalAutoClone
+
+	NewName          cmd.OptionalString
+	Status           cmd.OptionalInt
+	Response         cmd.OptionalString
+	Content          cmd.OptionalString
+	ContentType      cmd.OptionalString
+	RequestCondition cmd.OptionalString
+	CacheCondition   cmd.OptionalString
+}
+
+// NewUpdateCommand returns a usable command registered under the parent.
+func NewUpdateCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *UpdateCommand {
+	c := UpdateCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+	c.CmdClause = parent.Command("update", "Update a synthetic response object on a Fastly service version")
+
+	// required
+	c.CmdClause.Flag("name", "Response object name").Short('n').Required().StringVar(&c.input.Name)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// optional
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("cache-condition", "Name of the cache condition controlling when this response object applies").Action(c.CacheCondition.Set).StringVar(&c.CacheCondition.Value)
+	c.CmdClause.Flag("content", "Content to deliver for the response object").Action(c.Content.Set).StringVar(&c.Content.Value)
+	c.CmdClause.Flag("content-type", "MIME type of the content").Action(c.ContentType.Set).StringVar(&c.ContentType.Value)
+	c.CmdClause.Flag("new-name", "New response object name").Action(c.NewName.Set).StringVar(&c.NewName.Value)
+	c.CmdClause.Flag("request-condition", "Condition, which if met, will select this response object during a request").Action(c.RequestCondition.Set).StringVar(&c.RequestCondition.Value)
+	c.CmdClause.Flag("response", "HTTP response reason phrase (e.g. 'OK')").Action(c.Response.Set).StringVar(&c.Response.Value)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("status", "HTTP status code").Action(c.Status.Set).IntVar(&c.Status.Value)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *UpdateCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flags.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	c.input.ServiceID = serviceID
+	c.input.ServiceVersion = serviceVersion.Number
+
+	if c.NewName.WasSet {
+		c.input.NewName = &c.NewName.Value
+	}
+
+	if c.Status.WasSet {
+		c.input.Status = &c.Status.Value
+	}
+
+	if c.Response.WasSet {
+		c.input.Response = &c.Response.Value
+	}
+
+	if c.Content.WasSet {
+		c.input.Content = &c.Content.Value
+	}
+
+	if c.ContentType.WasSet {
+		c.input.ContentType = &c.ContentType.Value
+	}
+
+	if c.RequestCondition.WasSet {
+		c.input.RequestCondition = &c.RequestCondition.Value
+	}
+
+	if c.CacheCondition.WasSet {
+		c.input.CacheCondition = &c.CacheCondition.Value
+	}
+
+	r, err := c.Globals.APIClient.UpdateResponseObject(&c.input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Updated response object %s (service %s version %d)", r.Name, r.ServiceID, r.ServiceVersion)
+	return nil
+}
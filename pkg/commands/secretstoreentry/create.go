@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 
+	"github.com/fastly/cli/pkg/api"
 	"github.com/fastly/cli/pkg/cmd"
 	fsterr "github.com/fastly/cli/pkg/errors"
 	"github.com/fastly/cli/pkg/global"
@@ -130,30 +131,12 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 		return errMaxSecretLength
 	}
 
-	ck, err := c.Globals.APIClient.CreateClientKey()
+	ck, err := clientKey(c.Globals.APIClient)
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
 		return err
 	}
 
-	sk, err := c.Globals.APIClient.GetSigningKey()
-	if err != nil {
-		c.Globals.ErrLog.Add(err)
-		return err
-	}
-
-	if !bytes.Equal(sk, signingKey) && os.Getenv("FASTLY_USE_API_SIGNING_KEY") == "" {
-		err := fmt.Errorf("API signing key does not match expected value")
-		c.Globals.ErrLog.Add(err)
-		return err
-	}
-
-	if !ck.VerifySignature(sk) {
-		err := fmt.Errorf("unable to validate signature of client key")
-		c.Globals.ErrLog.Add(err)
-		return err
-	}
-
 	wrapped, err := ck.Encrypt(c.Input.Secret)
 	if err != nil {
 		c.Globals.ErrLog.Add(err)
@@ -178,3 +161,27 @@ func (c *CreateCommand) Exec(in io.Reader, out io.Writer) error {
 
 	return nil
 }
+
+// clientKey returns a client key for encrypting secrets, once its signature
+// has been verified with the signing key.
+func clientKey(client api.Interface) (*fastly.ClientKey, error) {
+	ck, err := client.CreateClientKey()
+	if err != nil {
+		return nil, err
+	}
+
+	sk, err := client.GetSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(sk, signingKey) && os.Getenv("FASTLY_USE_API_SIGNING_KEY") == "" {
+		return nil, fmt.Errorf("API signing key does not match expected value")
+	}
+
+	if !ck.VerifySignature(sk) {
+		return nil, fmt.Errorf("unable to validate signature of client key")
+	}
+
+	return ck, nil
+}
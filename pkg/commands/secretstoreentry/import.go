@@ -0,0 +1,386 @@
+package secretstoreentry
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+const (
+	formatDotenv = "dotenv"
+	formatJSON   = "json"
+)
+
+// The status of an imported secret.
+const (
+	importCreated   = "created"
+	importRotated   = "rotated"
+	importSkipped   = "skipped"
+	importUnchanged = "unchanged"
+)
+
+// NewImportCommand returns a usable command registered under the parent.
+func NewImportCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *ImportCommand {
+	c := ImportCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+
+	c.CmdClause = parent.Command("import", "Create secrets from a dotenv file or JSON object within specified store")
+
+	// Required.
+	c.RegisterFlag(cmd.StoreIDFlag(&c.storeID)) // --store-id
+
+	// Optional.
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        "file",
+		Short:       'f',
+		Description: "Read secrets from a dotenv (NAME=value) or JSON ({\"NAME\": \"value\"}) file",
+		Dst:         &c.file,
+	})
+	c.CmdClause.Flag("format", "Format of the secrets (default: json for a .json file, otherwise dotenv)").HintOptions(formatDotenv, formatJSON).EnumVar(&c.format, formatDotenv, formatJSON)
+	c.RegisterFlagBool(c.JSONFlag()) // --json
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        "rotate",
+		Description: "Replace secrets that already exist in the store, reporting those whose digest changed",
+		Dst:         &c.rotate,
+	})
+	c.RegisterFlagBool(cmd.BoolFlagOpts{
+		Name:        "stdin",
+		Description: "Read secrets from STDIN",
+		Dst:         &c.stdin,
+	})
+
+	return &c
+}
+
+// ImportCommand calls the Fastly API to create many secrets.
+type ImportCommand struct {
+	cmd.Base
+	cmd.JSONOutput
+
+	file     string
+	format   string
+	manifest manifest.Data
+	rotate   bool
+	stdin    bool
+	storeID  string
+}
+
+// ImportResult is the outcome of importing a single secret.
+//
+// NOTE: Only the digests are reported, never the secret values.
+type ImportResult struct {
+	Name           string `json:"name"`
+	Status         string `json:"status"`
+	Digest         string `json:"digest,omitempty"`
+	PreviousDigest string `json:"previous_digest,omitempty"`
+}
+
+var errNoSecretsSource = fsterr.RemediationError{
+	Inner:       fmt.Errorf("no secrets provided"),
+	Remediation: "Use one of --file or --stdin flag",
+}
+
+var errNoSecretValue = fsterr.RemediationError{
+	Inner:       fmt.Errorf("empty secret value"),
+	Remediation: "Provide a value for every secret, or remove the secrets without one",
+}
+
+// Exec invokes the application logic for the command.
+func (c *ImportCommand) Exec(in io.Reader, out io.Writer) error {
+	if c.Globals.Verbose() && c.JSONOutput.Enabled {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+	if c.file != "" && c.stdin {
+		return errMultipleSecretValue
+	}
+
+	var data []byte
+	switch {
+	case c.stdin:
+		// Determine if 'in' has data available.
+		if in == nil || text.IsTTY(in) {
+			return errNoSTDINData
+		}
+		var buf bytes.Buffer
+		if _, err := buf.ReadFrom(in); err != nil {
+			return err
+		}
+		data = buf.Bytes()
+
+	case c.file != "":
+		var err error
+		// gosec flagged this:
+		// G304 (CWE-22): Potential file inclusion via variable
+		// Disabling as we trust the source of the filepath variable.
+		/* #nosec */
+		if data, err = os.ReadFile(c.file); err != nil {
+			return err
+		}
+		if c.format == "" && strings.EqualFold(filepath.Ext(c.file), ".json") {
+			c.format = formatJSON
+		}
+
+	default:
+		return errNoSecretsSource
+	}
+
+	secrets, err := parseSecrets(data, c.format)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	names := make([]string, 0, len(secrets))
+	for name := range secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// NOTE: Every value is validated before any secret is changed, as rotating
+	// a secret deletes it before it's recreated.
+	for _, name := range names {
+		if secrets[name] == "" {
+			return fmt.Errorf("secret '%s': %w", name, errNoSecretValue)
+		}
+		if len(secrets[name]) > maxSecretLen {
+			return fmt.Errorf("secret '%s': %w", name, errMaxSecretLength)
+		}
+	}
+
+	existing, err := c.existingSecrets()
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	ck, err := clientKey(c.Globals.APIClient)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	results := make([]ImportResult, 0, len(names))
+	for _, name := range names {
+		r, err := c.importSecret(ck, name, secrets[name], existing)
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"Store ID": c.storeID,
+				"Name":     name,
+			})
+			if !c.JSONOutput.Enabled {
+				printImportResults(out, results)
+			}
+			return err
+		}
+		results = append(results, r)
+	}
+
+	if ok, err := c.WriteJSON(out, results); ok {
+		return err
+	}
+
+	printImportResults(out, results)
+
+	var skipped int
+	for _, r := range results {
+		if r.Status == importSkipped {
+			skipped++
+		}
+	}
+	text.Success(out, "Imported %d secrets into store %s", len(results)-skipped, c.storeID)
+	if skipped > 0 {
+		text.Info(out, "%d secrets already exist in the store, use --rotate to replace them", skipped)
+	}
+	return nil
+}
+
+// existingSecrets returns the digest of each secret in the store.
+func (c *ImportCommand) existingSecrets() (map[string][]byte, error) {
+	digests := make(map[string][]byte)
+	input := fastly.ListSecretsInput{ID: c.storeID}
+	for {
+		o, err := c.Globals.APIClient.ListSecrets(&input)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range o.Data {
+			digests[s.Name] = s.Digest
+		}
+		if input.Cursor = o.Meta.NextCursor; input.Cursor == "" {
+			return digests, nil
+		}
+	}
+}
+
+// importSecret creates the secret, first deleting an existing secret of the
+// same name when rotating.
+//
+// NOTE: The API doesn't support replacing a secret, so a secret is briefly
+// missing from the store while it's rotated.
+func (c *ImportCommand) importSecret(ck *fastly.ClientKey, name, value string, existing map[string][]byte) (ImportResult, error) {
+	r := ImportResult{Name: name, Status: importCreated}
+
+	previous, ok := existing[name]
+	if ok {
+		r.PreviousDigest = hex.EncodeToString(previous)
+		if !c.rotate {
+			r.Status = importSkipped
+			r.Digest = r.PreviousDigest
+			return r, nil
+		}
+	}
+
+	wrapped, err := ck.Encrypt([]byte(value))
+	if err != nil {
+		return r, err
+	}
+
+	if ok {
+		err := c.Globals.APIClient.DeleteSecret(&fastly.DeleteSecretInput{
+			ID:   c.storeID,
+			Name: name,
+		})
+		if err != nil {
+			return r, fmt.Errorf("error deleting secret '%s' for rotation: %w", name, err)
+		}
+	}
+
+	o, err := c.Globals.APIClient.CreateSecret(&fastly.CreateSecretInput{
+		ID:        c.storeID,
+		Name:      name,
+		Secret:    wrapped,
+		ClientKey: ck.PublicKey,
+	})
+	if err != nil {
+		if ok {
+			return r, fmt.Errorf("error creating secret '%s', it was deleted for rotation but not recreated: %w", name, err)
+		}
+		return r, fmt.Errorf("error creating secret '%s': %w", name, err)
+	}
+
+	r.Digest = hex.EncodeToString(o.Digest)
+	if ok {
+		r.Status = importRotated
+		if bytes.Equal(o.Digest, previous) {
+			r.Status = importUnchanged
+		}
+	}
+	return r, nil
+}
+
+// printImportResults displays the status and digest of each imported secret.
+func printImportResults(out io.Writer, results []ImportResult) {
+	tbl := text.NewTable(out)
+	tbl.AddHeader("Name", "Status", "Digest")
+	for _, r := range results {
+		tbl.AddLine(r.Name, r.Status, r.Digest)
+	}
+	tbl.Print()
+}
+
+// parseSecrets decodes the secrets, which are either a JSON object of string
+// values or dotenv formatted.
+func parseSecrets(data []byte, format string) (map[string]string, error) {
+	if format == formatJSON {
+		var secrets map[string]string
+		if err := json.Unmarshal(data, &secrets); err != nil {
+			return nil, fmt.Errorf("error decoding JSON secrets, expected an object of string values: %w", err)
+		}
+		return secrets, nil
+	}
+	return parseDotenv(data)
+}
+
+// parseDotenv decodes NAME=value lines, ignoring blank lines and comments.
+//
+// NOTE: An optional 'export' prefix is ignored. Double quoted values support
+// Go escape sequences (e.g. \n), single quoted values are used verbatim. A
+// comment may follow a value, separated by whitespace when unquoted. A name
+// may only be defined once.
+func parseDotenv(data []byte) (map[string]string, error) {
+	secrets := make(map[string]string)
+	lines := make(map[string]int)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxSecretLen*2)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("error decoding dotenv line %d: expected NAME=value", n)
+		}
+
+		if first, ok := lines[name]; ok {
+			return nil, fmt.Errorf("error decoding dotenv line %d: duplicate name '%s' (first defined on line %d)", n, name, first)
+		}
+		lines[name] = n
+
+		value, err := dotenvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("error decoding dotenv line %d: %w", n, err)
+		}
+		secrets[name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error decoding dotenv: %w", err)
+	}
+	return secrets, nil
+}
+
+// dotenvValue decodes a dotenv value, removing its quotes or trailing comment.
+func dotenvValue(value string) (string, error) {
+	var quoted, rest string
+	switch {
+	case strings.HasPrefix(value, `"`):
+		prefix, err := strconv.QuotedPrefix(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid quoted value")
+		}
+		if quoted, err = strconv.Unquote(prefix); err != nil {
+			return "", fmt.Errorf("invalid quoted value")
+		}
+		rest = value[len(prefix):]
+	case strings.HasPrefix(value, "'"):
+		end := strings.IndexByte(value[1:], '\'')
+		if end == -1 {
+			return "", fmt.Errorf("invalid quoted value")
+		}
+		quoted, rest = value[1:end+1], value[end+2:]
+	default:
+		for i := range value {
+			if value[i] == '#' && (i == 0 || value[i-1] == ' ' || value[i-1] == '\t') {
+				return strings.TrimSpace(value[:i]), nil
+			}
+		}
+		return value, nil
+	}
+
+	if rest = strings.TrimSpace(rest); rest != "" && !strings.HasPrefix(rest, "#") {
+		return "", fmt.Errorf("unexpected characters after quoted value")
+	}
+	return quoted, nil
+}
@@ -473,3 +473,198 @@ func TestListSecretsCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestImportSecretsCommand(t *testing.T) {
+	const storeID = "store123"
+
+	tmpDir := t.TempDir()
+	dotenvFile := path.Join(tmpDir, "secrets.env")
+	dotenv := `# API keys
+NEW_KEY=new-value
+export EXISTING_KEY="rotated-value"
+`
+	if err := os.WriteFile(dotenvFile, []byte(dotenv), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	jsonFile := path.Join(tmpDir, "secrets.json")
+	if err := os.WriteFile(jsonFile, []byte(`{"EXISTING_KEY": "old-value"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	invalidFile := path.Join(tmpDir, "invalid.env")
+	if err := os.WriteFile(invalidFile, []byte("NO_VALUE\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	duplicateFile := path.Join(tmpDir, "duplicate.env")
+	duplicate := `NEW_KEY=new-value
+# overridden below
+NEW_KEY=other-value
+`
+	if err := os.WriteFile(duplicateFile, []byte(duplicate), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	commentsFile := path.Join(tmpDir, "comments.env")
+	comments := `UNQUOTED=new-value # the new value
+QUOTED="new-value" # the new value
+HASH=new#value
+`
+	if err := os.WriteFile(commentsFile, []byte(comments), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	emptyFile := path.Join(tmpDir, "empty.env")
+	empty := `NEW_KEY=new-value
+EXISTING_KEY= # to be rotated
+`
+	if err := os.WriteFile(emptyFile, []byte(empty), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ckPub, ckPriv, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	skPub, skPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ck := &fastly.ClientKey{
+		PublicKey: ckPub[:],
+		Signature: ed25519.Sign(skPriv, ckPub[:]),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	// The digest of a secret is derived from its value, so that recreating a
+	// secret with the same value results in the same digest.
+	digest := func(value string) []byte {
+		return []byte("digest-" + value)
+	}
+
+	scenarios := []struct {
+		args        string
+		stdin       string
+		wantError   string
+		wantOutput  []string
+		wantDeletes []string
+		wantCreates []string
+	}{
+		{
+			args:      "import --store-id " + storeID,
+			wantError: "no secrets provided",
+		},
+		{
+			args:      fmt.Sprintf("import --store-id %s --file %s", storeID, invalidFile),
+			wantError: "error decoding dotenv line 1: expected NAME=value",
+		},
+		{
+			args:      fmt.Sprintf("import --store-id %s --file %s", storeID, duplicateFile),
+			wantError: "error decoding dotenv line 3: duplicate name 'NEW_KEY' (first defined on line 1)",
+		},
+		{
+			args: fmt.Sprintf("import --store-id %s --file %s", storeID, dotenvFile),
+			wantOutput: []string{
+				"NEW_KEY       created  " + hex.EncodeToString(digest("new-value")),
+				"EXISTING_KEY  skipped  " + hex.EncodeToString(digest("old-value")),
+				"Imported 1 secrets into store store123",
+				"1 secrets already exist in the store, use --rotate to replace them",
+			},
+			wantCreates: []string{"NEW_KEY"},
+		},
+		{
+			args: fmt.Sprintf("import --store-id %s --file %s --rotate", storeID, dotenvFile),
+			wantOutput: []string{
+				"EXISTING_KEY  rotated  " + hex.EncodeToString(digest("rotated-value")),
+				"Imported 2 secrets into store store123",
+			},
+			wantDeletes: []string{"EXISTING_KEY"},
+			wantCreates: []string{"EXISTING_KEY", "NEW_KEY"},
+		},
+		{
+			args: fmt.Sprintf("import --store-id %s --file %s", storeID, commentsFile),
+			wantOutput: []string{
+				"HASH      created  " + hex.EncodeToString(digest("new#value")),
+				"QUOTED    created  " + hex.EncodeToString(digest("new-value")),
+				"UNQUOTED  created  " + hex.EncodeToString(digest("new-value")),
+			},
+			wantCreates: []string{"HASH", "QUOTED", "UNQUOTED"},
+		},
+		{
+			args:      fmt.Sprintf("import --store-id %s --file %s --rotate", storeID, emptyFile),
+			wantError: "secret 'EXISTING_KEY': empty secret value",
+		},
+		{
+			args: fmt.Sprintf("import --store-id %s --file %s --rotate", storeID, jsonFile),
+			wantOutput: []string{
+				"EXISTING_KEY  unchanged  " + hex.EncodeToString(digest("old-value")),
+			},
+			wantDeletes: []string{"EXISTING_KEY"},
+			wantCreates: []string{"EXISTING_KEY"},
+		},
+		{
+			args:  fmt.Sprintf("import --store-id %s --stdin --format json --rotate --json", storeID),
+			stdin: `{"EXISTING_KEY": "rotated-value"}`,
+			wantOutput: []string{
+				fmt.Sprintf(`"previous_digest": %q`, hex.EncodeToString(digest("old-value"))),
+				`"status": "rotated"`,
+			},
+			wantDeletes: []string{"EXISTING_KEY"},
+			wantCreates: []string{"EXISTING_KEY"},
+		},
+	}
+
+	for _, testcase := range scenarios {
+		testcase := testcase
+		t.Run(testcase.args, func(t *testing.T) {
+			var deletes, creates []string
+			api := mock.API{
+				CreateClientKeyFn: func() (*fastly.ClientKey, error) { return ck, nil },
+				GetSigningKeyFn:   func() (ed25519.PublicKey, error) { return skPub, nil },
+				ListSecretsFn: func(i *fastly.ListSecretsInput) (*fastly.Secrets, error) {
+					return &fastly.Secrets{
+						Data: []fastly.Secret{{Name: "EXISTING_KEY", Digest: digest("old-value")}},
+					}, nil
+				},
+				DeleteSecretFn: func(i *fastly.DeleteSecretInput) error {
+					deletes = append(deletes, i.Name)
+					return nil
+				},
+				CreateSecretFn: func(i *fastly.CreateSecretInput) (*fastly.Secret, error) {
+					plaintext, ok := box.OpenAnonymous(nil, i.Secret, ckPub, ckPriv)
+					if !ok {
+						return nil, errors.New("failed to decrypt")
+					}
+					creates = append(creates, i.Name)
+					return &fastly.Secret{
+						Name:   i.Name,
+						Digest: digest(string(plaintext)),
+					}, nil
+				},
+			}
+
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testutil.Args(secretstoreentry.RootNameSecret+" "+testcase.args), &stdout)
+			if testcase.stdin != "" {
+				var stdin bytes.Buffer
+				stdin.WriteString(testcase.stdin)
+				opts.Stdin = &stdin
+			}
+			opts.APIClient = mock.APIClient(api)
+
+			// Tests generate their own signing keys, which won't match
+			// the hardcoded value.  Disable the check against the
+			// hardcoded value.
+			t.Setenv("FASTLY_USE_API_SIGNING_KEY", "1")
+
+			err := app.Run(opts)
+
+			testutil.AssertErrorContains(t, err, testcase.wantError)
+			for _, s := range testcase.wantOutput {
+				testutil.AssertStringContains(t, stdout.String(), s)
+			}
+			// The secret values must never be displayed.
+			for _, value := range []string{"new-value", "rotated-value", "old-value"} {
+				testutil.AssertStringDoesntContain(t, stdout.String(), value)
+			}
+			testutil.AssertEqual(t, testcase.wantDeletes, deletes)
+			testutil.AssertEqual(t, testcase.wantCreates, creates)
+		})
+	}
+}
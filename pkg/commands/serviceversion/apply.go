@@ -0,0 +1,270 @@
+package serviceversion
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// ApplyCommand reconciles a service version with a document produced by the
+// export command.
+type ApplyCommand struct {
+	cmd.Base
+
+	activate       bool
+	autoClone      cmd.OptionalAutoClone
+	file           string
+	format         string
+	manifest       manifest.Data
+	prune          bool
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// NewApplyCommand returns a usable command registered under the parent.
+func NewApplyCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *ApplyCommand {
+	var c ApplyCommand
+	c.Globals = g
+	c.manifest = m
+	c.CmdClause = parent.Command("apply", "Create, update and delete the resources of a Fastly service version to match a TOML or JSON document")
+
+	// Required.
+	c.CmdClause.Flag("file", "Path to the document to apply").Short('f').Required().StringVar(&c.file)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional.
+	c.CmdClause.Flag("activate", "Activate the service version once the document has been applied").BoolVar(&c.activate)
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("format", "Document format (inferred from the --file extension if not set)").HintOptions(DocumentFormats...).EnumVar(&c.format, DocumentFormats...)
+	c.CmdClause.Flag("prune", "Delete the resources of kinds missing from the document (by default only the kinds in the document are reconciled)").BoolVar(&c.prune)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ApplyCommand) Exec(in io.Reader, out io.Writer) error {
+	format := c.format
+	if format == "" {
+		format = FormatFromPath(c.file)
+	}
+
+	data, err := os.ReadFile(c.file)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error reading service version document: %w", err)
+	}
+	doc, err := DecodeDocument(data, format)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error decoding service version document: %w", err)
+	}
+	if err := validateKinds(doc.Resources); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flags.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	current, err := FetchResources(c.Globals.APIClient, serviceID, serviceVersion.Number)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	if !c.prune {
+		current = scopeResources(current, doc.Resources)
+	}
+
+	changes := DiffResources(current, doc.Resources)
+	if len(changes) == 0 {
+		text.Info(out, "Service %s version %d already matches %s", serviceID, serviceVersion.Number, c.file)
+	}
+
+	cont, err := c.confirmRemovals(changes, in, out)
+	if err != nil {
+		return err
+	}
+	if !cont {
+		return nil
+	}
+
+	if err := applyChanges(c.Globals.APIClient, serviceID, serviceVersion.Number, changes, out); err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	if len(changes) > 0 {
+		added, removed, changed := ChangeCounts(changes)
+		text.Success(out, "Applied %s to service %s version %d (%d added, %d removed, %d changed)", c.file, serviceID, serviceVersion.Number, added, removed, changed)
+	}
+
+	if !c.activate {
+		return nil
+	}
+
+	_, err = c.Globals.APIClient.ActivateVersion(&fastly.ActivateVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return fmt.Errorf("error activating service version %d: %w", serviceVersion.Number, err)
+	}
+
+	text.Success(out, "Activated service %s version %d", serviceID, serviceVersion.Number)
+	return nil
+}
+
+// confirmRemovals lists the resources that will be deleted and prompts the
+// user to confirm the deletion, unless --auto-yes is set.
+func (c *ApplyCommand) confirmRemovals(changes []Change, in io.Reader, out io.Writer) (bool, error) {
+	_, removed, _ := ChangeCounts(changes)
+	if removed == 0 || c.Globals.Flags.AutoYes {
+		return true, nil
+	}
+	if c.Globals.Flags.NonInteractive {
+		return false, fsterr.RemediationError{
+			Inner:       fmt.Errorf("applying %s would delete %d resources", c.file, removed),
+			Remediation: "Use the --auto-yes flag to confirm the deletion of resources.",
+		}
+	}
+
+	text.Warning(out, "The following resources will be deleted:")
+	text.Break(out)
+	for _, ch := range changes {
+		if ch.Action == ChangeRemoved {
+			text.Output(out, "  - %s '%s'", ch.Kind, ch.Name)
+		}
+	}
+	text.Break(out)
+	label := fmt.Sprintf("Delete %d resources? [y/N] ", removed)
+	return text.AskYesNo(out, text.BoldYellow(label), in)
+}
+
+// scopeResources limits the current resources to the kinds present in the
+// document, so that a partial document doesn't delete every resource of the
+// kinds it omits.
+func scopeResources(current, doc Resources) Resources {
+	scoped := make(Resources, len(doc))
+	for kind := range doc {
+		scoped[kind] = current[kind]
+	}
+	return scoped
+}
+
+// validateKinds ensures every resource kind in a document is one that can be
+// reconciled.
+func validateKinds(r Resources) error {
+	for kind := range r {
+		if _, ok := lookupKind(kind); !ok {
+			return fsterr.RemediationError{
+				Inner:       fmt.Errorf("unsupported resource kind '%s'", kind),
+				Remediation: "Generate the document using the `fastly service-version export` command.",
+			}
+		}
+	}
+	return nil
+}
+
+// lookupKind returns the resource kind with the given name.
+func lookupKind(kind string) (resourceKind, bool) {
+	for _, rk := range resourceKinds {
+		if rk.kind == kind {
+			return rk, true
+		}
+	}
+	return resourceKind{}, false
+}
+
+// applyChanges makes the API calls needed to apply the given changes to a
+// service version.
+//
+// NOTE: Resources are created and updated in the order of resourceKinds, and
+// deleted in the reverse order, so that resources referenced by others (e.g.
+// health checks used by backends) exist for as long as they're needed.
+func applyChanges(client api.Interface, serviceID string, serviceVersion int, changes []Change, out io.Writer) error {
+	for i := len(resourceKinds) - 1; i >= 0; i-- {
+		rk := resourceKinds[i]
+		for _, ch := range changes {
+			if ch.Kind != rk.kind || ch.Action != ChangeRemoved {
+				continue
+			}
+			if err := rk.ops.delete(client, serviceID, serviceVersion, ch.Name); err != nil {
+				return fmt.Errorf("error deleting %s '%s': %w", ch.Kind, ch.Name, err)
+			}
+			text.Output(out, "Deleted %s '%s'", ch.Kind, ch.Name)
+		}
+	}
+
+	for _, rk := range resourceKinds {
+		for _, ch := range changes {
+			if ch.Kind != rk.kind {
+				continue
+			}
+			switch ch.Action {
+			case ChangeAdded:
+				if err := rk.ops.create(client, serviceID, serviceVersion, ch.To); err != nil {
+					return fmt.Errorf("error creating %s '%s': %w", ch.Kind, ch.Name, err)
+				}
+				text.Output(out, "Created %s '%s'", ch.Kind, ch.Name)
+			case ChangeChanged:
+				if err := rk.ops.update(client, serviceID, serviceVersion, ch.Name, changedFields(ch.From, ch.To)); err != nil {
+					return fmt.Errorf("error updating %s '%s': %w", ch.Kind, ch.Name, err)
+				}
+				text.Output(out, "Updated %s '%s'", ch.Kind, ch.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,421 @@
+package serviceversion
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// DiffCommand compares the versioned resources of two service versions.
+type DiffCommand struct {
+	cmd.Base
+	cmd.JSONOutput
+
+	manifest    manifest.Data
+	from        cmd.OptionalServiceVersion
+	serviceName cmd.OptionalServiceNameID
+	to          cmd.OptionalServiceVersion
+}
+
+// NewDiffCommand returns a usable command registered under the parent.
+func NewDiffCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *DiffCommand {
+	var c DiffCommand
+	c.Globals = g
+	c.manifest = m
+	c.CmdClause = parent.Command("diff", "Show the differences between the resources of two Fastly service versions")
+
+	// Required.
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        "from",
+		Description: "The service version to compare from ('latest', 'active', or a version number)",
+		Dst:         &c.from.Value,
+		Required:    true,
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        "to",
+		Description: "The service version to compare to ('latest', 'active', or a version number)",
+		Dst:         &c.to.Value,
+		Required:    true,
+	})
+
+	// Optional.
+	c.RegisterFlagBool(c.JSONFlag()) // --json
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *DiffCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.Globals.Verbose() && c.JSONOutput.Enabled {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	if err != nil {
+		return err
+	}
+	if c.Globals.Verbose() {
+		cmd.DisplayServiceID(serviceID, flag, source, out)
+	}
+
+	fromVersion, err := c.from.Parse(serviceID, c.Globals.APIClient)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID": serviceID,
+			"From":       c.from.Value,
+		})
+		return err
+	}
+	toVersion, err := c.to.Parse(serviceID, c.Globals.APIClient)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID": serviceID,
+			"To":         c.to.Value,
+		})
+		return err
+	}
+
+	fromResources, err := FetchResources(c.Globals.APIClient, serviceID, fromVersion.Number)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": fromVersion.Number,
+		})
+		return err
+	}
+	toResources, err := FetchResources(c.Globals.APIClient, serviceID, toVersion.Number)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": toVersion.Number,
+		})
+		return err
+	}
+
+	changes := DiffResources(fromResources, toResources)
+
+	if ok, err := c.WriteJSON(out, VersionDiff{
+		ServiceID: serviceID,
+		From:      fromVersion.Number,
+		To:        toVersion.Number,
+		Changes:   changes,
+	}); ok {
+		return err
+	}
+
+	if len(changes) == 0 {
+		text.Info(out, "No differences found between service %s version %d and version %d", serviceID, fromVersion.Number, toVersion.Number)
+		return nil
+	}
+
+	for _, ch := range changes {
+		fmt.Fprintf(out, "--- %s/%s (version %d)\n", ch.Kind, ch.Name, fromVersion.Number)
+		fmt.Fprintf(out, "+++ %s/%s (version %d)\n", ch.Kind, ch.Name, toVersion.Number)
+		fmt.Fprint(out, unifiedDiff(renderResource(ch.From), renderResource(ch.To)))
+	}
+
+	added, removed, changed := ChangeCounts(changes)
+	text.Break(out)
+	text.Info(out, "%d added, %d removed, %d changed", added, removed, changed)
+	return nil
+}
+
+// VersionDiff is the machine-readable set of changes between two service
+// versions.
+type VersionDiff struct {
+	ServiceID string   `json:"service_id"`
+	From      int      `json:"from"`
+	To        int      `json:"to"`
+	Changes   []Change `json:"changes"`
+}
+
+// Change actions.
+const (
+	ChangeAdded   = "added"
+	ChangeChanged = "changed"
+	ChangeRemoved = "removed"
+)
+
+// Change describes a single resource that differs between two sets of
+// resources.
+type Change struct {
+	Action string         `json:"action"`
+	Kind   string         `json:"kind"`
+	Name   string         `json:"name"`
+	From   map[string]any `json:"from,omitempty"`
+	To     map[string]any `json:"to,omitempty"`
+}
+
+// DiffResources returns the resources that were added, removed or changed
+// going from one set of resources to another, ordered by kind and name.
+func DiffResources(from, to Resources) []Change {
+	kinds := map[string]bool{}
+	for k := range from {
+		kinds[k] = true
+	}
+	for k := range to {
+		kinds[k] = true
+	}
+	sortedKinds := make([]string, 0, len(kinds))
+	for k := range kinds {
+		sortedKinds = append(sortedKinds, k)
+	}
+	sort.Strings(sortedKinds)
+
+	changes := []Change{}
+	for _, kind := range sortedKinds {
+		names := map[string]bool{}
+		for n := range from[kind] {
+			names[n] = true
+		}
+		for n := range to[kind] {
+			names[n] = true
+		}
+		sortedNames := make([]string, 0, len(names))
+		for n := range names {
+			sortedNames = append(sortedNames, n)
+		}
+		sort.Strings(sortedNames)
+
+		for _, name := range sortedNames {
+			f, inFrom := from[kind][name]
+			t, inTo := to[kind][name]
+			switch {
+			case !inFrom:
+				changes = append(changes, Change{Action: ChangeAdded, Kind: kind, Name: name, To: t})
+			case !inTo:
+				changes = append(changes, Change{Action: ChangeRemoved, Kind: kind, Name: name, From: f})
+			case !equalValues(f, t):
+				changes = append(changes, Change{Action: ChangeChanged, Kind: kind, Name: name, From: f, To: t})
+			}
+		}
+	}
+	return changes
+}
+
+// ChangeCounts tallies the changes by action.
+func ChangeCounts(changes []Change) (added, removed, changed int) {
+	for _, ch := range changes {
+		switch ch.Action {
+		case ChangeAdded:
+			added++
+		case ChangeRemoved:
+			removed++
+		case ChangeChanged:
+			changed++
+		}
+	}
+	return added, removed, changed
+}
+
+// equalValues compares two values by their JSON representation so that values
+// decoded from different sources (API responses vs. files) compare
+// consistently.
+func equalValues(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+	return string(ja) == string(jb)
+}
+
+// renderResource renders a resource as sorted `key = value` lines.
+//
+// NOTE: Multi-line string values (e.g. VCL content) are expanded onto their
+// own indented lines so that changes within them are diffed line by line.
+func renderResource(r map[string]any) []string {
+	if r == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(r))
+	for k := range r {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var lines []string
+	for _, k := range keys {
+		v := r[k]
+		if s, ok := v.(string); ok && strings.Contains(s, "\n") {
+			lines = append(lines, k+" =")
+			for _, l := range strings.Split(strings.TrimSuffix(s, "\n"), "\n") {
+				lines = append(lines, "    "+l)
+			}
+			continue
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			data = []byte(fmt.Sprintf("%v", v))
+		}
+		lines = append(lines, fmt.Sprintf("%s = %s", k, data))
+	}
+	return lines
+}
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// diffLine is a single line of a line-based diff.
+type diffLine struct {
+	op   byte // ' ', '-' or '+'
+	text string
+}
+
+// diffLines computes a line-based diff between a and b using the longest
+// common subsequence of the lines that remain once any common prefix and
+// suffix are removed.
+func diffLines(a, b []string) []diffLine {
+	var prefix int
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	var suffix int
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	lines := make([]diffLine, 0, len(a)+len(b))
+	for _, l := range a[:prefix] {
+		lines = append(lines, diffLine{' ', l})
+	}
+
+	ma, mb := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	lcs := make([][]int, len(ma)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(mb)+1)
+	}
+	for i := len(ma) - 1; i >= 0; i-- {
+		for j := len(mb) - 1; j >= 0; j-- {
+			if ma[i] == mb[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < len(ma) && j < len(mb) {
+		switch {
+		case ma[i] == mb[j]:
+			lines = append(lines, diffLine{' ', ma[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{'-', ma[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', mb[j]})
+			j++
+		}
+	}
+	for ; i < len(ma); i++ {
+		lines = append(lines, diffLine{'-', ma[i]})
+	}
+	for ; j < len(mb); j++ {
+		lines = append(lines, diffLine{'+', mb[j]})
+	}
+
+	for _, l := range a[len(a)-suffix:] {
+		lines = append(lines, diffLine{' ', l})
+	}
+	return lines
+}
+
+// unifiedDiff renders the differences between a and b as unified diff hunks.
+func unifiedDiff(a, b []string) string {
+	lines := diffLines(a, b)
+
+	var sb strings.Builder
+	for start := 0; start < len(lines); {
+		// Find the next change.
+		for start < len(lines) && lines[start].op == ' ' {
+			start++
+		}
+		if start == len(lines) {
+			break
+		}
+
+		// Extend the hunk until there's a run of unchanged lines long enough
+		// to separate it from the next change.
+		end := start
+		for end < len(lines) {
+			if lines[end].op != ' ' {
+				end++
+				continue
+			}
+			run := end
+			for run < len(lines) && lines[run].op == ' ' {
+				run++
+			}
+			if run == len(lines) || run-end > 2*diffContext {
+				break
+			}
+			end = run
+		}
+
+		first := start - diffContext
+		if first < 0 {
+			first = 0
+		}
+		last := end + diffContext
+		if last > len(lines) {
+			last = len(lines)
+		}
+
+		// Line numbers are 1-based and count the lines preceding the hunk.
+		aStart, bStart := 1, 1
+		for _, l := range lines[:first] {
+			if l.op != '+' {
+				aStart++
+			}
+			if l.op != '-' {
+				bStart++
+			}
+		}
+		var aLen, bLen int
+		for _, l := range lines[first:last] {
+			if l.op != '+' {
+				aLen++
+			}
+			if l.op != '-' {
+				bLen++
+			}
+		}
+		if aLen == 0 {
+			aStart--
+		}
+		if bLen == 0 {
+			bStart--
+		}
+
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", aStart, aLen, bStart, bLen)
+		for _, l := range lines[first:last] {
+			fmt.Fprintf(&sb, "%c%s\n", l.op, l.text)
+		}
+		start = last
+	}
+	return sb.String()
+}
@@ -0,0 +1,181 @@
+package serviceversion
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	toml "github.com/pelletier/go-toml"
+)
+
+// Document formats.
+const (
+	FormatJSON = "json"
+	FormatTOML = "toml"
+)
+
+// DocumentFormats is the list of supported document formats.
+var DocumentFormats = []string{FormatTOML, FormatJSON}
+
+// Document is a declarative representation of every versioned resource on a
+// service version.
+type Document struct {
+	ServiceID string    `json:"service_id" toml:"service_id"`
+	Version   int       `json:"version" toml:"version"`
+	Resources Resources `json:"resources" toml:"resources"`
+}
+
+// Encode serialises the document in the given format.
+func (d Document) Encode(format string) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	default:
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Order(toml.OrderAlphabetical).Encode(d); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+}
+
+// DecodeDocument deserialises a document in the given format.
+func DecodeDocument(data []byte, format string) (Document, error) {
+	var d Document
+	var err error
+	switch format {
+	case FormatJSON:
+		err = json.Unmarshal(data, &d)
+	default:
+		err = toml.Unmarshal(data, &d)
+	}
+	return d, err
+}
+
+// FormatFromPath infers the document format from a file extension, falling
+// back to TOML.
+func FormatFromPath(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return FormatJSON
+	}
+	return FormatTOML
+}
+
+// ExportCommand writes every versioned resource of a service version to a
+// single document.
+type ExportCommand struct {
+	cmd.Base
+
+	file           string
+	format         string
+	manifest       manifest.Data
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// NewExportCommand returns a usable command registered under the parent.
+func NewExportCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *ExportCommand {
+	var c ExportCommand
+	c.Globals = g
+	c.manifest = m
+	c.CmdClause = parent.Command("export", "Export the resources of a Fastly service version to a TOML or JSON document")
+
+	// Required.
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// Optional.
+	c.CmdClause.Flag("file", "Path to write the document to (defaults to stdout)").Short('f').StringVar(&c.file)
+	c.CmdClause.Flag("format", "Document format (inferred from the --file extension if not set)").HintOptions(DocumentFormats...).EnumVar(&c.format, DocumentFormats...)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *ExportCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flags.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	resources, err := FetchResources(c.Globals.APIClient, serviceID, serviceVersion.Number)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	format := c.format
+	if format == "" {
+		format = FormatFromPath(c.file)
+	}
+
+	data, err := Document{
+		ServiceID: serviceID,
+		Version:   serviceVersion.Number,
+		Resources: resources,
+	}.Encode(format)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error encoding service version document: %w", err)
+	}
+
+	if c.file == "" {
+		_, err = out.Write(data)
+		if err != nil {
+			c.Globals.ErrLog.Add(err)
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.WriteFile(c.file, data, 0o600); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return fmt.Errorf("error writing service version document: %w", err)
+	}
+
+	text.Success(out, "Exported service %s version %d to %s", serviceID, serviceVersion.Number, c.file)
+	return nil
+}
@@ -0,0 +1,305 @@
+package serviceversion
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/go-fastly/v8/fastly"
+	"github.com/mitchellh/mapstructure"
+)
+
+// resourceOps creates, updates and deletes a single kind of versioned
+// resource from its generic representation.
+type resourceOps struct {
+	create func(c api.Interface, serviceID string, serviceVersion int, r map[string]any) error
+	update func(c api.Interface, serviceID string, serviceVersion int, name string, fields map[string]any) error
+	delete func(c api.Interface, serviceID string, serviceVersion int, name string) error
+}
+
+// newResourceOps returns the operations for a resource kind whose API inputs
+// follow the common convention of being identified by service ID, service
+// version and name.
+func newResourceOps[C, U, D, R any](
+	create func(api.Interface, *C) (R, error),
+	update func(api.Interface, *U) (R, error),
+	del func(api.Interface, *D) error,
+) resourceOps {
+	return resourceOps{
+		create: func(c api.Interface, sid string, v int, r map[string]any) error {
+			var input C
+			if err := decodeInput(withoutZeroValues(r), &input, sid, v, ""); err != nil {
+				return err
+			}
+			_, err := create(c, &input)
+			return err
+		},
+		update: func(c api.Interface, sid string, v int, name string, fields map[string]any) error {
+			var input U
+			if err := decodeInput(fields, &input, sid, v, name); err != nil {
+				return err
+			}
+			_, err := update(c, &input)
+			return err
+		},
+		delete: func(c api.Interface, sid string, v int, name string) error {
+			var input D
+			if err := decodeInput(nil, &input, sid, v, name); err != nil {
+				return err
+			}
+			return del(c, &input)
+		},
+	}
+}
+
+// rateLimitOps reconciles rate limiters, which are identified by ID rather
+// than by name.
+var rateLimitOps = resourceOps{
+	create: func(c api.Interface, sid string, v int, r map[string]any) error {
+		input := fastly.CreateERLInput{}
+		if err := decodeInput(withoutZeroValues(r), &input, sid, v, ""); err != nil {
+			return err
+		}
+		_, err := c.CreateERL(&input)
+		return err
+	},
+	update: func(c api.Interface, sid string, v int, name string, fields map[string]any) error {
+		id, err := rateLimitID(c, sid, v, name)
+		if err != nil {
+			return err
+		}
+		input := fastly.UpdateERLInput{}
+		if err := decodeInput(fields, &input, sid, v, ""); err != nil {
+			return err
+		}
+		input.ERLID = id
+		_, err = c.UpdateERL(&input)
+		return err
+	},
+	delete: func(c api.Interface, sid string, v int, name string) error {
+		id, err := rateLimitID(c, sid, v, name)
+		if err != nil {
+			return err
+		}
+		return c.DeleteERL(&fastly.DeleteERLInput{ERLID: id})
+	},
+}
+
+// rateLimitID returns the ID of the named rate limiter.
+func rateLimitID(c api.Interface, sid string, v int, name string) (string, error) {
+	erls, err := c.ListERLs(&fastly.ListERLsInput{ServiceID: sid, ServiceVersion: v})
+	if err != nil {
+		return "", err
+	}
+	for _, erl := range erls {
+		if erl.Name == name {
+			return erl.ID, nil
+		}
+	}
+	return "", fmt.Errorf("rate limiter '%s' not found", name)
+}
+
+// resourceLinkOps reconciles resource links, which are identified by ID
+// rather than by name.
+var resourceLinkOps = resourceOps{
+	create: func(c api.Interface, sid string, v int, r map[string]any) error {
+		input := fastly.CreateResourceInput{}
+		if err := decodeInput(withoutZeroValues(r), &input, sid, v, ""); err != nil {
+			return err
+		}
+		_, err := c.CreateResource(&input)
+		return err
+	},
+	update: func(c api.Interface, sid string, v int, name string, fields map[string]any) error {
+		id, err := resourceLinkID(c, sid, v, name)
+		if err != nil {
+			return err
+		}
+		input := fastly.UpdateResourceInput{}
+		if err := decodeInput(fields, &input, sid, v, ""); err != nil {
+			return err
+		}
+		input.ID = id
+		_, err = c.UpdateResource(&input)
+		return err
+	},
+	delete: func(c api.Interface, sid string, v int, name string) error {
+		id, err := resourceLinkID(c, sid, v, name)
+		if err != nil {
+			return err
+		}
+		return c.DeleteResource(&fastly.DeleteResourceInput{ID: id, ServiceID: sid, ServiceVersion: v})
+	},
+}
+
+// resourceLinkID returns the ID of the named resource link.
+func resourceLinkID(c api.Interface, sid string, v int, name string) (string, error) {
+	links, err := c.ListResources(&fastly.ListResourcesInput{ServiceID: sid, ServiceVersion: v})
+	if err != nil {
+		return "", err
+	}
+	for _, link := range links {
+		if link.Name == name {
+			return link.ID, nil
+		}
+	}
+	return "", fmt.Errorf("resource link '%s' not found", name)
+}
+
+// conditionOps reconciles conditions, setting the comment of a new condition
+// once it's created.
+//
+// NOTE: The go-fastly CreateConditionInput doesn't support the Comment field.
+var conditionOps = func() resourceOps {
+	ops := newResourceOps(api.Interface.CreateCondition, api.Interface.UpdateCondition, api.Interface.DeleteCondition)
+	return resourceOps{
+		create: func(c api.Interface, sid string, v int, r map[string]any) error {
+			if err := ops.create(c, sid, v, r); err != nil {
+				return err
+			}
+			comment, _ := r["comment"].(string)
+			if comment == "" {
+				return nil
+			}
+			name, _ := r["name"].(string)
+			if err := ops.update(c, sid, v, name, map[string]any{"comment": comment}); err != nil {
+				return fmt.Errorf("error setting the comment of the condition: %w", err)
+			}
+			return nil
+		},
+		update: ops.update,
+		delete: ops.delete,
+	}
+}()
+
+// directorOps reconciles directors, including the backends they're mapped to,
+// which are managed through a separate API.
+var directorOps = func() resourceOps {
+	ops := newResourceOps(api.Interface.CreateDirector, api.Interface.UpdateDirector, api.Interface.DeleteDirector)
+	return resourceOps{
+		create: func(c api.Interface, sid string, v int, r map[string]any) error {
+			if err := ops.create(c, sid, v, r); err != nil {
+				return err
+			}
+			name, _ := r["name"].(string)
+			return syncDirectorBackends(c, sid, v, name, nil, stringSlice(r["backends"]))
+		},
+		update: func(c api.Interface, sid string, v int, name string, fields map[string]any) error {
+			backends, syncBackends := fields["backends"]
+			delete(fields, "backends")
+			if len(fields) > 0 {
+				if err := ops.update(c, sid, v, name, fields); err != nil {
+					return err
+				}
+			}
+			if !syncBackends {
+				return nil
+			}
+			d, err := c.GetDirector(&fastly.GetDirectorInput{Name: name, ServiceID: sid, ServiceVersion: v})
+			if err != nil {
+				return err
+			}
+			return syncDirectorBackends(c, sid, v, name, d.Backends, stringSlice(backends))
+		},
+		delete: ops.delete,
+	}
+}()
+
+// syncDirectorBackends adds and removes backends from a director so that it's
+// mapped to exactly the desired backends.
+func syncDirectorBackends(c api.Interface, sid string, v int, director string, current, desired []string) error {
+	isCurrent := map[string]bool{}
+	for _, b := range current {
+		isCurrent[b] = true
+	}
+	isDesired := map[string]bool{}
+	for _, b := range desired {
+		isDesired[b] = true
+	}
+
+	for _, b := range current {
+		if !isDesired[b] {
+			err := c.DeleteDirectorBackend(&fastly.DeleteDirectorBackendInput{Backend: b, Director: director, ServiceID: sid, ServiceVersion: v})
+			if err != nil {
+				return fmt.Errorf("error removing backend '%s' from director: %w", b, err)
+			}
+		}
+	}
+	for _, b := range desired {
+		if !isCurrent[b] {
+			_, err := c.CreateDirectorBackend(&fastly.CreateDirectorBackendInput{Backend: b, Director: director, ServiceID: sid, ServiceVersion: v})
+			if err != nil {
+				return fmt.Errorf("error adding backend '%s' to director: %w", b, err)
+			}
+		}
+	}
+	return nil
+}
+
+// stringSlice converts a list decoded from an API response or document into a
+// slice of strings.
+func stringSlice(v any) []string {
+	var ss []string
+	_ = mapstructure.WeakDecode(v, &ss)
+	return ss
+}
+
+// decodeInput populates an API input struct from a generic resource.
+//
+// Resource fields are matched against the input's `url` struct tags, which
+// mirror the API field names used when the resource was fetched. The service
+// ID, service version and (when given) the name identifying an existing
+// resource are then set directly, as they're excluded from the tags.
+func decodeInput(r map[string]any, input any, sid string, v int, name string) error {
+	d, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		Result:           input,
+		TagName:          "url",
+		WeaklyTypedInput: true,
+	})
+	if err != nil {
+		return err
+	}
+	if r != nil {
+		if err := d.Decode(r); err != nil {
+			return err
+		}
+	}
+
+	rv := reflect.ValueOf(input).Elem()
+	if f := rv.FieldByName("ServiceID"); f.IsValid() && f.Kind() == reflect.String {
+		f.SetString(sid)
+	}
+	if f := rv.FieldByName("ServiceVersion"); f.IsValid() && f.Kind() == reflect.Int {
+		f.SetInt(int64(v))
+	}
+	if f := rv.FieldByName("Name"); name != "" && f.IsValid() && f.Kind() == reflect.String {
+		f.SetString(name)
+	}
+	return nil
+}
+
+// withoutZeroValues returns a copy of the resource without any fields that
+// are set to their zero value, so that creating a resource leaves those
+// fields to the API defaults.
+func withoutZeroValues(r map[string]any) map[string]any {
+	m := make(map[string]any, len(r))
+	for k, v := range r {
+		if v == nil || reflect.ValueOf(v).IsZero() {
+			continue
+		}
+		m[k] = v
+	}
+	return m
+}
+
+// changedFields returns the fields of the desired resource whose values
+// differ from the current resource.
+func changedFields(current, desired map[string]any) map[string]any {
+	fields := map[string]any{}
+	for k, v := range desired {
+		if !equalValues(current[k], v) {
+			fields[k] = v
+		}
+	}
+	return fields
+}
@@ -0,0 +1,401 @@
+package serviceversion
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/fastly/cli/pkg/api"
+	"github.com/fastly/go-fastly/v8/fastly"
+	"github.com/mitchellh/mapstructure"
+)
+
+// Resources maps a resource kind (e.g. "backend", "logging/s3") to the
+// resources of that kind, keyed by resource name.
+//
+// Each resource is represented as a generic map so that resources of every
+// kind can be compared, serialised and reconciled in the same way.
+type Resources map[string]map[string]map[string]any
+
+// volatileFields are resource fields that change between service versions
+// without representing a change in configuration.
+var volatileFields = []string{
+	"created_at",
+	"deleted_at",
+	"id",
+	"service_id",
+	"updated_at",
+	"version",
+}
+
+// resourceKind describes how to list and reconcile a single kind of
+// versioned resource.
+type resourceKind struct {
+	kind string
+	list func(c api.Interface, serviceID string, serviceVersion int) (any, error)
+	ops  resourceOps
+}
+
+// resourceKinds is the set of versioned resources that are fetched when
+// inspecting a service version.
+//
+// The kinds are ordered so that resources are created before any resources
+// that refer to them (e.g. conditions and healthchecks before backends).
+//
+// NOTE: Dictionary items, ACL entries and store contents aren't versioned and
+// so are intentionally not included.
+var resourceKinds = []resourceKind{
+	{
+		kind: "condition",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListConditions(&fastly.ListConditionsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: conditionOps,
+	},
+	{
+		kind: "healthcheck",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListHealthChecks(&fastly.ListHealthChecksInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateHealthCheck, api.Interface.UpdateHealthCheck, api.Interface.DeleteHealthCheck),
+	},
+	{
+		kind: "acl",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListACLs(&fastly.ListACLsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateACL, api.Interface.UpdateACL, api.Interface.DeleteACL),
+	},
+	{
+		kind: "dictionary",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListDictionaries(&fastly.ListDictionariesInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateDictionary, api.Interface.UpdateDictionary, api.Interface.DeleteDictionary),
+	},
+	{
+		kind: "backend",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListBackends(&fastly.ListBackendsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateBackend, api.Interface.UpdateBackend, api.Interface.DeleteBackend),
+	},
+	{
+		kind: "director",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListDirectors(&fastly.ListDirectorsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: directorOps,
+	},
+	{
+		kind: "domain",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListDomains(&fastly.ListDomainsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateDomain, api.Interface.UpdateDomain, api.Interface.DeleteDomain),
+	},
+	{
+		kind: "header",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListHeaders(&fastly.ListHeadersInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateHeader, api.Interface.UpdateHeader, api.Interface.DeleteHeader),
+	},
+	{
+		kind: "cache-setting",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListCacheSettings(&fastly.ListCacheSettingsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateCacheSetting, api.Interface.UpdateCacheSetting, api.Interface.DeleteCacheSetting),
+	},
+	{
+		kind: "request-setting",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListRequestSettings(&fastly.ListRequestSettingsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateRequestSetting, api.Interface.UpdateRequestSetting, api.Interface.DeleteRequestSetting),
+	},
+	{
+		kind: "response-object",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListResponseObjects(&fastly.ListResponseObjectsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateResponseObject, api.Interface.UpdateResponseObject, api.Interface.DeleteResponseObject),
+	},
+	{
+		kind: "gzip",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListGzips(&fastly.ListGzipsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateGzip, api.Interface.UpdateGzip, api.Interface.DeleteGzip),
+	},
+	{
+		kind: "logging/azureblob",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListBlobStorages(&fastly.ListBlobStoragesInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateBlobStorage, api.Interface.UpdateBlobStorage, api.Interface.DeleteBlobStorage),
+	},
+	{
+		kind: "logging/bigquery",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListBigQueries(&fastly.ListBigQueriesInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateBigQuery, api.Interface.UpdateBigQuery, api.Interface.DeleteBigQuery),
+	},
+	{
+		kind: "logging/cloudfiles",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListCloudfiles(&fastly.ListCloudfilesInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateCloudfiles, api.Interface.UpdateCloudfiles, api.Interface.DeleteCloudfiles),
+	},
+	{
+		kind: "logging/datadog",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListDatadog(&fastly.ListDatadogInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateDatadog, api.Interface.UpdateDatadog, api.Interface.DeleteDatadog),
+	},
+	{
+		kind: "logging/digitalocean",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListDigitalOceans(&fastly.ListDigitalOceansInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateDigitalOcean, api.Interface.UpdateDigitalOcean, api.Interface.DeleteDigitalOcean),
+	},
+	{
+		kind: "logging/elasticsearch",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListElasticsearch(&fastly.ListElasticsearchInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateElasticsearch, api.Interface.UpdateElasticsearch, api.Interface.DeleteElasticsearch),
+	},
+	{
+		kind: "logging/ftp",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListFTPs(&fastly.ListFTPsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateFTP, api.Interface.UpdateFTP, api.Interface.DeleteFTP),
+	},
+	{
+		kind: "logging/gcs",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListGCSs(&fastly.ListGCSsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateGCS, api.Interface.UpdateGCS, api.Interface.DeleteGCS),
+	},
+	{
+		kind: "logging/googlepubsub",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListPubsubs(&fastly.ListPubsubsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreatePubsub, api.Interface.UpdatePubsub, api.Interface.DeletePubsub),
+	},
+	{
+		kind: "logging/heroku",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListHerokus(&fastly.ListHerokusInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateHeroku, api.Interface.UpdateHeroku, api.Interface.DeleteHeroku),
+	},
+	{
+		kind: "logging/honeycomb",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListHoneycombs(&fastly.ListHoneycombsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateHoneycomb, api.Interface.UpdateHoneycomb, api.Interface.DeleteHoneycomb),
+	},
+	{
+		kind: "logging/https",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListHTTPS(&fastly.ListHTTPSInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateHTTPS, api.Interface.UpdateHTTPS, api.Interface.DeleteHTTPS),
+	},
+	{
+		kind: "logging/kafka",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListKafkas(&fastly.ListKafkasInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateKafka, api.Interface.UpdateKafka, api.Interface.DeleteKafka),
+	},
+	{
+		kind: "logging/kinesis",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListKinesis(&fastly.ListKinesisInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateKinesis, api.Interface.UpdateKinesis, api.Interface.DeleteKinesis),
+	},
+	{
+		kind: "logging/logentries",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListLogentries(&fastly.ListLogentriesInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateLogentries, api.Interface.UpdateLogentries, api.Interface.DeleteLogentries),
+	},
+	{
+		kind: "logging/loggly",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListLoggly(&fastly.ListLogglyInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateLoggly, api.Interface.UpdateLoggly, api.Interface.DeleteLoggly),
+	},
+	{
+		kind: "logging/logshuttle",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListLogshuttles(&fastly.ListLogshuttlesInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateLogshuttle, api.Interface.UpdateLogshuttle, api.Interface.DeleteLogshuttle),
+	},
+	{
+		kind: "logging/newrelic",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListNewRelic(&fastly.ListNewRelicInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateNewRelic, api.Interface.UpdateNewRelic, api.Interface.DeleteNewRelic),
+	},
+	{
+		kind: "logging/openstack",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListOpenstack(&fastly.ListOpenstackInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateOpenstack, api.Interface.UpdateOpenstack, api.Interface.DeleteOpenstack),
+	},
+	{
+		kind: "logging/papertrail",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListPapertrails(&fastly.ListPapertrailsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreatePapertrail, api.Interface.UpdatePapertrail, api.Interface.DeletePapertrail),
+	},
+	{
+		kind: "logging/s3",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListS3s(&fastly.ListS3sInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateS3, api.Interface.UpdateS3, api.Interface.DeleteS3),
+	},
+	{
+		kind: "logging/scalyr",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListScalyrs(&fastly.ListScalyrsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateScalyr, api.Interface.UpdateScalyr, api.Interface.DeleteScalyr),
+	},
+	{
+		kind: "logging/sftp",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListSFTPs(&fastly.ListSFTPsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateSFTP, api.Interface.UpdateSFTP, api.Interface.DeleteSFTP),
+	},
+	{
+		kind: "logging/splunk",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListSplunks(&fastly.ListSplunksInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateSplunk, api.Interface.UpdateSplunk, api.Interface.DeleteSplunk),
+	},
+	{
+		kind: "logging/sumologic",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListSumologics(&fastly.ListSumologicsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateSumologic, api.Interface.UpdateSumologic, api.Interface.DeleteSumologic),
+	},
+	{
+		kind: "logging/syslog",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListSyslogs(&fastly.ListSyslogsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateSyslog, api.Interface.UpdateSyslog, api.Interface.DeleteSyslog),
+	},
+	{
+		kind: "rate-limit",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListERLs(&fastly.ListERLsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: rateLimitOps,
+	},
+	{
+		kind: "resource-link",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListResources(&fastly.ListResourcesInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: resourceLinkOps,
+	},
+	{
+		kind: "vcl/custom",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListVCLs(&fastly.ListVCLsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateVCL, api.Interface.UpdateVCL, api.Interface.DeleteVCL),
+	},
+	{
+		kind: "vcl/snippet",
+		list: func(c api.Interface, sid string, v int) (any, error) {
+			return c.ListSnippets(&fastly.ListSnippetsInput{ServiceID: sid, ServiceVersion: v})
+		},
+		ops: newResourceOps(api.Interface.CreateSnippet, api.Interface.UpdateSnippet, api.Interface.DeleteSnippet),
+	},
+}
+
+// FetchResources retrieves every versioned resource for the given service
+// version.
+func FetchResources(client api.Interface, serviceID string, serviceVersion int) (Resources, error) {
+	r := make(Resources, len(resourceKinds))
+	for _, rk := range resourceKinds {
+		items, err := rk.list(client, serviceID, serviceVersion)
+		if err != nil {
+			return nil, fmt.Errorf("error listing %s resources for service version %d: %w", rk.kind, serviceVersion, err)
+		}
+		named, err := normalizeResources(items)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s resources for service version %d: %w", rk.kind, serviceVersion, err)
+		}
+		r[rk.kind] = named
+	}
+	return r, nil
+}
+
+// normalizeResources converts a slice of API resources into generic maps
+// keyed by resource name, with any version specific or unset fields removed.
+func normalizeResources(items any) (map[string]map[string]any, error) {
+	var list []map[string]any
+	if err := mapstructure.Decode(items, &list); err != nil {
+		return nil, err
+	}
+
+	named := make(map[string]map[string]any, len(list))
+	for _, m := range list {
+		for _, f := range volatileFields {
+			delete(m, f)
+		}
+		removeNilValues(m)
+		name, _ := m["name"].(string)
+		named[name] = m
+	}
+	return named, nil
+}
+
+// removeNilValues deletes any nil values (including typed nil pointers) from
+// the map and any nested maps, as they can't be represented in every document
+// format and are equivalent to the value not being set.
+func removeNilValues(m map[string]any) {
+	for k, v := range m {
+		if nested, ok := v.(map[string]any); ok {
+			removeNilValues(nested)
+			continue
+		}
+		if v == nil {
+			delete(m, k)
+			continue
+		}
+		switch rv := reflect.ValueOf(v); rv.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+			if rv.IsNil() {
+				delete(m, k)
+			}
+		}
+	}
+}
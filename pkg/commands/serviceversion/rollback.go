@@ -0,0 +1,200 @@
+package serviceversion
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// RollbackCommand activates a previously active service version.
+type RollbackCommand struct {
+	cmd.Base
+	manifest    manifest.Data
+	serviceName cmd.OptionalServiceNameID
+	to          cmd.OptionalInt
+}
+
+// NewRollbackCommand returns a usable command registered under the parent.
+func NewRollbackCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *RollbackCommand {
+	var c RollbackCommand
+	c.Globals = g
+	c.manifest = m
+	c.CmdClause = parent.Command("rollback", "Activate the service version that was active before the current one")
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("to", "Service version to roll back to (defaults to the most recently active version before the current one)").Action(c.to.Set).IntVar(&c.to.Value)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *RollbackCommand) Exec(in io.Reader, out io.Writer) error {
+	serviceID, source, flag, err := cmd.ServiceID(c.serviceName, c.manifest, c.Globals.APIClient, c.Globals.ErrLog)
+	if err != nil {
+		return err
+	}
+	if c.Globals.Verbose() {
+		cmd.DisplayServiceID(serviceID, flag, source, out)
+	}
+
+	versions, err := c.Globals.APIClient.ListVersions(&fastly.ListVersionsInput{
+		ServiceID: serviceID,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID": serviceID,
+		})
+		return fmt.Errorf("error listing service versions: %w", err)
+	}
+
+	current, err := cmd.GetActiveVersion(versions)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID": serviceID,
+		})
+		return err
+	}
+
+	target, err := c.target(versions, current)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": current.Number,
+		})
+		return err
+	}
+
+	if err := c.summarise(out, serviceID, current, target); err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": target.Number,
+		})
+		return err
+	}
+
+	if !c.Globals.Flags.AutoYes && !c.Globals.Flags.NonInteractive {
+		text.Break(out)
+		label := fmt.Sprintf("Activate service %s version %d? [y/N] ", serviceID, target.Number)
+		cont, err := text.AskYesNo(out, text.BoldYellow(label), in)
+		if err != nil {
+			return err
+		}
+		if !cont {
+			return nil
+		}
+	}
+
+	_, err = c.Globals.APIClient.ActivateVersion(&fastly.ActivateVersionInput{
+		ServiceID:      serviceID,
+		ServiceVersion: target.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": target.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Rolled back service %s from version %d to version %d", serviceID, current.Number, target.Number)
+	return nil
+}
+
+// target returns the service version to roll back to.
+//
+// NOTE: The API doesn't expose the activation history of a service. A version
+// is locked when it's activated, and its updated_at time changes when it's
+// deactivated, so the previously active version is expected to be both the
+// highest locked version below the current one and the most recently updated
+// locked version. When those differ (e.g. a rollback followed by a new deploy)
+// the previously active version is ambiguous and --to is required.
+func (c *RollbackCommand) target(versions []*fastly.Version, current *fastly.Version) (*fastly.Version, error) {
+	if c.to.WasSet {
+		for _, v := range versions {
+			if v.Number != c.to.Value {
+				continue
+			}
+			if v.Number == current.Number {
+				return nil, fmt.Errorf("service version %d is already active", v.Number)
+			}
+			return v, nil
+		}
+		return nil, fmt.Errorf("specified service version not found: %d", c.to.Value)
+	}
+
+	var highest, recent *fastly.Version
+	for _, v := range versions {
+		if !v.Locked || v.Number == current.Number {
+			continue
+		}
+		if v.Number < current.Number && (highest == nil || v.Number > highest.Number) {
+			highest = v
+		}
+		if v.UpdatedAt != nil && (recent == nil || v.UpdatedAt.After(*recent.UpdatedAt)) {
+			recent = v
+		}
+	}
+	if highest == nil {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("no previously active service version found before version %d", current.Number),
+			Remediation: "Use the --to flag to specify the service version to roll back to.",
+		}
+	}
+	if recent != nil && recent.Number != highest.Number {
+		return nil, fsterr.RemediationError{
+			Inner:       fmt.Errorf("unable to determine the previously active service version (version %d or version %d)", recent.Number, highest.Number),
+			Remediation: "Use the --to flag to specify the service version to roll back to.",
+		}
+	}
+	return highest, nil
+}
+
+// summarise displays the resources that will change by rolling back.
+func (c *RollbackCommand) summarise(out io.Writer, serviceID string, current, target *fastly.Version) error {
+	from, err := FetchResources(c.Globals.APIClient, serviceID, current.Number)
+	if err != nil {
+		return err
+	}
+	to, err := FetchResources(c.Globals.APIClient, serviceID, target.Number)
+	if err != nil {
+		return err
+	}
+	changes := DiffResources(from, to)
+
+	text.Info(out, "Rolling back service %s from version %d to version %d", serviceID, current.Number, target.Number)
+	if len(changes) == 0 {
+		text.Output(out, "No differences found between the versions' resources.")
+		return nil
+	}
+
+	text.Break(out)
+	for _, ch := range changes {
+		symbol := "~"
+		switch ch.Action {
+		case ChangeAdded:
+			symbol = "+"
+		case ChangeRemoved:
+			symbol = "-"
+		}
+		fmt.Fprintf(out, "  %s %s '%s'\n", symbol, ch.Kind, ch.Name)
+	}
+	added, removed, changed := ChangeCounts(changes)
+	text.Break(out)
+	text.Output(out, "%d added, %d removed, %d changed", added, removed, changed)
+	return nil
+}
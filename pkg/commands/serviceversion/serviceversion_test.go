@@ -2,6 +2,8 @@ package serviceversion_test
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -301,6 +303,326 @@ func TestVersionLock(t *testing.T) {
 	}
 }
 
+func TestVersionDiff(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --to flag",
+			Args:      args("service-version diff --service-id 123 --from 1"),
+			WantError: "error parsing arguments: required flag --to not provided",
+		},
+		{
+			Name: "validate ListBackends API error",
+			API: withVersionResources(mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListBackendsFn: func(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+					return nil, testutil.Err
+				},
+			}),
+			Args:      args("service-version diff --service-id 123 --from 1 --to 3"),
+			WantError: "error listing backend resources for service version 1: test error",
+		},
+		{
+			Name: "validate no differences",
+			API: withVersionResources(mock.API{
+				ListVersionsFn: testutil.ListVersions,
+			}),
+			Args:       args("service-version diff --service-id 123 --from 1 --to 3"),
+			WantOutput: "No differences found between service 123 version 1 and version 3",
+		},
+		{
+			Name: "validate unified diff output",
+			API: withVersionResources(mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListBackendsFn: listBackendsByVersion,
+				ListDomainsFn:  listDomainsByVersion,
+			}),
+			Args:       args("service-version diff --service-id 123 --from 1 --to 3"),
+			WantOutput: diffVersionsOutput,
+		},
+		{
+			Name: "validate JSON change set",
+			API: withVersionResources(mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListBackendsFn: listBackendsByVersion,
+				ListDomainsFn:  listDomainsByVersion,
+			}),
+			Args:       args("service-version diff --service-id 123 --from 1 --to 3 --json"),
+			WantOutput: `"action": "changed",`,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestVersionExport(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("service-version export --service-id 123"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name: "validate ListBackends API error",
+			API: withVersionResources(mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListBackendsFn: func(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+					return nil, testutil.Err
+				},
+			}),
+			Args:      args("service-version export --service-id 123 --version 1"),
+			WantError: "error listing backend resources for service version 1: test error",
+		},
+		{
+			Name: "validate TOML document",
+			API: withVersionResources(mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListBackendsFn: listBackendsByVersion,
+			}),
+			Args:       args("service-version export --service-id 123 --version 1"),
+			WantOutput: "[resources.backend.origin]",
+		},
+		{
+			Name: "validate JSON document",
+			API: withVersionResources(mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				ListBackendsFn: listBackendsByVersion,
+			}),
+			Args:       args("service-version export --service-id 123 --version 1 --format json"),
+			WantOutput: `"address": "a.example.com",`,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestVersionApply(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "service.json")
+	if err := os.WriteFile(file, []byte(applyDocument), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --file flag",
+			Args:      args("service-version apply --service-id 123 --version 1"),
+			WantError: "error parsing arguments: required flag --file not provided",
+		},
+		{
+			Name: "validate version is not editable without --autoclone",
+			API: withVersionResources(mock.API{
+				ListVersionsFn: testutil.ListVersions,
+			}),
+			Args:      args("service-version apply --service-id 123 --version 1 --file " + file),
+			WantError: "service version 1 is not editable",
+		},
+		{
+			Name: "validate UpdateBackend API error",
+			API: withVersionResources(mock.API{
+				ListVersionsFn:     testutil.ListVersions,
+				CloneVersionFn:     testutil.CloneVersionResult(4),
+				ListBackendsFn:     listBackendsByVersion,
+				ListHealthChecksFn: listHealthChecksOK,
+				CreateDomainFn:     createDomainOK,
+				UpdateBackendFn: func(i *fastly.UpdateBackendInput) (*fastly.Backend, error) {
+					return nil, testutil.Err
+				},
+			}),
+			Args:      args("service-version apply --service-id 123 --version 1 --autoclone --file " + file),
+			WantError: "error updating backend 'origin': test error",
+		},
+		{
+			Name: "validate changes are applied and activated",
+			API: withVersionResources(mock.API{
+				ListVersionsFn:     testutil.ListVersions,
+				CloneVersionFn:     testutil.CloneVersionResult(4),
+				ListBackendsFn:     listBackendsByVersion,
+				ListHealthChecksFn: listHealthChecksOK,
+				CreateDomainFn:     createDomainOK,
+				UpdateBackendFn:    updateBackendOK,
+				ActivateVersionFn:  activateVersionOK,
+			}),
+			Args:       args("service-version apply --service-id 123 --version 1 --autoclone --activate --file " + file),
+			WantOutput: applyOutput,
+		},
+		{
+			Name: "validate kinds missing from the document are deleted with --prune",
+			API: withVersionResources(mock.API{
+				ListVersionsFn:      testutil.ListVersions,
+				CloneVersionFn:      testutil.CloneVersionResult(4),
+				ListBackendsFn:      listBackendsByVersion,
+				ListHealthChecksFn:  listHealthChecksOK,
+				CreateDomainFn:      createDomainOK,
+				DeleteHealthCheckFn: deleteHealthCheckOK,
+				UpdateBackendFn:     updateBackendOK,
+			}),
+			Args:       args("service-version apply --service-id 123 --version 1 --autoclone --prune --auto-yes --file " + file),
+			WantOutput: applyPruneOutput,
+		},
+		{
+			Name: "validate deletions require confirmation",
+			API: withVersionResources(mock.API{
+				ListVersionsFn:     testutil.ListVersions,
+				CloneVersionFn:     testutil.CloneVersionResult(4),
+				ListBackendsFn:     listBackendsByVersion,
+				ListHealthChecksFn: listHealthChecksOK,
+			}),
+			Args:      args("service-version apply --service-id 123 --version 1 --autoclone --prune --non-interactive --file " + file),
+			WantError: "would delete 1 resources",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+// TestVersionApplyConditionComment validates that the comment of a condition
+// is set once the condition is created, as it can't be set on creation.
+func TestVersionApplyConditionComment(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "service.json")
+	if err := os.WriteFile(file, []byte(applyConditionDocument), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var comments []string
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("service-version apply --service-id 123 --version 1 --autoclone --file "+file), &stdout)
+	opts.APIClient = mock.APIClient(withVersionResources(mock.API{
+		ListVersionsFn: testutil.ListVersions,
+		CloneVersionFn: testutil.CloneVersionResult(4),
+		CreateConditionFn: func(i *fastly.CreateConditionInput) (*fastly.Condition, error) {
+			return &fastly.Condition{
+				Name:           *i.Name,
+				ServiceID:      i.ServiceID,
+				ServiceVersion: i.ServiceVersion,
+			}, nil
+		},
+		UpdateConditionFn: func(i *fastly.UpdateConditionInput) (*fastly.Condition, error) {
+			if i.Name != "commented" || i.ServiceVersion != 4 || i.Comment == nil {
+				return nil, testutil.Err
+			}
+			comments = append(comments, *i.Comment)
+			return &fastly.Condition{
+				Comment:        *i.Comment,
+				Name:           i.Name,
+				ServiceID:      i.ServiceID,
+				ServiceVersion: i.ServiceVersion,
+			}, nil
+		},
+	}))
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, stdout.String(), "Created condition 'commented'")
+	testutil.AssertStringContains(t, stdout.String(), "Created condition 'uncommented'")
+	testutil.AssertEqual(t, []string{"only for staff"}, comments)
+}
+
+func TestVersionRollback(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name: "validate no previously active version",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+			},
+			Args:      args("service-version rollback --service-id 123 --auto-yes"),
+			WantError: "no previously active service version found before version 1",
+		},
+		{
+			Name: "validate the previously active version is ambiguous after a rollback",
+			API: mock.API{
+				ListVersionsFn: listVersionsRolledBack,
+			},
+			Args:      args("service-version rollback --service-id 123 --auto-yes"),
+			WantError: "unable to determine the previously active service version (version 1 or version 2)",
+		},
+		{
+			Name: "validate --to flag matches the active version",
+			API: mock.API{
+				ListVersionsFn: listVersionsRollback,
+			},
+			Args:      args("service-version rollback --service-id 123 --to 3 --auto-yes"),
+			WantError: "service version 3 is already active",
+		},
+		{
+			Name: "validate --to flag matches an existing version",
+			API: mock.API{
+				ListVersionsFn: listVersionsRollback,
+			},
+			Args:      args("service-version rollback --service-id 123 --to 9 --auto-yes"),
+			WantError: "specified service version not found: 9",
+		},
+		{
+			Name: "validate ActivateVersion API error",
+			API: withVersionResources(mock.API{
+				ListVersionsFn:    listVersionsRollback,
+				ActivateVersionFn: activateVersionError,
+			}),
+			Args:      args("service-version rollback --service-id 123 --auto-yes"),
+			WantError: "test error",
+		},
+		{
+			Name: "validate rollback to the previously active version",
+			API: withVersionResources(mock.API{
+				ListVersionsFn:    listVersionsRollback,
+				ListBackendsFn:    listBackendsByVersion,
+				ListDomainsFn:     listDomainsByVersion,
+				ActivateVersionFn: activateVersionOK,
+			}),
+			Args:       args("service-version rollback --service-id 123 --auto-yes"),
+			WantOutput: rollbackOutput,
+		},
+		{
+			Name: "validate rollback with --to flag",
+			API: withVersionResources(mock.API{
+				ListVersionsFn:    listVersionsRollback,
+				ActivateVersionFn: activateVersionOK,
+			}),
+			Args:       args("service-version rollback --service-id 123 --to 1 --auto-yes"),
+			WantOutput: "Rolled back service 123 from version 3 to version 1",
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
 var listVersionsShortOutput = strings.TrimSpace(`
 NUMBER  ACTIVE  LAST EDITED (UTC)
 1       true    2000-01-01 01:00
@@ -404,3 +726,301 @@ func lockVersionOK(i *fastly.LockVersionInput) (*fastly.Version, error) {
 func lockVersionError(i *fastly.LockVersionInput) (*fastly.Version, error) {
 	return nil, testutil.Err
 }
+
+var diffVersionsOutput = `--- backend/origin (version 1)
++++ backend/origin (version 3)
+@@ -1,4 +1,4 @@
+-address = "a.example.com"
++address = "b.example.com"
+ auto_loadbalance = false
+ between_bytes_timeout = 0
+ comment = ""
+--- domain/www.example.com (version 1)
++++ domain/www.example.com (version 3)
+@@ -0,0 +1,2 @@
++comment = ""
++name = "www.example.com"
+`
+
+func listBackendsByVersion(i *fastly.ListBackendsInput) ([]*fastly.Backend, error) {
+	address := "a.example.com"
+	if i.ServiceVersion == 3 {
+		address = "b.example.com"
+	}
+	return []*fastly.Backend{
+		{
+			Address:        address,
+			Name:           "origin",
+			Port:           443,
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+		},
+	}, nil
+}
+
+func listDomainsByVersion(i *fastly.ListDomainsInput) ([]*fastly.Domain, error) {
+	if i.ServiceVersion != 3 {
+		return []*fastly.Domain{}, nil
+	}
+	return []*fastly.Domain{
+		{
+			Name:           "www.example.com",
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+		},
+	}, nil
+}
+
+var applyDocument = `{
+  "service_id": "123",
+  "version": 1,
+  "resources": {
+    "backend": {
+      "origin": {
+        "address": "c.example.com",
+        "name": "origin",
+        "port": 443
+      }
+    },
+    "domain": {
+      "www.example.com": {
+        "name": "www.example.com"
+      }
+    }
+  }
+}
+`
+
+var applyConditionDocument = `{
+  "service_id": "123",
+  "version": 1,
+  "resources": {
+    "condition": {
+      "commented": {
+        "comment": "only for staff",
+        "name": "commented",
+        "statement": "req.http.X-Staff",
+        "type": "REQUEST"
+      },
+      "uncommented": {
+        "name": "uncommented",
+        "statement": "req.http.X-Other",
+        "type": "REQUEST"
+      }
+    }
+  }
+}
+`
+
+var applyOutput = `Updated backend 'origin'
+Created domain 'www.example.com'
+
+SUCCESS: Applied `
+
+var applyPruneOutput = `Deleted healthcheck 'check'
+Updated backend 'origin'
+Created domain 'www.example.com'
+
+SUCCESS: Applied `
+
+func listHealthChecksOK(i *fastly.ListHealthChecksInput) ([]*fastly.HealthCheck, error) {
+	return []*fastly.HealthCheck{
+		{
+			Name:           "check",
+			Path:           "/status",
+			ServiceID:      i.ServiceID,
+			ServiceVersion: i.ServiceVersion,
+		},
+	}, nil
+}
+
+func deleteHealthCheckOK(i *fastly.DeleteHealthCheckInput) error {
+	if i.Name != "check" || i.ServiceVersion != 4 {
+		return testutil.Err
+	}
+	return nil
+}
+
+func createDomainOK(i *fastly.CreateDomainInput) (*fastly.Domain, error) {
+	if i.Name == nil || *i.Name != "www.example.com" || i.ServiceVersion != 4 {
+		return nil, testutil.Err
+	}
+	return &fastly.Domain{
+		Name:           *i.Name,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}, nil
+}
+
+func updateBackendOK(i *fastly.UpdateBackendInput) (*fastly.Backend, error) {
+	if i.Name != "origin" || i.Address == nil || *i.Address != "c.example.com" || i.Port != nil {
+		return nil, testutil.Err
+	}
+	return &fastly.Backend{
+		Address:        *i.Address,
+		Name:           i.Name,
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}, nil
+}
+
+// withVersionResources populates every versioned resource list function that
+// hasn't been set with one that returns no resources.
+func withVersionResources(api mock.API) mock.API {
+	if api.ListACLsFn == nil {
+		api.ListACLsFn = func(*fastly.ListACLsInput) ([]*fastly.ACL, error) { return nil, nil }
+	}
+	if api.ListBackendsFn == nil {
+		api.ListBackendsFn = func(*fastly.ListBackendsInput) ([]*fastly.Backend, error) { return nil, nil }
+	}
+	if api.ListDictionariesFn == nil {
+		api.ListDictionariesFn = func(*fastly.ListDictionariesInput) ([]*fastly.Dictionary, error) { return nil, nil }
+	}
+	if api.ListConditionsFn == nil {
+		api.ListConditionsFn = func(*fastly.ListConditionsInput) ([]*fastly.Condition, error) { return nil, nil }
+	}
+	if api.ListDirectorsFn == nil {
+		api.ListDirectorsFn = func(*fastly.ListDirectorsInput) ([]*fastly.Director, error) { return nil, nil }
+	}
+	if api.ListDomainsFn == nil {
+		api.ListDomainsFn = func(*fastly.ListDomainsInput) ([]*fastly.Domain, error) { return nil, nil }
+	}
+	if api.ListHeadersFn == nil {
+		api.ListHeadersFn = func(*fastly.ListHeadersInput) ([]*fastly.Header, error) { return nil, nil }
+	}
+	if api.ListCacheSettingsFn == nil {
+		api.ListCacheSettingsFn = func(*fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error) { return nil, nil }
+	}
+	if api.ListRequestSettingsFn == nil {
+		api.ListRequestSettingsFn = func(*fastly.ListRequestSettingsInput) ([]*fastly.RequestSetting, error) { return nil, nil }
+	}
+	if api.ListResponseObjectsFn == nil {
+		api.ListResponseObjectsFn = func(*fastly.ListResponseObjectsInput) ([]*fastly.ResponseObject, error) { return nil, nil }
+	}
+	if api.ListGzipsFn == nil {
+		api.ListGzipsFn = func(*fastly.ListGzipsInput) ([]*fastly.Gzip, error) { return nil, nil }
+	}
+	if api.ListHealthChecksFn == nil {
+		api.ListHealthChecksFn = func(*fastly.ListHealthChecksInput) ([]*fastly.HealthCheck, error) { return nil, nil }
+	}
+	if api.ListBlobStoragesFn == nil {
+		api.ListBlobStoragesFn = func(*fastly.ListBlobStoragesInput) ([]*fastly.BlobStorage, error) { return nil, nil }
+	}
+	if api.ListBigQueriesFn == nil {
+		api.ListBigQueriesFn = func(*fastly.ListBigQueriesInput) ([]*fastly.BigQuery, error) { return nil, nil }
+	}
+	if api.ListCloudfilesFn == nil {
+		api.ListCloudfilesFn = func(*fastly.ListCloudfilesInput) ([]*fastly.Cloudfiles, error) { return nil, nil }
+	}
+	if api.ListDatadogFn == nil {
+		api.ListDatadogFn = func(*fastly.ListDatadogInput) ([]*fastly.Datadog, error) { return nil, nil }
+	}
+	if api.ListDigitalOceansFn == nil {
+		api.ListDigitalOceansFn = func(*fastly.ListDigitalOceansInput) ([]*fastly.DigitalOcean, error) { return nil, nil }
+	}
+	if api.ListElasticsearchFn == nil {
+		api.ListElasticsearchFn = func(*fastly.ListElasticsearchInput) ([]*fastly.Elasticsearch, error) { return nil, nil }
+	}
+	if api.ListFTPsFn == nil {
+		api.ListFTPsFn = func(*fastly.ListFTPsInput) ([]*fastly.FTP, error) { return nil, nil }
+	}
+	if api.ListGCSsFn == nil {
+		api.ListGCSsFn = func(*fastly.ListGCSsInput) ([]*fastly.GCS, error) { return nil, nil }
+	}
+	if api.ListPubsubsFn == nil {
+		api.ListPubsubsFn = func(*fastly.ListPubsubsInput) ([]*fastly.Pubsub, error) { return nil, nil }
+	}
+	if api.ListHerokusFn == nil {
+		api.ListHerokusFn = func(*fastly.ListHerokusInput) ([]*fastly.Heroku, error) { return nil, nil }
+	}
+	if api.ListHoneycombsFn == nil {
+		api.ListHoneycombsFn = func(*fastly.ListHoneycombsInput) ([]*fastly.Honeycomb, error) { return nil, nil }
+	}
+	if api.ListHTTPSFn == nil {
+		api.ListHTTPSFn = func(*fastly.ListHTTPSInput) ([]*fastly.HTTPS, error) { return nil, nil }
+	}
+	if api.ListKafkasFn == nil {
+		api.ListKafkasFn = func(*fastly.ListKafkasInput) ([]*fastly.Kafka, error) { return nil, nil }
+	}
+	if api.ListKinesisFn == nil {
+		api.ListKinesisFn = func(*fastly.ListKinesisInput) ([]*fastly.Kinesis, error) { return nil, nil }
+	}
+	if api.ListLogentriesFn == nil {
+		api.ListLogentriesFn = func(*fastly.ListLogentriesInput) ([]*fastly.Logentries, error) { return nil, nil }
+	}
+	if api.ListLogglyFn == nil {
+		api.ListLogglyFn = func(*fastly.ListLogglyInput) ([]*fastly.Loggly, error) { return nil, nil }
+	}
+	if api.ListLogshuttlesFn == nil {
+		api.ListLogshuttlesFn = func(*fastly.ListLogshuttlesInput) ([]*fastly.Logshuttle, error) { return nil, nil }
+	}
+	if api.ListNewRelicFn == nil {
+		api.ListNewRelicFn = func(*fastly.ListNewRelicInput) ([]*fastly.NewRelic, error) { return nil, nil }
+	}
+	if api.ListOpenstacksFn == nil {
+		api.ListOpenstacksFn = func(*fastly.ListOpenstackInput) ([]*fastly.Openstack, error) { return nil, nil }
+	}
+	if api.ListPapertrailsFn == nil {
+		api.ListPapertrailsFn = func(*fastly.ListPapertrailsInput) ([]*fastly.Papertrail, error) { return nil, nil }
+	}
+	if api.ListS3sFn == nil {
+		api.ListS3sFn = func(*fastly.ListS3sInput) ([]*fastly.S3, error) { return nil, nil }
+	}
+	if api.ListScalyrsFn == nil {
+		api.ListScalyrsFn = func(*fastly.ListScalyrsInput) ([]*fastly.Scalyr, error) { return nil, nil }
+	}
+	if api.ListSFTPsFn == nil {
+		api.ListSFTPsFn = func(*fastly.ListSFTPsInput) ([]*fastly.SFTP, error) { return nil, nil }
+	}
+	if api.ListSplunksFn == nil {
+		api.ListSplunksFn = func(*fastly.ListSplunksInput) ([]*fastly.Splunk, error) { return nil, nil }
+	}
+	if api.ListSumologicsFn == nil {
+		api.ListSumologicsFn = func(*fastly.ListSumologicsInput) ([]*fastly.Sumologic, error) { return nil, nil }
+	}
+	if api.ListSyslogsFn == nil {
+		api.ListSyslogsFn = func(*fastly.ListSyslogsInput) ([]*fastly.Syslog, error) { return nil, nil }
+	}
+	if api.ListERLsFn == nil {
+		api.ListERLsFn = func(*fastly.ListERLsInput) ([]*fastly.ERL, error) { return nil, nil }
+	}
+	if api.ListResourcesFn == nil {
+		api.ListResourcesFn = func(*fastly.ListResourcesInput) ([]*fastly.Resource, error) { return nil, nil }
+	}
+	if api.ListVCLsFn == nil {
+		api.ListVCLsFn = func(*fastly.ListVCLsInput) ([]*fastly.VCL, error) { return nil, nil }
+	}
+	if api.ListSnippetsFn == nil {
+		api.ListSnippetsFn = func(*fastly.ListSnippetsInput) ([]*fastly.Snippet, error) { return nil, nil }
+	}
+	return api
+}
+
+func listVersionsRollback(i *fastly.ListVersionsInput) ([]*fastly.Version, error) {
+	return []*fastly.Version{
+		{ServiceID: i.ServiceID, Number: 1, Locked: true, UpdatedAt: testutil.MustParseTimeRFC3339("2000-01-02T01:00:00Z")},
+		{ServiceID: i.ServiceID, Number: 2, Locked: true, UpdatedAt: testutil.MustParseTimeRFC3339("2000-01-03T01:00:00Z")},
+		{ServiceID: i.ServiceID, Number: 3, Active: true, Locked: true, UpdatedAt: testutil.MustParseTimeRFC3339("2000-01-03T01:00:00Z")},
+		{ServiceID: i.ServiceID, Number: 4, UpdatedAt: testutil.MustParseTimeRFC3339("2000-01-04T01:00:00Z")},
+	}, nil
+}
+
+// listVersionsRolledBack lists the versions after version 2 was rolled back
+// to version 1, and version 3 (a clone of version 1) was then activated.
+func listVersionsRolledBack(i *fastly.ListVersionsInput) ([]*fastly.Version, error) {
+	return []*fastly.Version{
+		{ServiceID: i.ServiceID, Number: 1, Locked: true, UpdatedAt: testutil.MustParseTimeRFC3339("2000-01-04T01:00:00Z")},
+		{ServiceID: i.ServiceID, Number: 2, Locked: true, UpdatedAt: testutil.MustParseTimeRFC3339("2000-01-03T01:00:00Z")},
+		{ServiceID: i.ServiceID, Number: 3, Active: true, Locked: true, UpdatedAt: testutil.MustParseTimeRFC3339("2000-01-04T01:00:00Z")},
+	}, nil
+}
+
+var rollbackOutput = `INFO: Rolling back service 123 from version 3 to version 2
+
+  ~ backend 'origin'
+  - domain 'www.example.com'
+
+0 added, 1 removed, 1 changed
+
+SUCCESS: Rolled back service 123 from version 3 to version 2
+`
@@ -0,0 +1,3 @@
+// Package settings contains commands to inspect and manipulate the settings of
+// a Fastly service version (e.g. the default TTL and default host).
+package settings
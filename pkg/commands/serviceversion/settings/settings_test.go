@@ -0,0 +1,164 @@
+package settings_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+func TestSettingsDescribe(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("service-version settings describe --service-id 123"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name: "validate GetSettings API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetSettingsFn: func(i *fastly.GetSettingsInput) (*fastly.Settings, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("service-version settings describe --service-id 123 --version 1"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate GetSettings API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetSettingsFn:  getSettingsOK,
+			},
+			Args:       args("service-version settings describe --service-id 123 --version 1"),
+			WantOutput: describeSettingsOutput,
+		},
+		{
+			Name: "validate --json flag",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetSettingsFn:  getSettingsOK,
+			},
+			Args:       args("service-version settings describe --service-id 123 --version 1 --json"),
+			WantOutput: `"DefaultTTL": 3600`,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestSettingsUpdate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate invalid --default-ttl flag",
+			Args:      args("service-version settings update --service-id 123 --version 1 --default-ttl=-1"),
+			WantError: "error parsing arguments: strconv.ParseUint",
+		},
+		{
+			Name: "validate UpdateSettings API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				UpdateSettingsFn: func(i *fastly.UpdateSettingsInput) (*fastly.Settings, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("service-version settings update --service-id 123 --version 1 --default-ttl 60 --autoclone"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate UpdateSettings API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				CloneVersionFn: testutil.CloneVersionResult(4),
+				UpdateSettingsFn: func(i *fastly.UpdateSettingsInput) (*fastly.Settings, error) {
+					if i.DefaultTTL != 60 || i.DefaultHost != nil || !*i.StaleIfError || *i.StaleIfErrorTTL != 300 {
+						return nil, testutil.Err
+					}
+					return &fastly.Settings{
+						DefaultTTL:     i.DefaultTTL,
+						ServiceID:      i.ServiceID,
+						ServiceVersion: i.ServiceVersion,
+					}, nil
+				},
+			},
+			Args:       args("service-version settings update --service-id 123 --version 1 --default-ttl 60 --stale-if-error --stale-if-error-ttl 300 --autoclone"),
+			WantOutput: "Updated settings (service 123 version 4)",
+		},
+		{
+			Name: "validate default TTL is preserved when --default-ttl is not set",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetSettingsFn:  getSettingsOK,
+				UpdateSettingsFn: func(i *fastly.UpdateSettingsInput) (*fastly.Settings, error) {
+					if i.DefaultTTL != 3600 || *i.DefaultHost != "www.example.com" {
+						return nil, testutil.Err
+					}
+					return &fastly.Settings{
+						DefaultHost:    *i.DefaultHost,
+						DefaultTTL:     i.DefaultTTL,
+						ServiceID:      i.ServiceID,
+						ServiceVersion: i.ServiceVersion,
+					}, nil
+				},
+			},
+			Args:       args("service-version settings update --service-id 123 --version 3 --default-host www.example.com"),
+			WantOutput: "Updated settings (service 123 version 3)",
+		},
+		{
+			Name: "validate GetSettings API error when --default-ttl is not set",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetSettingsFn: func(i *fastly.GetSettingsInput) (*fastly.Settings, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("service-version settings update --service-id 123 --version 3 --default-host www.example.com"),
+			WantError: testutil.Err.Error(),
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func getSettingsOK(i *fastly.GetSettingsInput) (*fastly.Settings, error) {
+	return &fastly.Settings{
+		DefaultTTL:      3600,
+		ServiceID:       i.ServiceID,
+		ServiceVersion:  i.ServiceVersion,
+		StaleIfErrorTTL: 43200,
+	}, nil
+}
+
+var describeSettingsOutput = `
+Service ID: 123
+Version: 1
+Default host: 
+Default TTL: 3600
+Stale if error: false
+Stale if error TTL: 43200
+`
@@ -0,0 +1,136 @@
+package settings
+
+import (
+	"io"
+
+	"github.com/fastly/cli/pkg/cmd"
+	"github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// UpdateCommand calls the Fastly API to update the settings of a service
+// version.
+type UpdateCommand struct {
+	cmd.Base
+	manifest       manifest.Data
+	input          fastly.UpdateSettingsInput
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+	autoClone      cmd.OptionalAutoClone
+
+	DefaultHost     cmd.OptionalString
+	DefaultTTL      cmd.OptionalUint
+	StaleIfError    cmd.OptionalBool
+	StaleIfErrorTTL cmd.OptionalUint
+}
+
+// NewUpdateCommand returns a usable command registered under the parent.
+func NewUpdateCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *UpdateCommand {
+	c := UpdateCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+	c.CmdClause = parent.Command("update", "Update the settings of a Fastly service version")
+
+	// required
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// optional
+	c.RegisterAutoCloneFlag(cmd.AutoCloneFlagOpts{
+		Action: c.autoClone.Set,
+		Dst:    &c.autoClone.Value,
+	})
+	c.CmdClause.Flag("default-host", "The default host name for the version").Action(c.DefaultHost.Set).StringVar(&c.DefaultHost.Value)
+	c.CmdClause.Flag("default-ttl", "The default time-to-live (TTL) in seconds for the version").Action(c.DefaultTTL.Set).UintVar(&c.DefaultTTL.Value)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	c.CmdClause.Flag("stale-if-error", "Enables serving a stale object if there is an error").Action(c.StaleIfError.Set).BoolVar(&c.StaleIfError.Value)
+	c.CmdClause.Flag("stale-if-error-ttl", "The default time-to-live (TTL) in seconds for serving the stale object for the version").Action(c.StaleIfErrorTTL.Set).UintVar(&c.StaleIfErrorTTL.Value)
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *UpdateCommand) Exec(_ io.Reader, out io.Writer) error {
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AutoCloneFlag:      c.autoClone,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flags.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": errors.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	c.input.ServiceID = serviceID
+	c.input.ServiceVersion = serviceVersion.Number
+
+	// NOTE: The API always sets the default TTL, so if the flag isn't set we
+	// need to send the current value to avoid resetting it to zero.
+	if c.DefaultTTL.WasSet {
+		c.input.DefaultTTL = c.DefaultTTL.Value
+	} else {
+		current, err := c.Globals.APIClient.GetSettings(&fastly.GetSettingsInput{
+			ServiceID:      serviceID,
+			ServiceVersion: serviceVersion.Number,
+		})
+		if err != nil {
+			c.Globals.ErrLog.AddWithContext(err, map[string]any{
+				"Service ID":      serviceID,
+				"Service Version": serviceVersion.Number,
+			})
+			return err
+		}
+		c.input.DefaultTTL = current.DefaultTTL
+	}
+
+	if c.DefaultHost.WasSet {
+		c.input.DefaultHost = &c.DefaultHost.Value
+	}
+
+	if c.StaleIfError.WasSet {
+		c.input.StaleIfError = &c.StaleIfError.Value
+	}
+
+	if c.StaleIfErrorTTL.WasSet {
+		c.input.StaleIfErrorTTL = &c.StaleIfErrorTTL.Value
+	}
+
+	s, err := c.Globals.APIClient.UpdateSettings(&c.input)
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	text.Success(out, "Updated settings (service %s version %d)", s.ServiceID, s.ServiceVersion)
+	return nil
+}
@@ -0,0 +1,132 @@
+package vcl
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/fastly/cli/pkg/api/undocumented"
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+)
+
+// Boilerplate is the boilerplate VCL for a service version.
+type Boilerplate struct {
+	Content        string
+	ServiceID      string
+	ServiceVersion int
+}
+
+// BoilerplateCommand calls the Fastly API to retrieve the boilerplate VCL for
+// a service version.
+type BoilerplateCommand struct {
+	cmd.Base
+	cmd.JSONOutput
+
+	manifest       manifest.Data
+	output         string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// NewBoilerplateCommand returns a usable command registered under the parent.
+func NewBoilerplateCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *BoilerplateCommand {
+	c := BoilerplateCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+	c.CmdClause = parent.Command("boilerplate", "Show the boilerplate VCL to use as a starting point for custom VCL on a Fastly service version")
+
+	// required
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// optional
+	c.RegisterFlagBool(c.JSONFlag()) // --json
+	c.CmdClause.Flag("output", "Path to write the VCL to (defaults to stdout)").StringVar(&c.output)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *BoilerplateCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.Globals.Verbose() && c.JSONOutput.Enabled {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flags.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	token, _ := c.Globals.Token()
+	endpoint, _ := c.Globals.Endpoint()
+	content, err := undocumented.Call(undocumented.CallOptions{
+		APIEndpoint: endpoint,
+		HTTPClient:  c.Globals.HTTPClient,
+		Method:      http.MethodGet,
+		Path:        fmt.Sprintf(undocumented.Boilerplate, serviceID, serviceVersion.Number),
+		Token:       token,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		if apiErr, ok := err.(undocumented.APIError); ok && apiErr.StatusCode != 0 {
+			return fmt.Errorf("error retrieving boilerplate VCL: %w: %d %s", err, apiErr.StatusCode, http.StatusText(apiErr.StatusCode))
+		}
+		return fmt.Errorf("error retrieving boilerplate VCL: %w", err)
+	}
+
+	b := Boilerplate{
+		Content:        string(content),
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	}
+	data, err := render(&c.JSONOutput, b, b.Content)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	if err := writeOutput(out, c.output, data); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	if c.output != "" {
+		text.Success(out, "Wrote boilerplate VCL for service %s version %d to %s", serviceID, serviceVersion.Number, c.output)
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package vcl
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fastly/cli/pkg/cmd"
+	fsterr "github.com/fastly/cli/pkg/errors"
+	"github.com/fastly/cli/pkg/global"
+	"github.com/fastly/cli/pkg/manifest"
+	"github.com/fastly/cli/pkg/text"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+// GeneratedCommand calls the Fastly API to retrieve the generated VCL for a
+// service version.
+type GeneratedCommand struct {
+	cmd.Base
+	cmd.JSONOutput
+
+	manifest       manifest.Data
+	output         string
+	serviceName    cmd.OptionalServiceNameID
+	serviceVersion cmd.OptionalServiceVersion
+}
+
+// NewGeneratedCommand returns a usable command registered under the parent.
+func NewGeneratedCommand(parent cmd.Registerer, g *global.Data, m manifest.Data) *GeneratedCommand {
+	c := GeneratedCommand{
+		Base: cmd.Base{
+			Globals: g,
+		},
+		manifest: m,
+	}
+	c.CmdClause = parent.Command("generated", "Show the full VCL generated for a Fastly service version, including all snippets and custom VCL")
+
+	// required
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagVersionName,
+		Description: cmd.FlagVersionDesc,
+		Dst:         &c.serviceVersion.Value,
+		Required:    true,
+	})
+
+	// optional
+	c.RegisterFlagBool(c.JSONFlag()) // --json
+	c.CmdClause.Flag("output", "Path to write the VCL to (defaults to stdout)").StringVar(&c.output)
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Name:        cmd.FlagServiceIDName,
+		Description: cmd.FlagServiceIDDesc,
+		Dst:         &c.manifest.Flag.ServiceID,
+		Short:       's',
+	})
+	c.RegisterFlag(cmd.StringFlagOpts{
+		Action:      c.serviceName.Set,
+		Name:        cmd.FlagServiceName,
+		Description: cmd.FlagServiceDesc,
+		Dst:         &c.serviceName.Value,
+	})
+	return &c
+}
+
+// Exec invokes the application logic for the command.
+func (c *GeneratedCommand) Exec(_ io.Reader, out io.Writer) error {
+	if c.Globals.Verbose() && c.JSONOutput.Enabled {
+		return fsterr.ErrInvalidVerboseJSONCombo
+	}
+
+	serviceID, serviceVersion, err := cmd.ServiceDetails(cmd.ServiceDetailsOpts{
+		AllowActiveLocked:  true,
+		APIClient:          c.Globals.APIClient,
+		Manifest:           c.manifest,
+		Out:                out,
+		ServiceNameFlag:    c.serviceName,
+		ServiceVersionFlag: c.serviceVersion,
+		VerboseMode:        c.Globals.Flags.Verbose,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": fsterr.ServiceVersion(serviceVersion),
+		})
+		return err
+	}
+
+	v, err := c.Globals.APIClient.GetGeneratedVCL(&fastly.GetGeneratedVCLInput{
+		ServiceID:      serviceID,
+		ServiceVersion: serviceVersion.Number,
+	})
+	if err != nil {
+		c.Globals.ErrLog.AddWithContext(err, map[string]any{
+			"Service ID":      serviceID,
+			"Service Version": serviceVersion.Number,
+		})
+		return err
+	}
+
+	data, err := render(&c.JSONOutput, v, v.Content)
+	if err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	if err := writeOutput(out, c.output, data); err != nil {
+		c.Globals.ErrLog.Add(err)
+		return err
+	}
+	if c.output != "" {
+		text.Success(out, "Wrote generated VCL for service %s version %d to %s", serviceID, serviceVersion.Number, c.output)
+	}
+	return nil
+}
+
+// render returns the data to output: the given value encoded as JSON if the
+// --json flag is set, otherwise the raw VCL content.
+func render(j *cmd.JSONOutput, value any, content string) ([]byte, error) {
+	var buf bytes.Buffer
+	ok, err := j.WriteJSON(&buf, value)
+	if !ok {
+		return []byte(content), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error encoding JSON: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeOutput writes data to the file at path, or to out if path is empty.
+func writeOutput(out io.Writer, path string, data []byte) error {
+	if path == "" {
+		if _, err := out.Write(data); err != nil {
+			return fmt.Errorf("error: unable to write data to stdout: %w", err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("error writing VCL to %s: %w", path, err)
+	}
+	return nil
+}
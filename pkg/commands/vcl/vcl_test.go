@@ -0,0 +1,166 @@
+package vcl_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fastly/cli/pkg/app"
+	"github.com/fastly/cli/pkg/mock"
+	"github.com/fastly/cli/pkg/testutil"
+	"github.com/fastly/go-fastly/v8/fastly"
+)
+
+func TestVCLGenerated(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("vcl generated --service-id 123"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name: "validate GetGeneratedVCL API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+				GetGeneratedVCLFn: func(i *fastly.GetGeneratedVCLInput) (*fastly.VCL, error) {
+					return nil, testutil.Err
+				},
+			},
+			Args:      args("vcl generated --service-id 123 --version 1"),
+			WantError: testutil.Err.Error(),
+		},
+		{
+			Name: "validate GetGeneratedVCL API success",
+			API: mock.API{
+				ListVersionsFn:    testutil.ListVersions,
+				GetGeneratedVCLFn: getGeneratedVCLOK,
+			},
+			Args:       args("vcl generated --service-id 123 --version 1"),
+			WantOutput: generatedVCL,
+		},
+		{
+			Name: "validate --json flag",
+			API: mock.API{
+				ListVersionsFn:    testutil.ListVersions,
+				GetGeneratedVCLFn: getGeneratedVCLOK,
+			},
+			Args:       args("vcl generated --service-id 123 --version 1 --json"),
+			WantOutput: `"Content": "sub vcl_recv {\n  #FASTLY recv\n}\n"`,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func TestVCLGeneratedOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "generated.vcl")
+
+	var stdout bytes.Buffer
+	opts := testutil.NewRunOpts(testutil.Args("vcl generated --service-id 123 --version 1 --output "+path), &stdout)
+	opts.APIClient = mock.APIClient(mock.API{
+		ListVersionsFn:    testutil.ListVersions,
+		GetGeneratedVCLFn: getGeneratedVCLOK,
+	})
+	err := app.Run(opts)
+	testutil.AssertNoError(t, err)
+	testutil.AssertStringContains(t, stdout.String(), "Wrote generated VCL for service 123 version 1 to "+path)
+
+	data, err := os.ReadFile(path)
+	testutil.AssertNoError(t, err)
+	testutil.AssertString(t, generatedVCL, string(data))
+}
+
+func TestVCLBoilerplate(t *testing.T) {
+	args := testutil.Args
+	scenarios := []testutil.TestScenario{
+		{
+			Name:      "validate missing --version flag",
+			Args:      args("vcl boilerplate --service-id 123"),
+			WantError: "error parsing arguments: required flag --version not provided",
+		},
+		{
+			Name: "validate boilerplate API error",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+			},
+			Args:      args("vcl boilerplate --service-id 123 --version 1"),
+			WantError: "error retrieving boilerplate VCL: non-2xx response: 404 Not Found",
+		},
+		{
+			Name: "validate boilerplate API success",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+			},
+			Args:       args("vcl boilerplate --service-id 123 --version 1"),
+			WantOutput: boilerplateVCL,
+		},
+		{
+			Name: "validate --json flag",
+			API: mock.API{
+				ListVersionsFn: testutil.ListVersions,
+			},
+			Args:       args("vcl boilerplate --service-id 123 --version 1 --json"),
+			WantOutput: `"ServiceVersion": 1`,
+		},
+	}
+	responses := []*http.Response{
+		nil,
+		{
+			Body:       io.NopCloser(strings.NewReader("")),
+			Status:     http.StatusText(http.StatusNotFound),
+			StatusCode: http.StatusNotFound,
+		},
+		{
+			Body:       io.NopCloser(strings.NewReader(boilerplateVCL)),
+			Status:     http.StatusText(http.StatusOK),
+			StatusCode: http.StatusOK,
+		},
+		{
+			Body:       io.NopCloser(strings.NewReader(boilerplateVCL)),
+			Status:     http.StatusText(http.StatusOK),
+			StatusCode: http.StatusOK,
+		},
+	}
+	for testcaseIdx := range scenarios {
+		testcase := &scenarios[testcaseIdx]
+		t.Run(testcase.Name, func(t *testing.T) {
+			var stdout bytes.Buffer
+			opts := testutil.NewRunOpts(testcase.Args, &stdout)
+			opts.APIClient = mock.APIClient(testcase.API)
+			if responses[testcaseIdx] != nil {
+				opts.HTTPClient = mock.HTMLClient([]*http.Response{responses[testcaseIdx]}, []error{nil})
+			}
+			err := app.Run(opts)
+			testutil.AssertErrorContains(t, err, testcase.WantError)
+			testutil.AssertStringContains(t, stdout.String(), testcase.WantOutput)
+		})
+	}
+}
+
+func getGeneratedVCLOK(i *fastly.GetGeneratedVCLInput) (*fastly.VCL, error) {
+	return &fastly.VCL{
+		Content:        generatedVCL,
+		Main:           true,
+		Name:           "generated",
+		ServiceID:      i.ServiceID,
+		ServiceVersion: i.ServiceVersion,
+	}, nil
+}
+
+var generatedVCL = "sub vcl_recv {\n  #FASTLY recv\n}\n"
+
+var boilerplateVCL = "sub vcl_recv {\n#FASTLY recv\n  return(lookup);\n}\n"
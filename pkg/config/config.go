@@ -78,8 +78,32 @@ type Viceroy struct {
 
 // Language represents C@E language specific configuration.
 type Language struct {
-	Go   Go   `toml:"go"`
-	Rust Rust `toml:"rust"`
+	Custom map[string]CustomLanguage `toml:"custom,omitempty"`
+	Go     Go                        `toml:"go"`
+	Rust   Rust                      `toml:"rust"`
+}
+
+// CustomLanguage represents a C@E language defined as data, rather than being
+// built into the CLI (e.g. [language.custom.zig]).
+type CustomLanguage struct {
+	// Build is the default build command, used when the fastly.toml manifest
+	// doesn't define [scripts.build].
+	Build string `toml:"build"`
+	// DisplayName is the name shown when prompting for a language.
+	DisplayName string `toml:"display_name"`
+	// Output is where the build command writes the Wasm binary, if not
+	// bin/main.wasm.
+	Output string `toml:"output"`
+	// SourceDirectory is the directory containing the source code.
+	SourceDirectory string `toml:"source_directory"`
+	// StarterKits are the starter kits offered by `compute init`.
+	StarterKits []StarterKit `toml:"starter_kits"`
+	// Toolchain is the binary required to build the language.
+	Toolchain string `toml:"toolchain"`
+	// ToolchainConstraint is the toolchain version that we support.
+	ToolchainConstraint string `toml:"toolchain_constraint"`
+	// ToolchainVersion is the command that prints the toolchain version.
+	ToolchainVersion string `toml:"toolchain_version"`
 }
 
 // Go represents Go C@E language specific configuration.
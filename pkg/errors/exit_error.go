@@ -34,3 +34,24 @@ func (ee SkipExitError) Print(w io.Writer) {
 		text.Error(w, "%s.", ee.Err.Error())
 	}
 }
+
+// ExitCodeError is an error that causes the CLI to exit with the given code
+// rather than 1. An example is a failing `compute test` command, which exits
+// with the code of the test command.
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+// Unwrap returns the inner error.
+func (ee ExitCodeError) Unwrap() error {
+	return ee.Err
+}
+
+// Error prints the inner error string.
+func (ee ExitCodeError) Error() string {
+	if ee.Err == nil {
+		return ""
+	}
+	return ee.Err.Error()
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/fastly/cli/pkg/api"
 	fstruntime "github.com/fastly/cli/pkg/runtime"
@@ -17,6 +18,12 @@ import (
 const (
 	// metadataURL takes a GitHub repo (e.g. cli or viceroy), an OS (e.g. darwin or linux), and an arch (e.g. amd64 or arm64).
 	metadataURL = "https://developer.fastly.com/api/internal/releases/meta/%s/%s/%s"
+	// releaseAssetPrefix takes a GitHub repo, version, OS and arch.
+	releaseAssetPrefix = "%s_v%s_%s-%s"
+	// releaseURL takes a GitHub org, repo and version.
+	releaseURL = "https://api.github.com/repos/%s/%s/releases/tags/v%s"
+	// releasesURL takes a GitHub org and repo.
+	releasesURL = "https://api.github.com/repos/%s/%s/releases?per_page=100"
 )
 
 // New returns a usable asset.
@@ -76,7 +83,42 @@ func (g *Asset) Download() (bin string, err error) {
 	if err != nil {
 		return "", err
 	}
+	return g.download(endpoint)
+}
+
+// DownloadVersion retrieves the binary archive format of a specific release
+// version from GitHub.
+func (g *Asset) DownloadVersion(version string) (bin string, err error) {
+	endpoint, err := g.versionURL(version)
+	if err != nil {
+		return "", err
+	}
+	return g.download(endpoint)
+}
+
+// versionURL returns the download URL of the release asset for the current OS
+// and architecture, as listed by the GitHub API for the release version.
+func (g *Asset) versionURL(version string) (url string, err error) {
+	var r Release
+	if _, err := g.get(fmt.Sprintf(releaseURL, g.org, g.repo, version), "release", &r); err != nil {
+		return "", err
+	}
+
+	prefix := fmt.Sprintf(releaseAssetPrefix, g.repo, version, runtime.GOOS, runtime.GOARCH)
+	for _, a := range r.Assets {
+		if !strings.HasPrefix(a.Name, prefix) {
+			continue
+		}
+		if ext := strings.TrimPrefix(a.Name, prefix); ext == ".tar.gz" || ext == ".zip" {
+			return a.BrowserDownloadURL, nil
+		}
+	}
+	return "", fmt.Errorf("no asset found for your OS (%s) and architecture (%s) in the %s release v%s", runtime.GOOS, runtime.GOARCH, g.repo, version)
+}
 
+// download retrieves the binary archive from the endpoint and extracts the
+// executable binary.
+func (g *Asset) download(endpoint string) (bin string, err error) {
 	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create a HTTP request: %w", err)
@@ -147,6 +189,77 @@ func (g *Asset) Version() (version string, err error) {
 	return g.version, nil
 }
 
+// Versions returns the versions of every published (non-draft, non-prerelease)
+// release, as listed by the GitHub API.
+func (g *Asset) Versions() (versions []string, err error) {
+	for endpoint := fmt.Sprintf(releasesURL, g.org, g.repo); endpoint != ""; {
+		var releases []Release
+		header, err := g.get(endpoint, "releases", &releases)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range releases {
+			if r.Draft || r.Prerelease {
+				continue
+			}
+			versions = append(versions, strings.TrimPrefix(r.TagName, "v"))
+		}
+		endpoint = nextPageURL(header)
+	}
+	return versions, nil
+}
+
+// get requests the GitHub API endpoint and decodes the response into v,
+// returning the response headers. The description of the resource is used in
+// error messages.
+func (g *Asset) get(endpoint, description string, v any) (http.Header, error) {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a HTTP request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	if g.httpClient == nil {
+		g.httpClient = http.DefaultClient
+	}
+	res, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request GitHub %s: %w", description, err)
+	}
+	defer res.Body.Close() // #nosec G307
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to request GitHub %s: %s", description, res.Status)
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GitHub's %s response: %w", description, err)
+	}
+
+	err = json.Unmarshal(data, v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub's %s: %w", description, err)
+	}
+	return res.Header, nil
+}
+
+// nextPageURL returns the URL of the next page of results from the Link
+// header of a GitHub API response, or an empty string on the last page.
+func nextPageURL(header http.Header) string {
+	for _, link := range strings.Split(header.Get("Link"), ",") {
+		url, params, ok := strings.Cut(strings.TrimSpace(link), ";")
+		if !ok {
+			continue
+		}
+		for _, param := range strings.Split(params, ";") {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(url), "<>")
+			}
+		}
+	}
+	return ""
+}
+
 // metadata acquires GitHub metadata.
 func (g *Asset) metadata() (m Metadata, err error) {
 	endpoint := fmt.Sprintf(metadataURL, g.repo, runtime.GOOS, runtime.GOARCH)
@@ -188,16 +301,40 @@ type Metadata struct {
 	Version string `json:"version"`
 }
 
+// Release represents a GitHub API release.
+type Release struct {
+	// Assets are the files uploaded to the release.
+	Assets []ReleaseAsset `json:"assets"`
+	// Draft indicates if the release is unpublished.
+	Draft bool `json:"draft"`
+	// Prerelease indicates if the release isn't production ready.
+	Prerelease bool `json:"prerelease"`
+	// TagName is the release tag (e.g. v0.4.5).
+	TagName string `json:"tag_name"`
+}
+
+// ReleaseAsset represents a file uploaded to a GitHub API release.
+type ReleaseAsset struct {
+	// BrowserDownloadURL is the endpoint for downloading the asset.
+	BrowserDownloadURL string `json:"browser_download_url"`
+	// Name is the file name of the asset.
+	Name string `json:"name"`
+}
+
 // AssetVersioner describes a source of CLI release artifacts.
 type AssetVersioner interface {
 	// BinaryName returns the configured binary output name.
 	BinaryName() string
 	// Download implements the Versioner interface.
 	Download() (bin string, err error)
+	// DownloadVersion retrieves the binary of a specific release version.
+	DownloadVersion(version string) (bin string, err error)
 	// URL returns the asset URL if set, otherwise calls the API metadata endpoint.
 	URL() (url string, err error)
 	// Version returns the asset Version if set, otherwise calls the API metadata endpoint.
 	Version() (version string, err error)
+	// Versions returns the versions of every published release.
+	Versions() (versions []string, err error)
 }
 
 // createArchive copies the DevHub response body data into a temporary archive
@@ -2,8 +2,11 @@ package github
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"runtime"
+	"strings"
 	"testing"
 
 	fstruntime "github.com/fastly/cli/pkg/runtime"
@@ -68,3 +71,79 @@ func TestDownloadArchiveExtract(t *testing.T) {
 		})
 	}
 }
+
+// stubClient returns the response body and Link header stubbed for each URL.
+type stubClient map[string][2]string
+
+func (c stubClient) Do(req *http.Request) (*http.Response, error) {
+	stub, ok := c[req.URL.String()]
+	if !ok {
+		return &http.Response{
+			Status:     http.StatusText(http.StatusNotFound),
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(strings.NewReader("")),
+		}, nil
+	}
+	header := make(http.Header)
+	if stub[1] != "" {
+		header.Set("Link", stub[1])
+	}
+	return &http.Response{
+		Status:     http.StatusText(http.StatusOK),
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(stub[0])),
+	}, nil
+}
+
+// TestVersions validates that every page of releases is requested.
+func TestVersions(t *testing.T) {
+	page2 := "https://api.github.com/repositories/1/releases?per_page=100&page=2"
+	a := Asset{
+		httpClient: stubClient{
+			fmt.Sprintf(releasesURL, "fastly", "viceroy"): {
+				`[{"tag_name": "v0.5.0"}, {"tag_name": "v0.4.6", "prerelease": true}]`,
+				fmt.Sprintf(`<%s>; rel="next", <%s>; rel="last"`, page2, page2),
+			},
+			page2: {`[{"tag_name": "v0.4.5"}, {"tag_name": "v0.4.4", "draft": true}]`, ""},
+		},
+		org:  "fastly",
+		repo: "viceroy",
+	}
+
+	versions, err := a.Versions()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := strings.Join(versions, ","); got != "0.5.0,0.4.5" {
+		t.Fatalf("want versions 0.5.0,0.4.5, have %s", got)
+	}
+}
+
+// TestVersionURL validates that the release asset for the current OS and
+// architecture is resolved from the assets of the release.
+func TestVersionURL(t *testing.T) {
+	name := fmt.Sprintf("viceroy_v0.4.5_%s-%s.tar.gz", runtime.GOOS, runtime.GOARCH)
+	a := Asset{
+		httpClient: stubClient{
+			fmt.Sprintf(releaseURL, "fastly", "viceroy", "0.4.5"): {fmt.Sprintf(`{"tag_name": "v0.4.5", "assets": [
+				{"name": "%[1]s.sha256", "browser_download_url": "https://example.com/%[1]s.sha256"},
+				{"name": "%[1]s", "browser_download_url": "https://example.com/%[1]s"}
+			]}`, name), ""},
+		},
+		org:  "fastly",
+		repo: "viceroy",
+	}
+
+	url, err := a.versionURL("0.4.5")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "https://example.com/" + name; url != want {
+		t.Fatalf("want %s, have %s", want, url)
+	}
+
+	if _, err := a.versionURL("0.4.4"); err == nil {
+		t.Fatal("expected an error for a missing release")
+	}
+}
@@ -66,9 +66,9 @@ func (d *Data) Token() (string, lookup.Source) {
 		}
 	}
 
-	if d.Manifest.File.Profile != "" {
+	if profile, _ := d.Manifest.Profile(); profile != "" {
 		for k, v := range d.Config.Profiles {
-			if k == d.Manifest.File.Profile {
+			if k == profile {
 				return v.Token, lookup.SourceFile
 			}
 		}
@@ -112,6 +112,7 @@ type Flags struct {
 	AcceptDefaults bool
 	AutoYes        bool
 	Endpoint       string
+	Env            string
 	NonInteractive bool
 	Profile        string
 	Quiet          bool
@@ -46,6 +46,10 @@ const (
 	// SourceFlag indicates the parameter came from an explicit flag.
 	SourceFlag
 
+	// SourceOverlay indicates the parameter came from an environment overlay
+	// (see File.ApplyEnv).
+	SourceOverlay
+
 	// SpecIntro informs the user of what the manifest file is for.
 	SpecIntro = "This file describes a Fastly Compute@Edge package. To learn more visit:"
 
@@ -84,6 +88,10 @@ func (d *Data) ServiceID() (string, Source) {
 		return sid, SourceEnv
 	}
 
+	if o, _, ok := d.File.lookupOverlay(overlayFieldSet("service_id")); ok {
+		return o.ServiceID, SourceOverlay
+	}
+
 	if d.File.ServiceID != "" {
 		return d.File.ServiceID, SourceFile
 	}
@@ -91,6 +99,35 @@ func (d *Data) ServiceID() (string, Source) {
 	return "", SourceUndefined
 }
 
+// Profile yields a Profile.
+func (d *Data) Profile() (string, Source) {
+	if o, _, ok := d.File.lookupOverlay(overlayFieldSet("profile")); ok {
+		return o.Profile, SourceOverlay
+	}
+
+	if d.File.Profile != "" {
+		return d.File.Profile, SourceFile
+	}
+
+	return "", SourceUndefined
+}
+
+// Setup yields a Setup.
+//
+// NOTE: An environment overlay's [setup] replaces the manifest's [setup]
+// entirely, rather than being merged with it.
+func (d *Data) Setup() (Setup, Source) {
+	if o, _, ok := d.File.lookupOverlay(overlayFieldSet("setup")); ok {
+		return o.Setup, SourceOverlay
+	}
+
+	if d.File.Setup.Defined() {
+		return d.File.Setup, SourceFile
+	}
+
+	return Setup{}, SourceUndefined
+}
+
 // Description yields a Description.
 func (d *Data) Description() (string, Source) {
 	if d.File.Description != "" {
@@ -186,21 +223,24 @@ func (v *Version) UnmarshalText(txt []byte) error {
 // File represents all of the configuration parameters in the fastly.toml
 // manifest file schema.
 type File struct {
-	Authors         []string    `toml:"authors"`
-	Description     string      `toml:"description"`
-	Language        string      `toml:"language"`
-	Profile         string      `toml:"profile,omitempty"`
-	LocalServer     LocalServer `toml:"local_server,omitempty"`
-	ManifestVersion Version     `toml:"manifest_version"`
-	Name            string      `toml:"name"`
-	Scripts         Scripts     `toml:"scripts,omitempty"`
-	ServiceID       string      `toml:"service_id"`
-	Setup           Setup       `toml:"setup,omitempty"`
+	Authors         []string                  `toml:"authors"`
+	CustomLanguages map[string]CustomLanguage `toml:"custom_languages,omitempty"`
+	Description     string                    `toml:"description"`
+	Env             map[string]Overlay        `toml:"env,omitempty"`
+	Language        string                    `toml:"language"`
+	Profile         string                    `toml:"profile,omitempty"`
+	LocalServer     LocalServer               `toml:"local_server,omitempty"`
+	ManifestVersion Version                   `toml:"manifest_version"`
+	Name            string                    `toml:"name"`
+	Scripts         Scripts                   `toml:"scripts,omitempty"`
+	ServiceID       string                    `toml:"service_id"`
+	Setup           Setup                     `toml:"setup,omitempty"`
 
 	quiet     bool
 	errLog    fsterr.LogInterface
 	exists    bool
 	output    io.Writer
+	overlays  *overlays
 	readError error
 }
 
@@ -209,16 +249,34 @@ func (f *File) SetQuiet(v bool) {
 	f.quiet = v
 }
 
+// CustomLanguage represents a C@E language defined as data, rather than being
+// built into the CLI (e.g. [custom_languages.zig]).
+//
+// NOTE: It mirrors the CLI application configuration's [language.custom.<T>]
+// so a project can define its own language toolchain.
+type CustomLanguage struct {
+	Build               string `toml:"build,omitempty"`
+	DisplayName         string `toml:"display_name,omitempty"`
+	Output              string `toml:"output,omitempty"`
+	SourceDirectory     string `toml:"source_directory,omitempty"`
+	Toolchain           string `toml:"toolchain,omitempty"`
+	ToolchainConstraint string `toml:"toolchain_constraint,omitempty"`
+	ToolchainVersion    string `toml:"toolchain_version,omitempty"`
+}
+
 // Scripts represents build configuration.
 type Scripts struct {
 	Build     string `toml:"build,omitempty"`
 	PostBuild string `toml:"post_build,omitempty"`
+	Test      string `toml:"test,omitempty"`
 }
 
 // Setup represents a set of service configuration that works with the code in
 // the package. See https://developer.fastly.com/reference/fastly-toml/.
 type Setup struct {
+	ACLs         map[string]*SetupACL         `toml:"acls,omitempty"`
 	Backends     map[string]*SetupBackend     `toml:"backends,omitempty"`
+	ConfigStores map[string]*SetupConfigStore `toml:"config_stores,omitempty"`
 	Dictionaries map[string]*SetupDictionary  `toml:"dictionaries,omitempty"`
 	Loggers      map[string]*SetupLogger      `toml:"log_endpoints,omitempty"`
 	KVStores     map[string]*SetupKVStore     `toml:"kv_stores,omitempty"`
@@ -241,6 +299,15 @@ func (s Setup) Defined() bool {
 	if len(s.KVStores) > 0 {
 		defined = true
 	}
+	if len(s.SecretStores) > 0 {
+		defined = true
+	}
+	if len(s.ConfigStores) > 0 {
+		defined = true
+	}
+	if len(s.ACLs) > 0 {
+		defined = true
+	}
 
 	return defined
 }
@@ -267,6 +334,39 @@ type SetupDictionaryItems struct {
 // SetupLogger represents a '[setup.log_endpoints.<T>]' instance.
 type SetupLogger struct {
 	Provider string `toml:"provider,omitempty"`
+	// Parameters are the API fields for the provider's log endpoint (e.g.
+	// bucket_name for s3). When set, the log endpoint is created during setup,
+	// otherwise the user is informed it needs to be created manually.
+	//
+	// NOTE: A string value of the form ${NAME} is read from the environment so
+	// that credentials don't need to be included in the manifest.
+	Parameters map[string]any `toml:"parameters,omitempty"`
+}
+
+// SetupConfigStore represents a '[setup.config_stores.<T>]' instance.
+type SetupConfigStore struct {
+	Items       map[string]SetupConfigStoreItems `toml:"items,omitempty"`
+	Description string                           `toml:"description,omitempty"`
+}
+
+// SetupConfigStoreItems represents a '[setup.config_stores.<T>.items]' instance.
+type SetupConfigStoreItems struct {
+	Value       string `toml:"value,omitempty"`
+	Description string `toml:"description,omitempty"`
+}
+
+// SetupACL represents a '[setup.acls.<T>]' instance.
+type SetupACL struct {
+	Entries     []SetupACLEntry `toml:"entries,omitempty"`
+	Description string          `toml:"description,omitempty"`
+}
+
+// SetupACLEntry represents a '[[setup.acls.<T>.entries]]' instance.
+type SetupACLEntry struct {
+	IP      string `toml:"ip"`
+	Subnet  int    `toml:"subnet,omitempty"`
+	Negated bool   `toml:"negated,omitempty"`
+	Comment string `toml:"comment,omitempty"`
 }
 
 // SetupKVStore represents a '[setup.kv_stores.<T>]' instance.
@@ -297,10 +397,15 @@ type SetupSecretStoreEntry struct {
 
 // LocalServer represents a list of mocked Viceroy resources.
 type LocalServer struct {
+	ACLs         map[string]LocalACL           `toml:"acls,omitempty"`
 	Backends     map[string]LocalBackend       `toml:"backends"`
+	ConfigStores map[string]LocalConfigStore   `toml:"config_stores,omitempty"`
 	Dictionaries map[string]LocalDictionary    `toml:"dictionaries,omitempty"`
 	KVStores     map[string][]LocalKVStore     `toml:"kv_stores,omitempty"`
 	SecretStores map[string][]LocalSecretStore `toml:"secret_stores,omitempty"`
+	// ViceroyVersion pins the Viceroy version (e.g. 0.4.5) or semver range
+	// (e.g. ~0.4) used to serve the project locally.
+	ViceroyVersion string `toml:"viceroy_version,omitempty"`
 }
 
 // LocalBackend represents a backend to be mocked by the local testing server.
@@ -309,6 +414,12 @@ type LocalBackend struct {
 	OverrideHost string `toml:"override_host,omitempty"`
 	CertHost     string `toml:"cert_host,omitempty"`
 	UseSNI       bool   `toml:"use_sni,omitempty"`
+	// Mock is a directory of recorded request/response fixtures. When set, the
+	// backend is served locally from the fixtures rather than the URL.
+	Mock string `toml:"mock,omitempty"`
+	// Record indicates requests without a fixture are forwarded to the URL and
+	// the response recorded as a new fixture.
+	Record bool `toml:"record,omitempty"`
 }
 
 // LocalDictionary represents a dictionary to be mocked by the local testing server.
@@ -318,6 +429,18 @@ type LocalDictionary struct {
 	Contents map[string]string `toml:"contents,omitempty"`
 }
 
+// LocalConfigStore represents a config_store to be mocked by the local testing server.
+type LocalConfigStore struct {
+	File     string            `toml:"file,omitempty"`
+	Format   string            `toml:"format"`
+	Contents map[string]string `toml:"contents,omitempty"`
+}
+
+// LocalACL represents an ACL to be mocked by the local testing server.
+type LocalACL struct {
+	File string `toml:"file"`
+}
+
 // LocalKVStore represents an kv_store to be mocked by the local testing server.
 type LocalKVStore struct {
 	Key  string `toml:"key"`
@@ -476,6 +599,10 @@ func (f *File) Load(data []byte) error {
 
 // Read loads the manifest file content from disk.
 func (f *File) Read(path string) (err error) {
+	if f.overlays == nil {
+		f.overlays = &overlays{}
+	}
+
 	defer func() {
 		if err != nil {
 			f.readError = err
@@ -301,3 +301,117 @@ func TestManifestPersistsLocalServerSection(t *testing.T) {
 		t.Fatalf("testing section between original and updated fastly.toml do not match (-want +got):\n%s", diff)
 	}
 }
+
+func TestManifestApplyEnv(t *testing.T) {
+	// We're going to chdir to a temporary directory,
+	// so save the PWD to return to, afterwards.
+	pwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootdir := testutil.NewEnv(testutil.EnvOpts{
+		T: t,
+		Write: []testutil.FileIO{
+			{Src: `
+			manifest_version = 2
+			name = "test"
+			service_id = "base"
+			profile = "base"
+			[setup.backends.origin]
+			address = "base.example.com"
+			[env.stage]
+			service_id = "stage"
+			profile = "stage"
+			[env.prod]
+			profile = "prod"`, Dst: manifest.Filename},
+			{Src: `
+			service_id = "prod"
+			[setup.backends.origin]
+			address = "prod.example.com"
+			[local_server.backends.origin]
+			url = "http://localhost:8080"`, Dst: "fastly.prod.toml"},
+		},
+	})
+	defer os.RemoveAll(rootdir)
+
+	if err := os.Chdir(rootdir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(pwd)
+
+	var d manifest.Data
+	if err := d.File.Read(manifest.Filename); err != nil {
+		t.Fatal(err)
+	}
+
+	err = d.File.ApplyEnv("dev")
+	testutil.AssertErrorContains(t, err, "no manifest configuration found for environment 'dev'")
+
+	// The [env.stage] section overrides the service_id and profile, but not
+	// [setup].
+	if err := d.File.ApplyEnv("stage"); err != nil {
+		t.Fatal(err)
+	}
+	sid, src := d.ServiceID()
+	testutil.AssertString(t, "stage", sid)
+	if src != manifest.SourceOverlay {
+		t.Fatal("expected SourceOverlay")
+	}
+	testutil.AssertString(t, "[env.stage] in fastly.toml", d.File.OverlaySource("service_id"))
+	setup, src := d.Setup()
+	testutil.AssertString(t, "base.example.com", setup.Backends["origin"].Address)
+	if src != manifest.SourceFile {
+		t.Fatal("expected SourceFile")
+	}
+
+	// The fastly.prod.toml file takes priority over the [env.prod] section.
+	if err := d.File.ApplyEnv("prod"); err != nil {
+		t.Fatal(err)
+	}
+	sid, _ = d.ServiceID()
+	testutil.AssertString(t, "prod", sid)
+	testutil.AssertString(t, "fastly.prod.toml", d.File.OverlaySource("service_id"))
+	profile, _ := d.Profile()
+	testutil.AssertString(t, "prod", profile)
+	testutil.AssertString(t, "[env.prod] in fastly.toml", d.File.OverlaySource("profile"))
+	setup, _ = d.Setup()
+	testutil.AssertString(t, "prod.example.com", setup.Backends["origin"].Address)
+
+	// Copies of the manifest share the applied overlays.
+	c := d
+	if err := d.File.ApplyEnv("stage"); err != nil {
+		t.Fatal(err)
+	}
+	sid, _ = c.ServiceID()
+	testutil.AssertString(t, "stage", sid)
+
+	// A Service ID is persisted to the [env.<T>] section...
+	if err := d.File.SetEnvServiceID("new-stage"); err != nil {
+		t.Fatal(err)
+	}
+	var m manifest.File
+	if err := m.Read(manifest.Filename); err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertString(t, "new-stage", m.Env["stage"].ServiceID)
+	testutil.AssertString(t, "base", m.ServiceID)
+
+	// ...or to the fastly.<T>.toml file, preserving its other fields.
+	if err := d.File.ApplyEnv("prod"); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.File.SetEnvServiceID("new-prod"); err != nil {
+		t.Fatal(err)
+	}
+	sid, _ = d.ServiceID()
+	testutil.AssertString(t, "new-prod", sid)
+	tree, err := toml.LoadFile("fastly.prod.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testutil.AssertString(t, "new-prod", tree.Get("service_id").(string))
+	if tree.Get("local_server") == nil {
+		t.Fatal("expected [local_server] block to exist in fastly.prod.toml but is missing")
+	}
+}
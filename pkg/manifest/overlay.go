@@ -0,0 +1,187 @@
+package manifest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	fsterr "github.com/fastly/cli/pkg/errors"
+	toml "github.com/pelletier/go-toml"
+)
+
+// Overlay represents the manifest settings that can be overridden for a
+// specific environment (e.g. stage), either by a '[env.<T>]' section of the
+// fastly.toml manifest or by a fastly.<T>.toml manifest file.
+type Overlay struct {
+	Profile   string `toml:"profile,omitempty"`
+	ServiceID string `toml:"service_id,omitempty"`
+	Setup     Setup  `toml:"setup,omitempty"`
+}
+
+// EnvFilename returns the name of the manifest file for an environment.
+func EnvFilename(env string) string {
+	return fmt.Sprintf("fastly.%s.toml", env)
+}
+
+// overlays holds the overlays applied for an environment, in order of
+// increasing priority.
+type overlays struct {
+	env    string
+	layers []overlay
+}
+
+// overlay is an Overlay along with a description of where it came from.
+type overlay struct {
+	Overlay
+	source string
+}
+
+// ApplyEnv applies the overlays for the given environment to the manifest.
+//
+// The '[env.<T>]' section of the fastly.toml manifest is applied first, then
+// the fastly.<T>.toml manifest file (if either exist). It's an error for
+// neither to exist.
+//
+// NOTE: The overlays are shared by every copy of the File made after it was
+// Read, so that commands constructed before the --env flag was parsed still
+// see the overlays applied once it has been.
+func (f *File) ApplyEnv(env string) error {
+	if f.overlays == nil {
+		f.overlays = &overlays{}
+	}
+	f.overlays.env = env
+	f.overlays.layers = nil
+
+	if o, ok := f.Env[env]; ok {
+		f.overlays.layers = append(f.overlays.layers, overlay{o, fmt.Sprintf("[env.%s] in %s", env, Filename)})
+	}
+
+	path := EnvFilename(env)
+	// gosec flagged this:
+	// G304 (CWE-22): Potential file inclusion via variable.
+	// Disabling as we need to load the environment manifest from the user's file system.
+	// This file is decoded into a predefined struct, any unrecognised fields are dropped.
+	/* #nosec */
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		var o Overlay
+		if err := toml.Unmarshal(data, &o); err != nil {
+			f.logErr(err)
+			return fmt.Errorf("error parsing %s: %w", path, err)
+		}
+		f.overlays.layers = append(f.overlays.layers, overlay{o, path})
+	case !errors.Is(err, os.ErrNotExist):
+		f.logErr(err)
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	if len(f.overlays.layers) == 0 {
+		err := fsterr.RemediationError{
+			Inner:       fmt.Errorf("no manifest configuration found for environment '%s'", env),
+			Remediation: fmt.Sprintf("Add an [env.%s] section to the %s manifest or create a %s manifest.", env, Filename, path),
+		}
+		f.logErr(err)
+		return err
+	}
+	return nil
+}
+
+// Environment returns the environment applied to the manifest, if any.
+func (f *File) Environment() string {
+	if f.overlays == nil {
+		return ""
+	}
+	return f.overlays.env
+}
+
+// OverlaySource returns where the overlay value of a manifest field (profile,
+// service_id or setup) came from, or an empty string if it isn't overridden.
+func (f *File) OverlaySource(field string) string {
+	_, source, _ := f.lookupOverlay(overlayFieldSet(field))
+	return source
+}
+
+// overlayFieldSet returns a function reporting whether an Overlay sets the
+// given manifest field.
+func overlayFieldSet(field string) func(Overlay) bool {
+	return func(o Overlay) bool {
+		switch field {
+		case "profile":
+			return o.Profile != ""
+		case "service_id":
+			return o.ServiceID != ""
+		case "setup":
+			return o.Setup.Defined()
+		}
+		return false
+	}
+}
+
+// lookupOverlay returns the highest priority overlay, and its source, for
+// which set returns true.
+func (f *File) lookupOverlay(set func(Overlay) bool) (Overlay, string, bool) {
+	if f.overlays == nil {
+		return Overlay{}, "", false
+	}
+	for i := len(f.overlays.layers) - 1; i >= 0; i-- {
+		if l := f.overlays.layers[i]; set(l.Overlay) {
+			return l.Overlay, l.source, true
+		}
+	}
+	return Overlay{}, "", false
+}
+
+// SetEnvServiceID persists a Service ID for the applied environment.
+//
+// The Service ID is written to the fastly.<T>.toml manifest file if it exists,
+// otherwise to the '[env.<T>]' section of the fastly.toml manifest.
+func (f *File) SetEnvServiceID(serviceID string) error {
+	env := f.Environment()
+	if env == "" {
+		return fmt.Errorf("no environment applied to the manifest")
+	}
+	layers := f.overlays.layers
+
+	path := EnvFilename(env)
+	tree, err := toml.LoadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		var base File
+		if err := base.Read(Filename); err != nil {
+			return err
+		}
+		if base.Env == nil {
+			base.Env = make(map[string]Overlay)
+		}
+		o := base.Env[env]
+		o.ServiceID = serviceID
+		base.Env[env] = o
+		if err := base.Write(Filename); err != nil {
+			return err
+		}
+		// The '[env.<T>]' section is always the lowest priority overlay.
+		if len(layers) > 0 {
+			layers[0].ServiceID = serviceID
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// NOTE: The fastly.<T>.toml manifest is updated via its toml tree, rather
+	// than decoding it into an Overlay, so that its other fields (e.g. those
+	// used by `compute serve`) are preserved.
+	tree.Set("service_id", serviceID)
+	data, err := tree.Marshal()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, FilePermissions); err != nil {
+		return err
+	}
+	if len(layers) > 0 {
+		layers[len(layers)-1].ServiceID = serviceID
+	}
+	return nil
+}
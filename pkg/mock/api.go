@@ -28,8 +28,12 @@ type API struct {
 	ActivateVersionFn   func(*fastly.ActivateVersionInput) (*fastly.Version, error)
 	DeactivateVersionFn func(*fastly.DeactivateVersionInput) (*fastly.Version, error)
 	LockVersionFn       func(*fastly.LockVersionInput) (*fastly.Version, error)
+	ValidateVersionFn   func(*fastly.ValidateVersionInput) (bool, string, error)
 	LatestVersionFn     func(*fastly.LatestVersionInput) (*fastly.Version, error)
 
+	GetSettingsFn    func(*fastly.GetSettingsInput) (*fastly.Settings, error)
+	UpdateSettingsFn func(*fastly.UpdateSettingsInput) (*fastly.Settings, error)
+
 	CreateDomainFn       func(*fastly.CreateDomainInput) (*fastly.Domain, error)
 	ListDomainsFn        func(*fastly.ListDomainsInput) ([]*fastly.Domain, error)
 	GetDomainFn          func(*fastly.GetDomainInput) (*fastly.Domain, error)
@@ -50,6 +54,51 @@ type API struct {
 	UpdateHealthCheckFn func(*fastly.UpdateHealthCheckInput) (*fastly.HealthCheck, error)
 	DeleteHealthCheckFn func(*fastly.DeleteHealthCheckInput) error
 
+	CreateConditionFn func(*fastly.CreateConditionInput) (*fastly.Condition, error)
+	ListConditionsFn  func(*fastly.ListConditionsInput) ([]*fastly.Condition, error)
+	GetConditionFn    func(*fastly.GetConditionInput) (*fastly.Condition, error)
+	UpdateConditionFn func(*fastly.UpdateConditionInput) (*fastly.Condition, error)
+	DeleteConditionFn func(*fastly.DeleteConditionInput) error
+
+	CreateHeaderFn func(*fastly.CreateHeaderInput) (*fastly.Header, error)
+	ListHeadersFn  func(*fastly.ListHeadersInput) ([]*fastly.Header, error)
+	GetHeaderFn    func(*fastly.GetHeaderInput) (*fastly.Header, error)
+	UpdateHeaderFn func(*fastly.UpdateHeaderInput) (*fastly.Header, error)
+	DeleteHeaderFn func(*fastly.DeleteHeaderInput) error
+
+	CreateDirectorFn func(*fastly.CreateDirectorInput) (*fastly.Director, error)
+	ListDirectorsFn  func(*fastly.ListDirectorsInput) ([]*fastly.Director, error)
+	GetDirectorFn    func(*fastly.GetDirectorInput) (*fastly.Director, error)
+	UpdateDirectorFn func(*fastly.UpdateDirectorInput) (*fastly.Director, error)
+	DeleteDirectorFn func(*fastly.DeleteDirectorInput) error
+
+	CreateDirectorBackendFn func(*fastly.CreateDirectorBackendInput) (*fastly.DirectorBackend, error)
+	DeleteDirectorBackendFn func(*fastly.DeleteDirectorBackendInput) error
+
+	CreateCacheSettingFn func(*fastly.CreateCacheSettingInput) (*fastly.CacheSetting, error)
+	ListCacheSettingsFn  func(*fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error)
+	GetCacheSettingFn    func(*fastly.GetCacheSettingInput) (*fastly.CacheSetting, error)
+	UpdateCacheSettingFn func(*fastly.UpdateCacheSettingInput) (*fastly.CacheSetting, error)
+	DeleteCacheSettingFn func(*fastly.DeleteCacheSettingInput) error
+
+	CreateRequestSettingFn func(*fastly.CreateRequestSettingInput) (*fastly.RequestSetting, error)
+	ListRequestSettingsFn  func(*fastly.ListRequestSettingsInput) ([]*fastly.RequestSetting, error)
+	GetRequestSettingFn    func(*fastly.GetRequestSettingInput) (*fastly.RequestSetting, error)
+	UpdateRequestSettingFn func(*fastly.UpdateRequestSettingInput) (*fastly.RequestSetting, error)
+	DeleteRequestSettingFn func(*fastly.DeleteRequestSettingInput) error
+
+	CreateResponseObjectFn func(*fastly.CreateResponseObjectInput) (*fastly.ResponseObject, error)
+	ListResponseObjectsFn  func(*fastly.ListResponseObjectsInput) ([]*fastly.ResponseObject, error)
+	GetResponseObjectFn    func(*fastly.GetResponseObjectInput) (*fastly.ResponseObject, error)
+	UpdateResponseObjectFn func(*fastly.UpdateResponseObjectInput) (*fastly.ResponseObject, error)
+	DeleteResponseObjectFn func(*fastly.DeleteResponseObjectInput) error
+
+	CreateGzipFn func(*fastly.CreateGzipInput) (*fastly.Gzip, error)
+	ListGzipsFn  func(*fastly.ListGzipsInput) ([]*fastly.Gzip, error)
+	GetGzipFn    func(*fastly.GetGzipInput) (*fastly.Gzip, error)
+	UpdateGzipFn func(*fastly.UpdateGzipInput) (*fastly.Gzip, error)
+	DeleteGzipFn func(*fastly.DeleteGzipInput) error
+
 	GetPackageFn    func(*fastly.GetPackageInput) (*fastly.Package, error)
 	UpdatePackageFn func(*fastly.UpdatePackageInput) (*fastly.Package, error)
 
@@ -229,6 +278,8 @@ type API struct {
 	UpdateVCLFn func(*fastly.UpdateVCLInput) (*fastly.VCL, error)
 	DeleteVCLFn func(*fastly.DeleteVCLInput) error
 
+	GetGeneratedVCLFn func(*fastly.GetGeneratedVCLInput) (*fastly.VCL, error)
+
 	CreateSnippetFn        func(i *fastly.CreateSnippetInput) (*fastly.Snippet, error)
 	ListSnippetsFn         func(i *fastly.ListSnippetsInput) ([]*fastly.Snippet, error)
 	GetSnippetFn           func(i *fastly.GetSnippetInput) (*fastly.Snippet, error)
@@ -448,11 +499,26 @@ func (m API) LockVersion(i *fastly.LockVersionInput) (*fastly.Version, error) {
 	return m.LockVersionFn(i)
 }
 
+// ValidateVersion implements Interface.
+func (m API) ValidateVersion(i *fastly.ValidateVersionInput) (bool, string, error) {
+	return m.ValidateVersionFn(i)
+}
+
 // LatestVersion implements Interface.
 func (m API) LatestVersion(i *fastly.LatestVersionInput) (*fastly.Version, error) {
 	return m.LatestVersionFn(i)
 }
 
+// GetSettings implements Interface.
+func (m API) GetSettings(i *fastly.GetSettingsInput) (*fastly.Settings, error) {
+	return m.GetSettingsFn(i)
+}
+
+// UpdateSettings implements Interface.
+func (m API) UpdateSettings(i *fastly.UpdateSettingsInput) (*fastly.Settings, error) {
+	return m.UpdateSettingsFn(i)
+}
+
 // CreateDomain implements Interface.
 func (m API) CreateDomain(i *fastly.CreateDomainInput) (*fastly.Domain, error) {
 	return m.CreateDomainFn(i)
@@ -538,6 +604,191 @@ func (m API) DeleteHealthCheck(i *fastly.DeleteHealthCheckInput) error {
 	return m.DeleteHealthCheckFn(i)
 }
 
+// CreateCondition implements Interface.
+func (m API) CreateCondition(i *fastly.CreateConditionInput) (*fastly.Condition, error) {
+	return m.CreateConditionFn(i)
+}
+
+// ListConditions implements Interface.
+func (m API) ListConditions(i *fastly.ListConditionsInput) ([]*fastly.Condition, error) {
+	return m.ListConditionsFn(i)
+}
+
+// GetCondition implements Interface.
+func (m API) GetCondition(i *fastly.GetConditionInput) (*fastly.Condition, error) {
+	return m.GetConditionFn(i)
+}
+
+// UpdateCondition implements Interface.
+func (m API) UpdateCondition(i *fastly.UpdateConditionInput) (*fastly.Condition, error) {
+	return m.UpdateConditionFn(i)
+}
+
+// DeleteCondition implements Interface.
+func (m API) DeleteCondition(i *fastly.DeleteConditionInput) error {
+	return m.DeleteConditionFn(i)
+}
+
+// CreateHeader implements Interface.
+func (m API) CreateHeader(i *fastly.CreateHeaderInput) (*fastly.Header, error) {
+	return m.CreateHeaderFn(i)
+}
+
+// ListHeaders implements Interface.
+func (m API) ListHeaders(i *fastly.ListHeadersInput) ([]*fastly.Header, error) {
+	return m.ListHeadersFn(i)
+}
+
+// GetHeader implements Interface.
+func (m API) GetHeader(i *fastly.GetHeaderInput) (*fastly.Header, error) {
+	return m.GetHeaderFn(i)
+}
+
+// UpdateHeader implements Interface.
+func (m API) UpdateHeader(i *fastly.UpdateHeaderInput) (*fastly.Header, error) {
+	return m.UpdateHeaderFn(i)
+}
+
+// DeleteHeader implements Interface.
+func (m API) DeleteHeader(i *fastly.DeleteHeaderInput) error {
+	return m.DeleteHeaderFn(i)
+}
+
+// CreateDirector implements Interface.
+func (m API) CreateDirector(i *fastly.CreateDirectorInput) (*fastly.Director, error) {
+	return m.CreateDirectorFn(i)
+}
+
+// ListDirectors implements Interface.
+func (m API) ListDirectors(i *fastly.ListDirectorsInput) ([]*fastly.Director, error) {
+	return m.ListDirectorsFn(i)
+}
+
+// GetDirector implements Interface.
+func (m API) GetDirector(i *fastly.GetDirectorInput) (*fastly.Director, error) {
+	return m.GetDirectorFn(i)
+}
+
+// UpdateDirector implements Interface.
+func (m API) UpdateDirector(i *fastly.UpdateDirectorInput) (*fastly.Director, error) {
+	return m.UpdateDirectorFn(i)
+}
+
+// DeleteDirector implements Interface.
+func (m API) DeleteDirector(i *fastly.DeleteDirectorInput) error {
+	return m.DeleteDirectorFn(i)
+}
+
+// CreateDirectorBackend implements Interface.
+func (m API) CreateDirectorBackend(i *fastly.CreateDirectorBackendInput) (*fastly.DirectorBackend, error) {
+	return m.CreateDirectorBackendFn(i)
+}
+
+// DeleteDirectorBackend implements Interface.
+func (m API) DeleteDirectorBackend(i *fastly.DeleteDirectorBackendInput) error {
+	return m.DeleteDirectorBackendFn(i)
+}
+
+// CreateCacheSetting implements Interface.
+func (m API) CreateCacheSetting(i *fastly.CreateCacheSettingInput) (*fastly.CacheSetting, error) {
+	return m.CreateCacheSettingFn(i)
+}
+
+// ListCacheSettings implements Interface.
+func (m API) ListCacheSettings(i *fastly.ListCacheSettingsInput) ([]*fastly.CacheSetting, error) {
+	return m.ListCacheSettingsFn(i)
+}
+
+// GetCacheSetting implements Interface.
+func (m API) GetCacheSetting(i *fastly.GetCacheSettingInput) (*fastly.CacheSetting, error) {
+	return m.GetCacheSettingFn(i)
+}
+
+// UpdateCacheSetting implements Interface.
+func (m API) UpdateCacheSetting(i *fastly.UpdateCacheSettingInput) (*fastly.CacheSetting, error) {
+	return m.UpdateCacheSettingFn(i)
+}
+
+// DeleteCacheSetting implements Interface.
+func (m API) DeleteCacheSetting(i *fastly.DeleteCacheSettingInput) error {
+	return m.DeleteCacheSettingFn(i)
+}
+
+// CreateRequestSetting implements Interface.
+func (m API) CreateRequestSetting(i *fastly.CreateRequestSettingInput) (*fastly.RequestSetting, error) {
+	return m.CreateRequestSettingFn(i)
+}
+
+// ListRequestSettings implements Interface.
+func (m API) ListRequestSettings(i *fastly.ListRequestSettingsInput) ([]*fastly.RequestSetting, error) {
+	return m.ListRequestSettingsFn(i)
+}
+
+// GetRequestSetting implements Interface.
+func (m API) GetRequestSetting(i *fastly.GetRequestSettingInput) (*fastly.RequestSetting, error) {
+	return m.GetRequestSettingFn(i)
+}
+
+// UpdateRequestSetting implements Interface.
+func (m API) UpdateRequestSetting(i *fastly.UpdateRequestSettingInput) (*fastly.RequestSetting, error) {
+	return m.UpdateRequestSettingFn(i)
+}
+
+// DeleteRequestSetting implements Interface.
+func (m API) DeleteRequestSetting(i *fastly.DeleteRequestSettingInput) error {
+	return m.DeleteRequestSettingFn(i)
+}
+
+// CreateResponseObject implements Interface.
+func (m API) CreateResponseObject(i *fastly.CreateResponseObjectInput) (*fastly.ResponseObject, error) {
+	return m.CreateResponseObjectFn(i)
+}
+
+// ListResponseObjects implements Interface.
+func (m API) ListResponseObjects(i *fastly.ListResponseObjectsInput) ([]*fastly.ResponseObject, error) {
+	return m.ListResponseObjectsFn(i)
+}
+
+// GetResponseObject implements Interface.
+func (m API) GetResponseObject(i *fastly.GetResponseObjectInput) (*fastly.ResponseObject, error) {
+	return m.GetResponseObjectFn(i)
+}
+
+// UpdateResponseObject implements Interface.
+func (m API) UpdateResponseObject(i *fastly.UpdateResponseObjectInput) (*fastly.ResponseObject, error) {
+	return m.UpdateResponseObjectFn(i)
+}
+
+// DeleteResponseObject implements Interface.
+func (m API) DeleteResponseObject(i *fastly.DeleteResponseObjectInput) error {
+	return m.DeleteResponseObjectFn(i)
+}
+
+// CreateGzip implements Interface.
+func (m API) CreateGzip(i *fastly.CreateGzipInput) (*fastly.Gzip, error) {
+	return m.CreateGzipFn(i)
+}
+
+// ListGzips implements Interface.
+func (m API) ListGzips(i *fastly.ListGzipsInput) ([]*fastly.Gzip, error) {
+	return m.ListGzipsFn(i)
+}
+
+// GetGzip implements Interface.
+func (m API) GetGzip(i *fastly.GetGzipInput) (*fastly.Gzip, error) {
+	return m.GetGzipFn(i)
+}
+
+// UpdateGzip implements Interface.
+func (m API) UpdateGzip(i *fastly.UpdateGzipInput) (*fastly.Gzip, error) {
+	return m.UpdateGzipFn(i)
+}
+
+// DeleteGzip implements Interface.
+func (m API) DeleteGzip(i *fastly.DeleteGzipInput) error {
+	return m.DeleteGzipFn(i)
+}
+
 // GetPackage implements Interface.
 func (m API) GetPackage(i *fastly.GetPackageInput) (*fastly.Package, error) {
 	return m.GetPackageFn(i)
@@ -1273,6 +1524,11 @@ func (m API) DeleteVCL(i *fastly.DeleteVCLInput) error {
 	return m.DeleteVCLFn(i)
 }
 
+// GetGeneratedVCL implements Interface.
+func (m API) GetGeneratedVCL(i *fastly.GetGeneratedVCLInput) (*fastly.VCL, error) {
+	return m.GetGeneratedVCLFn(i)
+}
+
 // CreateSnippet implements Interface.
 func (m API) CreateSnippet(i *fastly.CreateSnippetInput) (*fastly.Snippet, error) {
 	return m.CreateSnippetFn(i)
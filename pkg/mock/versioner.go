@@ -5,6 +5,7 @@ import "fmt"
 // AssetVersioner mocks the github.AssetVersioner interface.
 type AssetVersioner struct {
 	AssetVersion   string
+	AssetVersions  []string
 	BinaryFilename string
 	DownloadOK     bool
 	DownloadedFile string
@@ -23,6 +24,11 @@ func (av AssetVersioner) Download() (string, error) {
 	return "", fmt.Errorf("not implemented")
 }
 
+// DownloadVersion implements github.Versioner interface.
+func (av AssetVersioner) DownloadVersion(_ string) (string, error) {
+	return av.Download()
+}
+
 // URL implements github.Versioner interface.
 func (av AssetVersioner) URL() (string, error) {
 	return "", nil
@@ -32,3 +38,11 @@ func (av AssetVersioner) URL() (string, error) {
 func (av AssetVersioner) Version() (string, error) {
 	return av.AssetVersion, nil
 }
+
+// Versions implements github.Versioner interface.
+func (av AssetVersioner) Versions() ([]string, error) {
+	if av.AssetVersions == nil {
+		return []string{av.AssetVersion}, nil
+	}
+	return av.AssetVersions, nil
+}
@@ -103,7 +103,7 @@ func Edit(name string, p config.Profiles, opts ...EditOption) (config.Profiles,
 // if the default profile (if available) is acceptable to use instead.
 func Init(token string, m *manifest.Data, g *global.Data, in io.Reader, out io.Writer) (string, error) {
 	// First check the fastly.toml manifest 'profile' field.
-	profile := m.File.Profile
+	profile, _ := m.Profile()
 
 	// Otherwise check the --profile global flag.
 	if profile == "" {
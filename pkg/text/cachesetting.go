@@ -0,0 +1,22 @@
+package text
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/go-fastly/v8/fastly"
+	"github.com/segmentio/textio"
+)
+
+// PrintCacheSetting pretty prints a fastly.CacheSetting structure in verbose
+// format to a given io.Writer. Consumers can provide a prefix string which
+// will be used as a prefix to each line, useful for indentation.
+func PrintCacheSetting(out io.Writer, prefix string, s *fastly.CacheSetting) {
+	out = textio.NewPrefixWriter(out, prefix)
+
+	fmt.Fprintf(out, "Name: %s\n", s.Name)
+	fmt.Fprintf(out, "Action: %s\n", s.Action)
+	fmt.Fprintf(out, "TTL: %d\n", s.TTL)
+	fmt.Fprintf(out, "Stale TTL: %d\n", s.StaleTTL)
+	fmt.Fprintf(out, "Cache condition: %s\n", s.CacheCondition)
+}
@@ -0,0 +1,29 @@
+package text
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fastly/go-fastly/v8/fastly"
+	"github.com/segmentio/textio"
+)
+
+// PrintRequestSetting pretty prints a fastly.RequestSetting structure in
+// verbose format to a given io.Writer. Consumers can provide a prefix string
+// which will be used as a prefix to each line, useful for indentation.
+func PrintRequestSetting(out io.Writer, prefix string, s *fastly.RequestSetting) {
+	out = textio.NewPrefixWriter(out, prefix)
+
+	fmt.Fprintf(out, "Name: %s\n", s.Name)
+	fmt.Fprintf(out, "Action: %s\n", s.Action)
+	fmt.Fprintf(out, "X-Forwarded-For: %s\n", s.XForwardedFor)
+	fmt.Fprintf(out, "Default host: %s\n", s.DefaultHost)
+	fmt.Fprintf(out, "Hash keys: %s\n", s.HashKeys)
+	fmt.Fprintf(out, "Max stale age: %d\n", s.MaxStaleAge)
+	fmt.Fprintf(out, "Bypass busy wait: %t\n", s.BypassBusyWait)
+	fmt.Fprintf(out, "Force miss: %t\n", s.ForceMiss)
+	fmt.Fprintf(out, "Force SSL: %t\n", s.ForceSSL)
+	fmt.Fprintf(out, "Geo headers: %t\n", s.GeoHeaders)
+	fmt.Fprintf(out, "Timer support: %t\n", s.TimerSupport)
+	fmt.Fprintf(out, "Request condition: %s\n", s.RequestCondition)
+}